@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+// difficultyDir returns where custom difficulty profiles are looked up by
+// name, or "" if the platform has no config directory.
+func difficultyDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "keyforge", "difficulties")
+}
+
+// applyDifficulty sets the difficulty named by a --difficulty flag: a
+// built-in preset in any case, a profile NAME.yaml in the difficulties
+// config directory, or the path to a profile file. A name without a path
+// separator that matches no profile falls back to normal with a warning.
+func applyDifficulty(settings *engine.GameSettings, name string) error {
+	if preset := strings.ToLower(name); engine.IsBuiltinDifficulty(preset) {
+		settings.Difficulty = preset
+		return nil
+	}
+	path := name
+	isPath := strings.ContainsRune(name, os.PathSeparator)
+	if ext := filepath.Ext(name); ext != ".yaml" && ext != ".yml" && !isPath {
+		path = ""
+		if dir := difficultyDir(); dir != "" {
+			path = filepath.Join(dir, name+".yaml")
+		}
+	}
+	if !isPath && !profileExists(path) {
+		fmt.Fprintf(os.Stderr, "Warning: no difficulty profile %q, playing %s\n", name, engine.DifficultyNormal)
+		settings.Difficulty = engine.DifficultyNormal
+		return nil
+	}
+	profile, err := engine.LoadDifficultyProfile(path)
+	if err != nil {
+		return err
+	}
+	settings.UseProfile(profile)
+	return nil
+}
+
+// profileExists reports whether a profile file may be at path. Errors other
+// than its absence are left for loading the profile to report.
+func profileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
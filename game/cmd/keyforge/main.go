@@ -11,30 +11,77 @@ import (
 	"github.com/keyforge/keyforge/internal/ui"
 )
 
+// subcommands run instead of the game when named as the first argument.
+var subcommands = map[string]func([]string) error{
+	"solve":    runSolve,
+	"replay":   runReplay,
+	"simulate": runSimulate,
+}
+
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "keyforge %s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Connection flags
 	nvimMode := flag.Bool("nvim-mode", false, "Enable Neovim integration mode with RPC")
 	rpcSocket := flag.String("rpc-socket", "", "Unix socket path for RPC communication")
 
 	// Game settings flags (defaults from nvim config)
-	difficulty := flag.String("difficulty", "normal", "Difficulty level: easy, normal, hard")
+	difficulty := flag.String("difficulty", "normal", "Difficulty level: easy, normal, hard, or a custom profile name or file")
 	gameSpeed := flag.Float64("game-speed", 1.0, "Game speed multiplier: 0.5, 1.0, 1.5, 2.0")
 	startingGold := flag.Int("starting-gold", 200, "Starting gold amount (100-500)")
 	startingHealth := flag.Int("starting-health", 100, "Starting health (50-200)")
+	director := flag.Bool("director", false, "Adapt upcoming waves to how you're doing")
+	seed := flag.Uint64("seed", 0, "Random seed for reproducible games (0 = random)")
+	replayDir := flag.String("replay-dir", ui.DefaultReplayDir(), "Directory to save game replays in (empty disables recording)")
+	reportDir := flag.String("report-dir", ui.DefaultReportDir(), "Directory to export end-of-game reports to")
+	randomLevel := flag.String("random-level", "", "Random level ID to offer in level select, e.g. random-beginner-4242")
 
 	flag.Parse()
 
 	// Build settings from flags
 	settings := engine.GameSettings{
-		Difficulty:     *difficulty,
 		GameSpeed:      engine.GameSpeed(*gameSpeed),
 		StartingGold:   *startingGold,
 		StartingHealth: *startingHealth,
+		Seed:           *seed,
+		Director:       *director,
+	}
+	if err := applyDifficulty(&settings, *difficulty); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid difficulty: %v\n", err)
+		os.Exit(1)
 	}
 	settings.Validate()
 
 	model := ui.NewModelWithSettings(settings)
 	model.NvimMode = *nvimMode
+	model.ReportDir = *reportDir
+	if *randomLevel != "" {
+		levelDifficulty, levelSeed, ok := engine.ParseRandomLevelID(*randomLevel)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Invalid random level ID %q\n", *randomLevel)
+			os.Exit(1)
+		}
+		model.SetRandomLevel(levelDifficulty, levelSeed)
+	}
+	if path := ui.DefaultEndlessBestsPath(); path != "" {
+		bests, err := ui.LoadEndlessBests(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ignoring unreadable endless personal bests: %v\n", err)
+		}
+		model.EndlessBests = bests
+	}
+	if *replayDir != "" {
+		model.Recorder = ui.NewReplayRecorder(*replayDir)
+	}
 
 	// In nvim mode, start the RPC server/client
 	if *nvimMode {
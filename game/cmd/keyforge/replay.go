@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/ui"
+)
+
+// runReplay plays a recorded game back in the TUI.
+// Usage: keyforge replay [--speed N] [--paused] FILE.
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := fs.Float64("speed", 1.0, "Playback speed: 0.25, 0.5, 1, 2, 4, 8")
+	paused := fs.Bool("paused", false, "Start paused; step with [.]")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: keyforge replay [--speed N] [--paused] FILE")
+	}
+
+	replay, err := engine.LoadReplay(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	level := engine.NewLevelRegistry().GetByID(replay.LevelID)
+	if level == nil {
+		return fmt.Errorf("unknown level %q", replay.LevelID)
+	}
+
+	model := ui.NewReplayModel(replay, level)
+	model.Player.SetSpeed(*speed)
+	model.Player.Paused = *paused
+
+	_, err = tea.NewProgram(model, tea.WithAltScreen()).Run()
+	return err
+}
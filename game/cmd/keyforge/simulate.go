@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+// runSimulate plays a level headlessly with a scripted build order and
+// prints the statistics as JSON.
+// Usage: keyforge simulate --level ID [--difficulty D] [--seed N] [--runs N] [--director] [--build FILE].
+func runSimulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	levelID := fs.String("level", "level-1", "Level ID to simulate")
+	difficulty := fs.String("difficulty", engine.DifficultyNormal, "Difficulty: easy, normal, hard, or a custom profile name or file")
+	seed := fs.Uint64("seed", 1, "Random seed (first seed with --runs)")
+	runs := fs.Int("runs", 1, "Simulate this many consecutive seeds in parallel and print a JSON array")
+	startingGold := fs.Int("starting-gold", 200, "Starting gold amount (100-500)")
+	startingHealth := fs.Int("starting-health", 100, "Starting health (50-200)")
+	director := fs.Bool("director", false, "Let the adaptive difficulty director adjust upcoming waves")
+	buildFile := fs.String("build", "", "YAML build order: list of {wave, tower, x, y, upgrade}")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	level := engine.NewLevelRegistry().GetByID(*levelID)
+	if level == nil {
+		return fmt.Errorf("unknown level %q", *levelID)
+	}
+
+	var order []engine.BuildStep
+	if *buildFile != "" {
+		data, err := os.ReadFile(*buildFile)
+		if err != nil {
+			return err
+		}
+		if order, err = engine.ParseBuildOrder(data); err != nil {
+			return fmt.Errorf("%s: %w", *buildFile, err)
+		}
+	}
+	if *seed == 0 {
+		return errors.New("--seed must be non-zero")
+	}
+
+	settings := engine.GameSettings{
+		GameSpeed:      engine.SpeedNormal,
+		StartingGold:   *startingGold,
+		StartingHealth: *startingHealth,
+		Seed:           *seed,
+		Director:       *director,
+	}
+	if err := applyDifficulty(&settings, *difficulty); err != nil {
+		return err
+	}
+	settings.Validate()
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if *runs <= 1 {
+		return enc.Encode(engine.Simulate(level, settings, order))
+	}
+
+	seeds := make([]uint64, *runs)
+	for i := range seeds {
+		seeds[i] = *seed + uint64(i)
+	}
+	return enc.Encode(engine.SimulateSeeds(level, settings, order, seeds))
+}
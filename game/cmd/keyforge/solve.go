@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/ui"
+	"github.com/keyforge/keyforge/internal/vim"
+)
+
+// runSolve audits par_keystrokes by solving challenges offline.
+// Usage: keyforge solve [--id ID] [--category NAME] [--max-keys N] [--max-states N].
+func runSolve(args []string) error {
+	fs := flag.NewFlagSet("solve", flag.ExitOnError)
+	id := fs.String("id", "", "Solve a single challenge by ID")
+	category := fs.String("category", "", "Only solve challenges in this category")
+	maxKeys := fs.Int("max-keys", 0, "Longest key sequence to search (default 12)")
+	maxStates := fs.Int("max-states", 0, "Editor states to explore per challenge (default 200000)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cm, err := engine.NewChallengeManager()
+	if err != nil {
+		return fmt.Errorf("loading challenges: %w", err)
+	}
+
+	var challenges []engine.Challenge
+	for _, c := range cm.GetAllChallenges() {
+		if (*id == "" || c.ID == *id) && (*category == "" || c.Category == *category) {
+			challenges = append(challenges, c)
+		}
+	}
+	if len(challenges) == 0 {
+		return errors.New("no matching challenges")
+	}
+
+	cfg := vim.DefaultSolverConfig()
+	if *maxKeys > 0 {
+		cfg.MaxKeystrokes = *maxKeys
+	}
+	if *maxStates > 0 {
+		cfg.MaxStates = *maxStates
+	}
+
+	writeSolveReport(os.Stdout, challenges, cfg)
+	return nil
+}
+
+// writeSolveReport prints one line per challenge comparing par to the optimum.
+func writeSolveReport(out io.Writer, challenges []engine.Challenge, cfg vim.SolverConfig) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPAR\tOPTIMAL\tKEYS\tNOTE")
+
+	var solved, mismatched int
+	for i := range challenges {
+		c := &challenges[i]
+		if !ui.Solvable(c) {
+			fmt.Fprintf(w, "%s\t%d\t-\t-\t%s\n", c.ID, c.ParKeystrokes, "skipped (plugin or free-form)")
+			continue
+		}
+		solution, err := ui.SolveChallenge(c, cfg)
+		if err != nil {
+			fmt.Fprintf(w, "%s\t%d\t-\t-\t%s\n", c.ID, c.ParKeystrokes, "not solved within bounds")
+			continue
+		}
+		solved++
+
+		note := ""
+		switch {
+		case solution.Keystrokes < c.ParKeystrokes:
+			note = "par above optimal"
+			mismatched++
+		case solution.Keystrokes > c.ParKeystrokes:
+			note = "par below optimal"
+			mismatched++
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", c.ID, c.ParKeystrokes, solution.Keystrokes, solution, note)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(out, "\n%d/%d solved, %d with par != optimal\n", solved, len(challenges), mismatched)
+}
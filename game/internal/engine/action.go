@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// ActionKind identifies a player input that changes the simulation.
+type ActionKind string
+
+const (
+	ActionMoveCursor     ActionKind = "move_cursor"
+	ActionSelectTower    ActionKind = "select_tower"
+	ActionPlaceTower     ActionKind = "place_tower"
+	ActionUpgradeTower   ActionKind = "upgrade_tower"
+	ActionTogglePause    ActionKind = "toggle_pause"
+	ActionStartChallenge ActionKind = "start_challenge"
+	ActionEndChallenge   ActionKind = "end_challenge"
+	ActionCallWave       ActionKind = "call_wave"
+	ActionSellTower      ActionKind = "sell_tower"
+	ActionUndo           ActionKind = "undo"
+	ActionRedo           ActionKind = "redo"
+	ActionStartWave      ActionKind = "start_wave"
+)
+
+// Action is one player input, stamped with the tick it was applied on.
+// Applying the same actions on the same ticks to a game with the same seed
+// reproduces the game exactly.
+type Action struct {
+	Tick uint64     `json:"tick"`
+	Kind ActionKind `json:"kind"`
+
+	// Cursor delta for move_cursor, grid position for place/upgrade
+	DX int `json:"dx,omitempty"`
+	DY int `json:"dy,omitempty"`
+	X  int `json:"x,omitempty"`
+	Y  int `json:"y,omitempty"`
+
+	Tower  entities.TowerType `json:"tower,omitempty"`
+	Branch string             `json:"branch,omitempty"` // Specialisation for upgrade_tower
+
+	// Challenge outcome; Waiting pauses the game while the challenge runs
+	ChallengeID string  `json:"challenge_id,omitempty"`
+	Category    string  `json:"category,omitempty"`
+	Waiting     bool    `json:"waiting,omitempty"`
+	Success     bool    `json:"success,omitempty"`
+	Gold        int     `json:"gold,omitempty"`
+	Efficiency  float64 `json:"efficiency,omitempty"` // Par over keystrokes, charges the challenge's tower
+	SpeedBonus  float64 `json:"speed_bonus,omitempty"`
+	Elapsed     float64 `json:"elapsed,omitempty"` // Seconds the player spent, for reference
+}
+
+// Apply performs an action and reports whether it changed anything.
+// Challenges are applied by outcome, so a replay needs neither the editor
+// nor Neovim to reproduce them.
+func (g *Game) Apply(a Action) bool {
+	switch a.Kind {
+	case ActionMoveCursor:
+		x, y := g.CursorX, g.CursorY
+		g.MoveCursor(a.DX, a.DY)
+		return g.CursorX != x || g.CursorY != y
+	case ActionSelectTower:
+		g.SelectTower(a.Tower)
+		return true
+	case ActionPlaceTower:
+		g.CursorX, g.CursorY = a.X, a.Y
+		return g.PlaceTower()
+	case ActionUpgradeTower:
+		g.CursorX, g.CursorY = a.X, a.Y
+		if a.Branch != "" {
+			return g.SpecializeTower(a.Branch)
+		}
+		return g.UpgradeTower()
+	case ActionTogglePause:
+		state := g.State
+		g.TogglePause()
+		return g.State != state
+	case ActionStartChallenge:
+		running := g.ChallengeActive
+		if a.Waiting {
+			g.StartChallengeWaiting()
+		} else {
+			g.StartChallenge()
+		}
+		if g.ChallengeActive && !running {
+			g.ChallengeCategory = a.Category
+		}
+		return g.ChallengeActive
+	case ActionCallWave:
+		return g.CallNextWave()
+	case ActionSellTower:
+		g.CursorX, g.CursorY = a.X, a.Y
+		return g.SellTower()
+	case ActionUndo:
+		return g.Undo()
+	case ActionRedo:
+		return g.Redo()
+	case ActionStartWave:
+		return g.StartWave()
+	case ActionEndChallenge:
+		if !g.ChallengeActive {
+			return false
+		}
+		gold := 0
+		if a.Success {
+			gold = a.Gold
+			g.addGold(gold, GoldSourceChallenge, a.SpeedBonus)
+			g.breakBossShields()
+			g.chargeTower(a.Efficiency, a.SpeedBonus)
+		}
+		category := g.ChallengeCategory
+		g.EndChallenge()
+		g.Events.Publish(ChallengeEnded{Success: a.Success, Gold: gold, Category: category})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// BossPhaseKind is what a boss does when a phase starts. The values match
+// the "kind" field of the boss_phase RPC notification.
+type BossPhaseKind string
+
+const (
+	BossPhaseMinions       BossPhaseKind = "minions"
+	BossPhaseSpeedBurst    BossPhaseKind = "speed_burst"
+	BossPhaseDisableTowers BossPhaseKind = "disable_towers"
+	BossPhaseShield        BossPhaseKind = "shield"
+	// BossPhaseShieldBroken isn't a health threshold: it follows a shield
+	// once the player completes a challenge.
+	BossPhaseShieldBroken BossPhaseKind = "shield_broken"
+)
+
+// BossPhase starts when a boss's health drops to Threshold of its maximum.
+type BossPhase struct {
+	Threshold float64
+	Kind      BossPhaseKind
+}
+
+// BossPhases lists the phases of each boss enemy, highest threshold first.
+var BossPhases = map[entities.EnemyType][]BossPhase{
+	entities.EnemyBoss: {
+		{Threshold: 0.8, Kind: BossPhaseMinions},
+		{Threshold: 0.6, Kind: BossPhaseSpeedBurst},
+		{Threshold: 0.4, Kind: BossPhaseDisableTowers},
+		{Threshold: 0.2, Kind: BossPhaseShield},
+	},
+}
+
+const (
+	bossMinions         = 3
+	bossMinionType      = entities.EnemyBug
+	bossBurstSpeed      = 2.0 // Speed multiplier during a speed burst
+	bossBurstDuration   = 3.0 // Seconds
+	bossDisabledTowers  = 2   // Nearest towers switched off
+	bossDisableDuration = 5.0 // Seconds
+)
+
+// Boss is the fight state of a boss on the field.
+type Boss struct {
+	Enemy     *entities.Enemy
+	Phases    []BossPhase
+	Phase     int     // Phases started so far
+	Shielded  bool    // Takes no damage until the player completes a challenge
+	BurstLeft float64 // Seconds of speed burst left
+}
+
+// CurrentPhase returns the kind of the latest phase started, or "" before
+// the first.
+func (b *Boss) CurrentPhase() BossPhaseKind {
+	if b.Phase == 0 {
+		return ""
+	}
+	return b.Phases[b.Phase-1].Kind
+}
+
+// phaseHealth is the health at or below which phase i starts.
+func (b *Boss) phaseHealth(i int) int {
+	return int(b.Phases[i].Threshold * float64(b.Enemy.MaxHealth))
+}
+
+// absorb returns how much of a hit gets through: none while shielded, and
+// never more than reaches the next phase, so one big hit can't skip it.
+func (b *Boss) absorb(damage int) int {
+	if b.Shielded {
+		return 0
+	}
+	if b.Phase < len(b.Phases) {
+		return min(damage, max(0, b.Enemy.Health-b.phaseHealth(b.Phase)))
+	}
+	return damage
+}
+
+// ActiveBoss returns the first boss still alive on the field, or nil.
+func (g *Game) ActiveBoss() *Boss {
+	for _, b := range g.Bosses {
+		if !b.Enemy.Dead {
+			return b
+		}
+	}
+	return nil
+}
+
+// bossFor returns the fight state of an enemy, or nil if it isn't a boss.
+func (g *Game) bossFor(e *entities.Enemy) *Boss {
+	for _, b := range g.Bosses {
+		if b.Enemy == e {
+			return b
+		}
+	}
+	return nil
+}
+
+// updateBosses ends speed bursts and forgets bosses that died or leaked.
+func (g *Game) updateBosses(dt float64) {
+	g.Bosses = slices.DeleteFunc(g.Bosses, func(b *Boss) bool { return b.Enemy.Dead })
+	for _, b := range g.Bosses {
+		if b.BurstLeft <= 0 {
+			continue
+		}
+		b.BurstLeft -= dt
+		if b.BurstLeft <= 0 {
+			b.BurstLeft = 0
+			b.Enemy.Speed = b.Enemy.Info().Speed
+		}
+	}
+}
+
+// advanceBoss starts every phase the boss's health has dropped to.
+func (g *Game) advanceBoss(b *Boss) {
+	for !b.Enemy.Dead && b.Phase < len(b.Phases) && b.Enemy.Health <= b.phaseHealth(b.Phase) {
+		kind := b.Phases[b.Phase].Kind
+		b.Phase++
+		switch kind {
+		case BossPhaseMinions:
+			g.spawnMinions(b.Enemy)
+		case BossPhaseSpeedBurst:
+			b.BurstLeft = bossBurstDuration
+			b.Enemy.Speed = b.Enemy.Info().Speed * bossBurstSpeed
+		case BossPhaseDisableTowers:
+			g.disableTowersNear(b.Enemy.Pos)
+		case BossPhaseShield:
+			b.Shielded = true
+		case BossPhaseShieldBroken:
+			// Only a challenge breaks a shield
+		}
+		g.Events.Publish(BossPhaseChanged{Boss: b, Kind: kind})
+	}
+}
+
+// breakBossShields drops the shield of every boss on the field; completing a
+// challenge is the only way to do it.
+func (g *Game) breakBossShields() {
+	for _, b := range g.Bosses {
+		if b.Shielded && !b.Enemy.Dead {
+			b.Shielded = false
+			g.Events.Publish(BossPhaseChanged{Boss: b, Kind: BossPhaseShieldBroken})
+		}
+	}
+}
+
+// spawnMinions spawns minions where the boss stands; they walk the rest of
+// its route.
+func (g *Game) spawnMinions(boss *entities.Enemy) {
+	for range bossMinions {
+		minion := entities.NewEnemy(g.enemyIDs.Next(), bossMinionType, boss.Pos)
+		minion.Path = boss.Path
+		minion.PathIndex = boss.PathIndex
+		minion.PathProg = boss.PathProg
+		if g.Maze != nil {
+			g.enemyRoutes[minion.ID] = slices.Clone(g.enemyRoutes[boss.ID])
+		}
+		g.Enemies = append(g.Enemies, minion)
+		g.Events.Publish(EnemySpawned{Enemy: minion})
+	}
+}
+
+// disableTowersNear switches off the towers closest to pos for a while.
+// Ties go to the tower built first.
+func (g *Game) disableTowersNear(pos entities.Position) {
+	distance := func(t *entities.Tower) float64 {
+		dx, dy := t.Pos.X-pos.X, t.Pos.Y-pos.Y
+		return dx*dx + dy*dy
+	}
+	towers := slices.Clone(g.Towers)
+	slices.SortStableFunc(towers, func(a, b *entities.Tower) int {
+		return cmp.Compare(distance(a), distance(b))
+	})
+	for _, t := range towers[:min(bossDisabledTowers, len(towers))] {
+		t.DisabledLeft = bossDisableDuration
+	}
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// newBossGame returns a game with a single boss on the main path and no waves.
+func newBossGame(t *testing.T) (*Game, *Boss) {
+	t.Helper()
+	g := NewGame(20, 20)
+	g.WaveFunc = func(int) Wave { return Wave{} }
+	g.SpawnEnemy(entities.EnemyBoss)
+	if len(g.Bosses) != 1 {
+		t.Fatalf("Expected the boss to get fight state, got %d bosses", len(g.Bosses))
+	}
+	return g, g.Bosses[0]
+}
+
+// hitEnemy lands a projectile with the given damage on an enemy.
+func hitEnemy(g *Game, e *entities.Enemy, damage int) {
+	g.Projectiles = append(g.Projectiles, &entities.Projectile{Pos: e.Pos, Target: e.Pos, Damage: damage, TargetID: e.ID})
+	g.updateProjectiles(0)
+}
+
+func TestBossPhasesFollowHealthThresholds(t *testing.T) {
+	g, boss := newBossGame(t)
+	var phases []BossPhaseKind
+	g.Events.Subscribe(func(e Event) {
+		if p, ok := e.(BossPhaseChanged); ok {
+			phases = append(phases, p.Kind)
+		}
+	})
+
+	// One huge hit stops at the first threshold instead of skipping phases
+	hitEnemy(g, boss.Enemy, 10_000)
+	if boss.Enemy.Dead || boss.Enemy.Health != boss.phaseHealth(0) {
+		t.Fatalf("Boss health = %d, want it held at the first threshold %d", boss.Enemy.Health, boss.phaseHealth(0))
+	}
+	if boss.CurrentPhase() != BossPhaseMinions {
+		t.Errorf("Phase = %q, want minions", boss.CurrentPhase())
+	}
+	if minions := len(g.Enemies) - 1; minions != bossMinions {
+		t.Errorf("Boss spawned %d minions, want %d", minions, bossMinions)
+	}
+	for _, e := range g.Enemies[1:] {
+		if e.Pos != boss.Enemy.Pos || e.PathIndex != boss.Enemy.PathIndex {
+			t.Errorf("Minion starts at %v index %d, want the boss's %v index %d",
+				e.Pos, e.PathIndex, boss.Enemy.Pos, boss.Enemy.PathIndex)
+		}
+	}
+
+	for range 3 {
+		hitEnemy(g, boss.Enemy, 10_000)
+	}
+	want := []BossPhaseKind{BossPhaseMinions, BossPhaseSpeedBurst, BossPhaseDisableTowers, BossPhaseShield}
+	if len(phases) != len(want) {
+		t.Fatalf("Phases = %v, want %v", phases, want)
+	}
+	for i := range want {
+		if phases[i] != want[i] {
+			t.Errorf("Phase %d = %q, want %q", i, phases[i], want[i])
+		}
+	}
+}
+
+func TestBossSpeedBurstWearsOff(t *testing.T) {
+	g, boss := newBossGame(t)
+	hitEnemy(g, boss.Enemy, 10_000)
+	hitEnemy(g, boss.Enemy, 10_000)
+
+	base := boss.Enemy.Info().Speed
+	if boss.Enemy.Speed != base*bossBurstSpeed {
+		t.Fatalf("Speed during burst = %v, want %v", boss.Enemy.Speed, base*bossBurstSpeed)
+	}
+	g.updateBosses(bossBurstDuration)
+	if boss.Enemy.Speed != base || boss.BurstLeft != 0 {
+		t.Errorf("Speed after burst = %v (%v left), want %v", boss.Enemy.Speed, boss.BurstLeft, base)
+	}
+}
+
+func TestBossDisablesNearestTowers(t *testing.T) {
+	g, boss := newBossGame(t)
+	g.Gold = 10_000
+	g.Projectiles = nil
+	boss.Enemy.Pos = entities.Position{X: 5, Y: 5}
+	var towers []*entities.Tower
+	for _, x := range []int{6, 12, 7} {
+		g.CursorX, g.CursorY = x, 4
+		if !g.PlaceTower() {
+			t.Fatalf("Could not place a tower at %d,4", x)
+		}
+		towers = append(towers, g.Towers[len(g.Towers)-1])
+	}
+
+	for range 3 {
+		hitEnemy(g, boss.Enemy, 10_000)
+	}
+	if !towers[0].Disabled() || !towers[2].Disabled() || towers[1].Disabled() {
+		t.Fatalf("Disabled = %v %v %v, want the two nearest towers only",
+			towers[0].Disabled(), towers[1].Disabled(), towers[2].Disabled())
+	}
+	if p := towers[0].Update(0.1, g.Enemies, &g.projectileIDs); p != nil {
+		t.Error("Disabled tower fired")
+	}
+	towers[0].Update(bossDisableDuration, g.Enemies, &g.projectileIDs)
+	if towers[0].Disabled() {
+		t.Error("Tower still disabled after the duration")
+	}
+}
+
+func TestBossShieldBreaksOnChallenge(t *testing.T) {
+	g, boss := newBossGame(t)
+	for range 4 {
+		hitEnemy(g, boss.Enemy, 10_000)
+	}
+	if !boss.Shielded {
+		t.Fatal("Expected the boss to raise its shield")
+	}
+
+	health := boss.Enemy.Health
+	hitEnemy(g, boss.Enemy, 10_000)
+	if boss.Enemy.Health != health {
+		t.Fatalf("Shielded boss took damage: %d -> %d", health, boss.Enemy.Health)
+	}
+
+	// A failed challenge leaves the shield up
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.Apply(Action{Kind: ActionEndChallenge, Success: false})
+	if !boss.Shielded {
+		t.Fatal("A failed challenge broke the shield")
+	}
+
+	var broken bool
+	g.Events.Subscribe(func(e Event) {
+		if p, ok := e.(BossPhaseChanged); ok && p.Kind == BossPhaseShieldBroken {
+			broken = true
+		}
+	})
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.Apply(Action{Kind: ActionEndChallenge, Success: true, Gold: 10})
+	if boss.Shielded || !broken {
+		t.Fatalf("Shielded = %v, broken event = %v after a successful challenge", boss.Shielded, broken)
+	}
+
+	hitEnemy(g, boss.Enemy, 10_000)
+	if !boss.Enemy.Dead {
+		t.Error("Unshielded boss survived a lethal hit")
+	}
+	g.updateBosses(0)
+	if g.ActiveBoss() != nil || len(g.Bosses) != 0 {
+		t.Error("Dead boss still tracked")
+	}
+}
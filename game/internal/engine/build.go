@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"math"
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// sellRefund is the share of the gold paid for a tower, upgrades included,
+// that selling it pays back.
+const sellRefund = 0.7
+
+// buildCommand is a change to the towers made in a build phase, which the
+// player can undo and redo until the wave starts.
+type buildCommand interface {
+	undo(g *Game)
+	redo(g *Game) bool
+}
+
+// placeCommand is a tower bought in the build phase.
+type placeCommand struct {
+	tower *entities.Tower
+	cost  int
+}
+
+func (c *placeCommand) undo(g *Game) {
+	g.removeTower(c.tower)
+	g.addGold(c.cost, GoldSourceRefund, 0)
+	g.Events.Publish(TowerRemoved{Tower: c.tower, Refund: c.cost})
+}
+
+func (c *placeCommand) redo(g *Game) bool {
+	if g.Gold < c.cost {
+		return false
+	}
+	c.tower.Paid = c.cost
+	g.addTower(c.tower)
+	g.addGold(-c.cost, GoldSourceTower, 0)
+	g.Events.Publish(TowerPlaced{Tower: c.tower, Cost: c.cost})
+	return true
+}
+
+// upgradeCommand is a tower upgrade or branch bought in the build phase,
+// with the stats the tower had before it.
+type upgradeCommand struct {
+	tower    *entities.Tower
+	cost     int
+	branch   string // Branch taken, "" for an upgrade tier
+	level    int
+	damage   int
+	rng      float64
+	cooldown float64
+}
+
+func (c *upgradeCommand) undo(g *Game) {
+	t := c.tower
+	t.Level, t.Damage, t.Range, t.Cooldown = c.level, c.damage, c.rng, c.cooldown
+	if c.branch != "" {
+		t.Branch = ""
+	}
+	t.Paid -= c.cost
+	g.addGold(c.cost, GoldSourceRefund, 0)
+}
+
+func (c *upgradeCommand) redo(g *Game) bool {
+	if g.Gold < c.cost {
+		return false
+	}
+	upgraded := false
+	if c.branch != "" {
+		upgraded = c.tower.Specialize(c.branch)
+	} else {
+		upgraded = c.tower.Upgrade()
+	}
+	if !upgraded {
+		return false
+	}
+	c.tower.Paid += c.cost
+	g.addGold(-c.cost, GoldSourceUpgrade, 0)
+	g.Events.Publish(TowerUpgraded{Tower: c.tower, Cost: c.cost})
+	return true
+}
+
+// sellCommand is a tower sold in the build phase.
+type sellCommand struct {
+	tower  *entities.Tower
+	refund int
+}
+
+func (c *sellCommand) undo(g *Game) {
+	g.addTower(c.tower)
+	g.addGold(-c.refund, GoldSourceTower, 0)
+	g.Events.Publish(TowerPlaced{Tower: c.tower, Cost: c.refund})
+}
+
+func (c *sellCommand) redo(g *Game) bool {
+	g.removeTower(c.tower)
+	g.addGold(c.refund, GoldSourceRefund, 0)
+	g.Events.Publish(TowerRemoved{Tower: c.tower, Refund: c.refund})
+	return true
+}
+
+// record puts a change on the undo stack while building. A new change
+// drops whatever was undone before it.
+func (g *Game) record(c buildCommand) {
+	if !g.Building {
+		return
+	}
+	g.undoStack = append(g.undoStack, c)
+	g.redoStack = nil
+}
+
+// CanUndo reports whether the build phase has a change to undo.
+func (g *Game) CanUndo() bool {
+	return g.Building && len(g.undoStack) > 0
+}
+
+// CanRedo reports whether the build phase has an undone change to redo.
+func (g *Game) CanRedo() bool {
+	return g.Building && len(g.redoStack) > 0
+}
+
+// Undo reverts the latest change of the build phase and refunds it in full.
+func (g *Game) Undo() bool {
+	if !g.CanUndo() {
+		return false
+	}
+	c := g.undoStack[len(g.undoStack)-1]
+	g.undoStack = g.undoStack[:len(g.undoStack)-1]
+	c.undo(g)
+	g.redoStack = append(g.redoStack, c)
+	return true
+}
+
+// Redo applies the latest undone change again.
+func (g *Game) Redo() bool {
+	if !g.CanRedo() {
+		return false
+	}
+	c := g.redoStack[len(g.redoStack)-1]
+	if !c.redo(g) {
+		return false
+	}
+	g.redoStack = g.redoStack[:len(g.redoStack)-1]
+	g.undoStack = append(g.undoStack, c)
+	return true
+}
+
+// StartWave ends the build phase and sends the next wave. Changes made in
+// the build phase can't be undone after this.
+func (g *Game) StartWave() bool {
+	if !g.Building {
+		return false
+	}
+	g.Building = false
+	g.undoStack, g.redoStack = nil, nil
+	if g.WaveComplete {
+		g.startNextWave()
+	}
+	return true
+}
+
+// SellValue returns the gold selling a tower pays back.
+func (g *Game) SellValue(t *entities.Tower) int {
+	return int(math.Round(float64(t.Paid) * sellRefund))
+}
+
+// SellTower sells the tower at the cursor position.
+func (g *Game) SellTower() bool {
+	tower := g.GetTowerAt(g.CursorX, g.CursorY)
+	if tower == nil {
+		return false
+	}
+	c := &sellCommand{tower: tower, refund: g.SellValue(tower)}
+	c.redo(g)
+	g.record(c)
+	return true
+}
+
+// addTower puts a tower on the grid.
+func (g *Game) addTower(t *entities.Tower) {
+	g.Towers = append(g.Towers, t)
+	if g.Maze != nil {
+		g.replanMaze()
+	}
+}
+
+// removeTower takes a tower off the grid.
+func (g *Game) removeTower(t *entities.Tower) {
+	g.Towers = slices.DeleteFunc(g.Towers, func(other *entities.Tower) bool { return other == t })
+	if g.Maze != nil {
+		g.replanMaze()
+	}
+}
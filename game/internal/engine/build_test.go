@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func newBuildGame(t *testing.T) *Game {
+	t.Helper()
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.BuildPhase = true
+	g := NewGameFromLevelAndSettings(&level, settings)
+	g.Gold = 1000
+	return g
+}
+
+// buildSpot returns a cell next to the path where a tower can go.
+func buildSpot(t *testing.T, g *Game) (int, int) {
+	t.Helper()
+	for y := range g.Height {
+		for x := range g.Width {
+			if g.CanPlaceTower(x, y) {
+				return x, y
+			}
+		}
+	}
+	t.Fatal("No free cell on the level")
+	return 0, 0
+}
+
+func TestBuildPhaseFreezesSimulation(t *testing.T) {
+	g := newBuildGame(t)
+	if !g.Building {
+		t.Fatal("Expected the game to start in a build phase")
+	}
+	for range 300 {
+		g.Step()
+	}
+	if len(g.Enemies) != 0 || g.SpawnIndex != 0 {
+		t.Fatalf("Enemies spawned during the build phase: %d", len(g.Enemies))
+	}
+	if g.UpcomingWave() != 1 {
+		t.Errorf("UpcomingWave() = %d, want 1 before the first wave", g.UpcomingWave())
+	}
+
+	if !g.Apply(Action{Kind: ActionStartWave}) || g.Building {
+		t.Fatal("Starting the wave didn't end the build phase")
+	}
+	g.Step()
+	if len(g.Enemies) == 0 {
+		t.Error("Wave 1 didn't start after the build phase")
+	}
+}
+
+func TestBuildPhaseUndoRedo(t *testing.T) {
+	g := newBuildGame(t)
+	x, y := buildSpot(t, g)
+	gold := g.Gold
+
+	placeAt(g, x, y)
+	tower := g.GetTowerAt(x, y)
+	g.CursorX, g.CursorY = x, y
+	if !g.UpgradeTower() {
+		t.Fatal("Upgrade failed")
+	}
+	spent := gold - g.Gold
+
+	if !g.Undo() || tower.Level != 0 || tower.Damage != entities.TowerTypes[tower.Type].Damage {
+		t.Fatalf("Undoing the upgrade left level %d damage %d", tower.Level, tower.Damage)
+	}
+	if !g.Undo() || g.HasTower(x, y) || g.Gold != gold {
+		t.Fatalf("Undoing the placement: tower %v, gold %d want %d", g.HasTower(x, y), g.Gold, gold)
+	}
+	if g.Undo() {
+		t.Error("Undid past the start of the build phase")
+	}
+
+	if !g.Redo() || !g.Redo() || g.GetTowerAt(x, y) != tower || tower.Level != 1 || gold-g.Gold != spent {
+		t.Fatalf("Redo didn't restore the upgraded tower (level %d, spent %d want %d)", tower.Level, gold-g.Gold, spent)
+	}
+
+	// A new change drops what was undone
+	g.Undo()
+	g.Apply(Action{Kind: ActionSellTower, X: x, Y: y})
+	if g.HasTower(x, y) || g.CanRedo() {
+		t.Fatalf("After selling: tower %v, can redo %v", g.HasTower(x, y), g.CanRedo())
+	}
+	if !g.Undo() || g.GetTowerAt(x, y) != tower {
+		t.Fatal("Undoing the sale didn't bring the tower back")
+	}
+
+	g.StartWave()
+	if g.CanUndo() || g.Undo() {
+		t.Error("Build phase changes can still be undone after the wave started")
+	}
+}
+
+func TestBuildPhaseBetweenWaves(t *testing.T) {
+	g := newBuildGame(t)
+	g.WaveFunc = func(int) Wave { return Wave{BonusGold: 10} }
+	g.StartWave()
+	g.Step()
+	if !g.WaveComplete || !g.Building {
+		t.Fatalf("Complete %v building %v, want a build phase after the wave", g.WaveComplete, g.Building)
+	}
+	if g.UpcomingWave() != 2 || g.CanCallWave() {
+		t.Errorf("UpcomingWave() = %d, CanCallWave() = %v; want 2 and no early call", g.UpcomingWave(), g.CanCallWave())
+	}
+	for range 300 {
+		g.Step()
+	}
+	if g.Wave != 1 {
+		t.Fatalf("Wave %d started without the player", g.Wave)
+	}
+	g.StartWave()
+	if g.Wave != 2 || g.WaveComplete {
+		t.Errorf("Wave = %d, complete = %v after starting it", g.Wave, g.WaveComplete)
+	}
+}
+
+func TestSellTowerRefundsAndReport(t *testing.T) {
+	g := NewGame(20, 14)
+	g.Gold = 1000
+	x, y := buildSpot(t, g)
+	placeAt(g, x, y)
+	tower := g.GetTowerAt(x, y)
+	g.CursorX, g.CursorY = x, y
+	g.UpgradeTower()
+
+	info := tower.Info()
+	want := int(float64(info.Cost+info.Upgrades[0].Cost)*sellRefund + 0.5)
+	if g.SellValue(tower) != want {
+		t.Errorf("SellValue() = %d, want %d", g.SellValue(tower), want)
+	}
+	gold := g.Gold
+	if !g.SellTower() || g.Gold != gold+want || g.HasTower(x, y) {
+		t.Fatalf("Selling paid %d, want %d", g.Gold-gold, want)
+	}
+	if g.CanUndo() {
+		t.Error("A sale outside the build phase can be undone")
+	}
+
+	r := g.Report()
+	if len(r.Towers) != 0 {
+		t.Errorf("Report lists a tower that never fired: %+v", r.Towers)
+	}
+	if spent := info.Cost + info.Upgrades[0].Cost - want; r.GoldSpent != spent {
+		t.Errorf("GoldSpent = %d, want %d net of the refund", r.GoldSpent, spent)
+	}
+}
@@ -1,9 +1,10 @@
 package engine
 
 import (
-	"crypto/rand"
 	"embed"
-	"math/big"
+	"maps"
+	"math/rand/v2"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -25,12 +26,37 @@ type Challenge struct {
 	ExpectedCursor  []int  `yaml:"expected_cursor,omitempty"`
 	ExpectedContent string `yaml:"expected_content,omitempty"`
 	FunctionName    string `yaml:"function_name,omitempty"`
+	Pattern         string `yaml:"pattern,omitempty"`
+	Signature       string `yaml:"signature,omitempty"`
+	OldName         string `yaml:"old_name,omitempty"`
+	NewName         string `yaml:"new_name,omitempty"`
 	CursorStart     []int  `yaml:"cursor_start,omitempty"`
 	ParKeystrokes   int    `yaml:"par_keystrokes"`
 	GoldBase        int    `yaml:"gold_base"`
 	RequiredPlugin  string `yaml:"required_plugin,omitempty"`
 	HintAction      string `yaml:"hint_action,omitempty"`
 	HintFallback    string `yaml:"hint_fallback,omitempty"`
+	// Golf restrictions: forbidden keys in Vim notation ("insert_mode" forbids
+	// insert mode), a hard keystroke limit and a time limit in seconds
+	ForbiddenKeys []string `yaml:"forbidden_keys,omitempty"`
+	MaxKeystrokes int      `yaml:"max_keystrokes,omitempty"`
+	TimeLimit     int      `yaml:"time_limit,omitempty"`
+	// Checks holds the sub-checks for all_of/any_of validation
+	Checks []ChallengeCheck `yaml:"checks,omitempty"`
+}
+
+// ChallengeCheck is a single check within a composite (all_of/any_of) validation.
+type ChallengeCheck struct {
+	ValidationType  string           `yaml:"validation_type"`
+	ExpectedBuffer  string           `yaml:"expected_buffer,omitempty"`
+	ExpectedCursor  []int            `yaml:"expected_cursor,omitempty"`
+	ExpectedContent string           `yaml:"expected_content,omitempty"`
+	FunctionName    string           `yaml:"function_name,omitempty"`
+	Pattern         string           `yaml:"pattern,omitempty"`
+	Signature       string           `yaml:"signature,omitempty"`
+	OldName         string           `yaml:"old_name,omitempty"`
+	NewName         string           `yaml:"new_name,omitempty"`
+	Checks          []ChallengeCheck `yaml:"checks,omitempty"`
 }
 
 // DurationTier returns the duration tier based on par_keystrokes.
@@ -72,6 +98,7 @@ type ChallengeManager struct {
 	challenges   []Challenge
 	byCategory   map[string][]Challenge
 	byDifficulty map[int][]Challenge
+	rng          *rand.Rand
 }
 
 // NewChallengeManager creates a new challenge manager and loads challenges.
@@ -80,6 +107,7 @@ func NewChallengeManager() (*ChallengeManager, error) {
 		challenges:   make([]Challenge, 0),
 		byCategory:   make(map[string][]Challenge),
 		byDifficulty: make(map[int][]Challenge),
+		rng:          NewChallengeRNG(NewSeed()),
 	}
 
 	if err := cm.loadChallenges(); err != nil {
@@ -143,15 +171,15 @@ func (cm *ChallengeManager) GetRandomChallenge(category string, maxDifficulty in
 		return nil
 	}
 
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidateIndices))))
-	if err != nil {
-		// Fallback to first candidate on error
-		return &cm.challenges[candidateIndices[0]]
-	}
-	idx := candidateIndices[n.Int64()]
+	idx := candidateIndices[cm.rng.IntN(len(candidateIndices))]
 	return &cm.challenges[idx]
 }
 
+// SetRNG replaces the random source used by GetRandomChallenge.
+func (cm *ChallengeManager) SetRNG(rng *rand.Rand) {
+	cm.rng = rng
+}
+
 // GetChallengesByCategory returns all challenges in a category.
 func (cm *ChallengeManager) GetChallengesByCategory(category string) []Challenge {
 	return cm.byCategory[category]
@@ -166,6 +194,11 @@ func (cm *ChallengeManager) GetCategories() []string {
 	return categories
 }
 
+// SortedCategories returns all available categories in alphabetical order.
+func (cm *ChallengeManager) SortedCategories() []string {
+	return slices.Sorted(maps.Keys(cm.byCategory))
+}
+
 // Count returns the total number of challenges.
 func (cm *ChallengeManager) Count() int {
 	return len(cm.challenges)
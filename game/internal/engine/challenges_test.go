@@ -293,6 +293,13 @@ func TestValidationTypes(t *testing.T) {
 		"function_exists": true,
 		"pattern":         true,
 		"different":       true,
+		"all_of":          true,
+		"any_of":          true,
+
+		"go_parses":             true,
+		"go_function_signature": true,
+		"go_identifier_renamed": true,
+		"go_no_unused_vars":     true,
 	}
 
 	for _, cat := range cm.GetCategories() {
@@ -303,3 +310,36 @@ func TestValidationTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestValidationFields(t *testing.T) {
+	cm, err := NewChallengeManager()
+	if err != nil {
+		t.Fatalf("NewChallengeManager() error = %v", err)
+	}
+
+	for _, c := range cm.GetAllChallenges() {
+		switch c.ValidationType {
+		case "pattern":
+			if c.Pattern == "" {
+				t.Errorf("Challenge %s uses pattern validation without a pattern", c.ID)
+			}
+		case "function_exists", "go_function_signature":
+			if c.FunctionName == "" {
+				t.Errorf("Challenge %s uses %s validation without a function_name", c.ID, c.ValidationType)
+			}
+		case "go_identifier_renamed":
+			if c.OldName == "" || c.NewName == "" {
+				t.Errorf("Challenge %s uses go_identifier_renamed without old_name/new_name", c.ID)
+			}
+		case "all_of", "any_of":
+			if len(c.Checks) == 0 {
+				t.Errorf("Challenge %s uses %s validation without checks", c.ID, c.ValidationType)
+			}
+			for _, check := range c.Checks {
+				if check.ValidationType == "" {
+					t.Errorf("Challenge %s has a check without validation_type", c.ID)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package engine
+
+// Challenge charges limit how often the player can take a challenge in
+// tower defense mode. They build up over time and with every completed wave,
+// up to the economy's cap, and each challenge uses one.
+
+// ChallengeChargeCap returns the most charges the player can bank, 0 if
+// challenges are unlimited.
+func (g *Game) ChallengeChargeCap() int {
+	return g.Economy.ChallengeChargeCap
+}
+
+// NextChargeIn returns the seconds of play until the next charge, 0 when the
+// charges are full or unlimited.
+func (g *Game) NextChargeIn() float64 {
+	if g.ChallengeChargeCap() == 0 || g.ChallengeCharges >= g.ChallengeChargeCap() {
+		return 0
+	}
+	return max(0, g.Economy.ChallengeChargeTime-g.chargeTimer)
+}
+
+// CanStartChallenge reports whether a challenge can start now.
+func (g *Game) CanStartChallenge() bool {
+	return g.State == StatePlaying && !g.ChallengeActive &&
+		(g.ChallengeChargeCap() == 0 || g.ChallengeCharges > 0)
+}
+
+// useChallengeCharge takes a charge for a challenge that is starting.
+func (g *Game) useChallengeCharge() {
+	if g.ChallengeChargeCap() > 0 {
+		g.addChallengeCharges(-1)
+	}
+}
+
+// updateChallengeCharges earns a charge for every ChallengeChargeTime seconds
+// spent below the cap.
+func (g *Game) updateChallengeCharges(dt float64) {
+	if g.ChallengeChargeCap() == 0 || g.Economy.ChallengeChargeTime <= 0 {
+		return
+	}
+	if g.ChallengeCharges >= g.ChallengeChargeCap() {
+		g.chargeTimer = 0
+		return
+	}
+	g.chargeTimer += dt
+	if g.chargeTimer >= g.Economy.ChallengeChargeTime {
+		g.chargeTimer -= g.Economy.ChallengeChargeTime
+		g.addChallengeCharges(1)
+	}
+}
+
+// addChallengeCharges changes the charges within 0 and the cap and
+// publishes the change.
+func (g *Game) addChallengeCharges(delta int) {
+	if g.ChallengeChargeCap() == 0 {
+		return
+	}
+	charges := min(max(g.ChallengeCharges+delta, 0), g.ChallengeChargeCap())
+	if charges == g.ChallengeCharges {
+		return
+	}
+	g.ChallengeCharges = charges
+	g.Events.Publish(ChallengeChargesChanged{Charges: charges})
+}
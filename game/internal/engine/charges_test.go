@@ -0,0 +1,99 @@
+package engine
+
+import "testing"
+
+// chargeEvents returns the charge counts published by g.
+func chargeEvents(g *Game) *[]int {
+	counts := make([]int, 0)
+	g.Events.Subscribe(func(e Event) {
+		if c, ok := e.(ChallengeChargesChanged); ok {
+			counts = append(counts, c.Charges)
+		}
+	})
+	return &counts
+}
+
+func TestChallengesUseCharges(t *testing.T) {
+	g := NewGame(20, 14)
+	g.WaveFunc = func(int) Wave { return Wave{} }
+	counts := chargeEvents(g)
+	if g.ChallengeCharges != g.Economy.ChallengeCharges || g.ChallengeChargeCap() != 3 {
+		t.Fatalf("Started with %d/%d charges", g.ChallengeCharges, g.ChallengeChargeCap())
+	}
+
+	for range g.Economy.ChallengeCharges {
+		if !g.Apply(Action{Kind: ActionStartChallenge}) {
+			t.Fatal("Couldn't start a challenge with a charge left")
+		}
+		g.Apply(Action{Kind: ActionEndChallenge})
+	}
+	if g.CanStartChallenge() || g.Apply(Action{Kind: ActionStartChallenge, Waiting: true}) || g.ChallengeActive {
+		t.Fatal("Started a challenge without charges")
+	}
+	if len(*counts) != 2 || (*counts)[1] != 0 {
+		t.Errorf("Charge events %v, want [1 0]", *counts)
+	}
+}
+
+func TestChallengeChargesAccrue(t *testing.T) {
+	g := NewGame(20, 14)
+	g.WaveFunc = func(int) Wave { return Wave{Spawns: []Spawn{{}, {Delay: 1000}}} } // Never completes
+	g.ChallengeCharges = 0
+	counts := chargeEvents(g)
+
+	half := int(g.Economy.ChallengeChargeTime / FixedTimestep / 2)
+	for range half {
+		g.Step()
+	}
+	if g.ChallengeCharges != 0 || g.NextChargeIn() <= 0 {
+		t.Fatalf("%d charges, next in %vs halfway to a charge", g.ChallengeCharges, g.NextChargeIn())
+	}
+	for range half + 1 {
+		g.Step()
+	}
+	if g.ChallengeCharges != 1 {
+		t.Fatalf("Expected a charge after %vs, have %d", g.Economy.ChallengeChargeTime, g.ChallengeCharges)
+	}
+
+	// Charges stop at the cap, and so does the timer
+	for range 10 * half {
+		g.Step()
+	}
+	if g.ChallengeCharges != g.ChallengeChargeCap() || g.NextChargeIn() != 0 {
+		t.Errorf("%d charges, next in %vs; want a full %d", g.ChallengeCharges, g.NextChargeIn(), g.ChallengeChargeCap())
+	}
+	if len(*counts) != g.ChallengeChargeCap() {
+		t.Errorf("Charge events %v, want one per charge", *counts)
+	}
+}
+
+func TestWaveCompletionEarnsCharge(t *testing.T) {
+	g := NewGame(20, 14)
+	g.WaveFunc = func(int) Wave { return Wave{BonusGold: 10} }
+	g.ChallengeCharges = 0
+	g.Step()
+	if !g.WaveComplete || g.ChallengeCharges != g.Economy.ChallengeChargesPerWave {
+		t.Errorf("Complete %v with %d charges, want %d", g.WaveComplete, g.ChallengeCharges, g.Economy.ChallengeChargesPerWave)
+	}
+}
+
+func TestUnlimitedChallenges(t *testing.T) {
+	g := NewGameWithEconomy(20, 14, EconomyConfig{})
+	for range 10 {
+		if !g.Apply(Action{Kind: ActionStartChallenge}) {
+			t.Fatal("A game without a charge cap limited challenges")
+		}
+		g.Apply(Action{Kind: ActionEndChallenge})
+	}
+	if g.ChallengeCharges != 0 || g.NextChargeIn() != 0 {
+		t.Errorf("Unlimited game has %d charges, next in %vs", g.ChallengeCharges, g.NextChargeIn())
+	}
+}
+
+func TestDifficultyChallengeCharges(t *testing.T) {
+	easy, hard := EconomyConfigForDifficulty(DifficultyEasy), EconomyConfigForDifficulty(DifficultyHard)
+	if easy.ChallengeChargeCap <= hard.ChallengeChargeCap || easy.ChallengeChargeTime >= hard.ChallengeChargeTime {
+		t.Errorf("Easy charges (cap %d, %vs) should beat hard (cap %d, %vs)",
+			easy.ChallengeChargeCap, easy.ChallengeChargeTime, hard.ChallengeChargeCap, hard.ChallengeChargeTime)
+	}
+}
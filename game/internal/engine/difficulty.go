@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DifficultyProfile is a user-defined difficulty preset: the economy of a
+// built-in preset with some of its fields overridden.
+type DifficultyProfile struct {
+	Name    string
+	Economy EconomyConfig
+}
+
+// difficultyFile is the YAML layout of a difficulty profile. Economy fields
+// sit at the top level next to the name and base preset.
+type difficultyFile struct {
+	Name          string `yaml:"name"`
+	Base          string `yaml:"base"`
+	EconomyConfig `yaml:",inline"`
+}
+
+// IsBuiltinDifficulty reports whether name is one of the built-in presets.
+func IsBuiltinDifficulty(name string) bool {
+	switch name {
+	case DifficultyEasy, DifficultyNormal, DifficultyHard:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseDifficultyProfile reads a YAML difficulty profile. Fields the profile
+// leaves out keep the values of its base preset, normal unless it names
+// another. Unknown fields are an error so typos don't go unnoticed.
+func ParseDifficultyProfile(data []byte) (DifficultyProfile, error) {
+	var head difficultyFile
+	if err := yaml.Unmarshal(data, &head); err != nil {
+		return DifficultyProfile{}, err
+	}
+	if head.Base == "" {
+		head.Base = DifficultyNormal
+	}
+	if !IsBuiltinDifficulty(head.Base) {
+		return DifficultyProfile{}, fmt.Errorf("unknown base difficulty %q", head.Base)
+	}
+	if IsBuiltinDifficulty(head.Name) {
+		return DifficultyProfile{}, fmt.Errorf("name %q is taken by a built-in difficulty", head.Name)
+	}
+
+	file := difficultyFile{EconomyConfig: EconomyConfigForDifficulty(head.Base)}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return DifficultyProfile{}, err
+	}
+	if err := checkEconomy(file.EconomyConfig); err != nil {
+		return DifficultyProfile{}, err
+	}
+	return DifficultyProfile{Name: head.Name, Economy: file.EconomyConfig}, nil
+}
+
+// LoadDifficultyProfile reads a difficulty profile from path. A profile
+// without a name is named after its file.
+func LoadDifficultyProfile(path string) (DifficultyProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DifficultyProfile{}, err
+	}
+	profile, err := ParseDifficultyProfile(data)
+	if err != nil {
+		return DifficultyProfile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return profile, nil
+}
+
+// checkEconomy rejects economy values no game can be played with.
+func checkEconomy(e EconomyConfig) error {
+	switch {
+	case e.MobGoldMultiplier < 0 || e.WaveBonusMultiplier < 0:
+		return errors.New("gold multipliers can't be negative")
+	case e.TowerCostMult < 0 || e.EnemyHealthMult < 0:
+		return errors.New("cost and health multipliers can't be negative")
+	case e.InterestRate < 0 || e.InterestRate > 1:
+		return fmt.Errorf("interest rate %v is not between 0 and 1", e.InterestRate)
+	case e.InterestCap < 0 || e.ChallengeChargeCap < 0 || e.ChallengeCharges < 0:
+		return errors.New("caps and charges can't be negative")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestParseDifficultyProfile(t *testing.T) {
+	profile, err := ParseDifficultyProfile([]byte(`
+name: brutal
+base: hard
+interest_rate: 0.1
+interest_cap: 30
+enemy_health_mult: 1.5
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hard := EconomyConfigForDifficulty(DifficultyHard)
+	e := profile.Economy
+	if profile.Name != "brutal" || e.InterestRate != 0.1 || e.InterestCap != 30 || e.EnemyHealthMult != 1.5 {
+		t.Errorf("Profile %q economy %+v doesn't have the overrides", profile.Name, e)
+	}
+	if e.TowerCostMult != hard.TowerCostMult || e.MobGoldMultiplier != hard.MobGoldMultiplier {
+		t.Errorf("Fields left out didn't keep the hard values: %+v", e)
+	}
+
+	profile, err = ParseDifficultyProfile([]byte("tower_cost_mult: 0.5\n"))
+	if err != nil || profile.Economy.WaveBonusMultiplier != DefaultEconomyConfig().WaveBonusMultiplier {
+		t.Errorf("A profile without a base didn't start from normal: %+v, %v", profile.Economy, err)
+	}
+}
+
+func TestParseDifficultyProfileErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{"unknown field", "tower_cost: 2\n"},
+		{"unknown base", "base: nightmare\n"},
+		{"built-in name", "name: hard\n"},
+		{"negative multiplier", "enemy_health_mult: -1\n"},
+		{"interest over 100%", "interest_rate: 2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDifficultyProfile([]byte(tt.yaml)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadDifficultyProfileNamesAfterFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "marathon.yaml")
+	if err := os.WriteFile(path, []byte("base: easy\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	profile, err := LoadDifficultyProfile(path)
+	if err != nil || profile.Name != "marathon" {
+		t.Errorf("Loaded %q, %v; want the profile named marathon", profile.Name, err)
+	}
+}
+
+func TestInterestPaidAtWaveEnd(t *testing.T) {
+	economy := DefaultEconomyConfig()
+	economy.InterestRate, economy.InterestCap = 0.1, 15
+	g := NewGameWithEconomy(20, 14, economy)
+	g.WaveFunc = func(int) Wave { return Wave{BonusGold: 20} }
+	g.State = StatePlaying
+	g.Gold = 100
+	var interest int
+	g.Events.Subscribe(func(e Event) {
+		if e, ok := e.(GoldChanged); ok && e.Source == GoldSourceInterest {
+			interest += e.Delta
+		}
+	})
+
+	g.Step()
+	// The wave bonus is banked before interest is paid on the total
+	if bonus := economy.CalculateWaveBonus(20); interest != min((100+bonus)/10, 15) {
+		t.Errorf("Interest paid %d on %d gold", interest, 100+bonus)
+	}
+	if r := g.Report(); r.GoldEarned[GoldSourceInterest] != interest {
+		t.Errorf("Report counts %d interest, want %d", r.GoldEarned[GoldSourceInterest], interest)
+	}
+}
+
+func TestEconomyScalesCostsAndHealth(t *testing.T) {
+	economy := DefaultEconomyConfig()
+	economy.TowerCostMult, economy.EnemyHealthMult = 2, 1.5
+	g := NewGameWithEconomy(20, 14, economy)
+	g.Gold = 1000
+	x, y := buildSpot(t, g)
+	info := entities.TowerTypes[entities.TowerArrow]
+
+	placeAt(g, x, y)
+	if spent := 1000 - g.Gold; spent != 2*info.Cost {
+		t.Fatalf("Placing cost %d, want %d", spent, 2*info.Cost)
+	}
+	tower := g.GetTowerAt(x, y)
+	if g.UpgradeCost(tower) != 2*info.Upgrades[0].Cost {
+		t.Errorf("UpgradeCost() = %d, want %d", g.UpgradeCost(tower), 2*info.Upgrades[0].Cost)
+	}
+	if want := int(float64(2*info.Cost)*sellRefund + 0.5); g.SellValue(tower) != want {
+		t.Errorf("SellValue() = %d, want %d of what was paid", g.SellValue(tower), want)
+	}
+
+	g.SpawnEnemy(entities.EnemyBug)
+	base := entities.NewEnemy(0, entities.EnemyBug, entities.Position{}).MaxHealth
+	if e := g.Enemies[0]; e.MaxHealth != economy.CalculateEnemyHealth(base) || e.Health != e.MaxHealth {
+		t.Errorf("Enemy health %d/%d, want %d", e.Health, e.MaxHealth, economy.CalculateEnemyHealth(base))
+	}
+}
+
+// TestSellRefundsWhatWasPaid tests that selling refunds a share of the gold
+// paid, each price rounded as it was charged, not of the rounded total.
+func TestSellRefundsWhatWasPaid(t *testing.T) {
+	g := NewGameWithEconomy(20, 14, EconomyConfigForDifficulty(DifficultyHard))
+	g.Gold = 1000
+	x, y := buildSpot(t, g)
+	placeAt(g, x, y)
+	tower := g.GetTowerAt(x, y)
+	g.UpgradeTower()
+
+	if tower.Paid != 58+35 || 1000-g.Gold != tower.Paid {
+		t.Fatalf("Paid %d and spent %d, want 93 for a Hard Arrow with one upgrade", tower.Paid, 1000-g.Gold)
+	}
+	if got := g.SellValue(tower); got != 65 {
+		t.Errorf("SellValue() = %d, want 65", got)
+	}
+}
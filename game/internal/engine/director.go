@@ -0,0 +1,194 @@
+package engine
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Director tuning. After each wave the director scores how the player did
+// from -1 (overwhelmed) to 1 (cruising) and moves its pressure by that
+// score times directorStep. Pressure runs from -1 to 1, and at either end
+// the waves are changed by the swings below.
+const (
+	directorStep         = 0.25
+	directorHealthSwing  = 0.3  // Enemy health ±30% at full pressure
+	directorDelaySwing   = 0.3  // Spawn delay ∓30% at full pressure
+	directorExtraEnemies = 0.25 // Share of a wave's spawns added at full pressure
+	directorExtraDelay   = 0.8  // Least delay before an added enemy
+
+	// A wave the player is struggling with leaks at least this share of
+	// its enemies or costs at least this share of the player's health
+	directorLeakRate   = 0.2
+	directorHealthLost = 0.1
+	// Challenge accuracy the director takes as mastery, and as struggling
+	directorGoodAccuracy = 0.8
+	directorBadAccuracy  = 0.5
+)
+
+// DirectorAdjustment is a change the director made to the upcoming waves,
+// with why it made it.
+type DirectorAdjustment struct {
+	Wave       int     `json:"wave"`     // Wave whose result prompted the change
+	Pressure   float64 `json:"pressure"` // -1 easiest to 1 hardest
+	HealthMult float64 `json:"health_mult"`
+	DelayMult  float64 `json:"delay_mult"`
+	ExtraShare float64 `json:"extra_share"` // Share of each wave's spawns added
+	Reason     string  `json:"reason"`
+}
+
+// String describes the adjustment for the HUD and the combat report.
+func (a DirectorAdjustment) String() string {
+	direction := "easier"
+	if a.Pressure > 0 {
+		direction = "harder"
+	}
+	s := fmt.Sprintf("%s after wave %d (%s): health %+.0f%%, spawn delay %+.0f%%",
+		direction, a.Wave, a.Reason, (a.HealthMult-1)*100, (a.DelayMult-1)*100)
+	if a.ExtraShare > 0 {
+		s += fmt.Sprintf(", %+.0f%% enemies", a.ExtraShare*100)
+	}
+	return s
+}
+
+// Director adjusts upcoming waves to how the player is doing: it watches
+// leaks, the trend of health lost and challenge success, and makes waves
+// tougher, denser and larger for players who cruise, and weaker and
+// sparser for players who struggle, within fixed bounds. Every change is
+// logged.
+type Director struct {
+	Pressure float64
+	Log      []DirectorAdjustment
+
+	lastHealthLost int
+	// Challenges seen at the last wave, to count the ones since
+	attempted, succeeded int
+
+	// Waves adjusted at cachePressure, so they aren't rebuilt every update
+	cache         waveCache
+	cachePressure float64
+}
+
+// NewDirector creates a director that starts with the waves as designed.
+func NewDirector() *Director {
+	return &Director{}
+}
+
+// HealthMult returns how much the director scales enemy health.
+func (d *Director) HealthMult() float64 {
+	return 1 + directorHealthSwing*d.Pressure
+}
+
+// DelayMult returns how much the director scales the delay between spawns.
+func (d *Director) DelayMult() float64 {
+	return 1 - directorDelaySwing*d.Pressure
+}
+
+// ExtraShare returns the share of each wave's spawns the director adds.
+func (d *Director) ExtraShare() float64 {
+	return directorExtraEnemies * max(d.Pressure, 0)
+}
+
+// Adjust returns wave as the director wants it played. Added enemies repeat
+// the wave's spawns, spread over it, after its last one; bosses aren't
+// repeated.
+func (d *Director) Adjust(wave Wave) Wave {
+	if d.Pressure == 0 || len(wave.Spawns) == 0 {
+		return wave
+	}
+	healthMult, delayMult := d.HealthMult(), d.DelayMult()
+	adjusted := wave
+	adjusted.Spawns = make([]Spawn, 0, len(wave.Spawns))
+	for _, s := range wave.Spawns {
+		s.HealthMult = cmp.Or(s.HealthMult, 1) * healthMult
+		s.Delay *= delayMult
+		adjusted.Spawns = append(adjusted.Spawns, s)
+	}
+	extra := int(math.Round(d.ExtraShare() * float64(len(wave.Spawns))))
+	for i := range extra {
+		s := adjusted.Spawns[i*len(wave.Spawns)/extra]
+		if _, boss := BossPhases[s.Type]; boss {
+			continue
+		}
+		s.Delay = max(s.Delay, directorExtraDelay)
+		adjusted.Spawns = append(adjusted.Spawns, s)
+	}
+	return adjusted
+}
+
+// adjusted returns wave n as Adjust changes it, kept until the pressure changes.
+func (d *Director) adjusted(n int, wave Wave) Wave {
+	if d.cachePressure != d.Pressure {
+		d.cache, d.cachePressure = nil, d.Pressure
+	}
+	return d.cache.get(n, func() Wave { return d.Adjust(wave) })
+}
+
+// observe scores the wave just completed and adjusts the pressure. It
+// returns the adjustment, or false if the pressure didn't change.
+func (d *Director) observe(g *Game, waveNum int) (DirectorAdjustment, bool) {
+	w := *g.Stats.wave(waveNum)
+	c := g.Stats.challenges
+	attempted, succeeded := c.Attempted-d.attempted, c.Succeeded-d.succeeded
+	d.attempted, d.succeeded = c.Attempted, c.Succeeded
+	worse := d.lastHealthLost > 0 && w.HealthLost > d.lastHealthLost
+	d.lastHealthLost = w.HealthLost
+
+	var score float64
+	var reasons []string
+	enemies := max(w.Kills+w.Leaks, 1)
+	switch {
+	case float64(w.Leaks)/float64(enemies) >= directorLeakRate ||
+		float64(w.HealthLost) >= directorHealthLost*float64(g.MaxHealth):
+		score = -1
+		reasons = append(reasons, fmt.Sprintf("%d leaked for %d health", w.Leaks, w.HealthLost))
+	case w.Leaks > 0:
+		score = -0.5
+		reasons = append(reasons, fmt.Sprintf("%d leaked", w.Leaks))
+	default:
+		score = 0.5
+		reasons = append(reasons, "no leaks")
+	}
+	if worse {
+		score -= 0.25
+		reasons = append(reasons, "losing more health")
+	}
+	if attempted > 0 {
+		accuracy := float64(succeeded) / float64(attempted)
+		switch {
+		case accuracy >= directorGoodAccuracy:
+			score += 0.5
+		case accuracy < directorBadAccuracy:
+			score -= 0.5
+		}
+		reasons = append(reasons, fmt.Sprintf("%d/%d challenges", succeeded, attempted))
+	}
+
+	score = max(-1, min(score, 1))
+	pressure := max(-1, min(d.Pressure+score*directorStep, 1))
+	if pressure == d.Pressure {
+		return DirectorAdjustment{}, false
+	}
+	d.Pressure = pressure
+	a := DirectorAdjustment{
+		Wave:       waveNum,
+		Pressure:   pressure,
+		HealthMult: d.HealthMult(),
+		DelayMult:  d.DelayMult(),
+		ExtraShare: d.ExtraShare(),
+		Reason:     strings.Join(reasons, ", "),
+	}
+	d.Log = append(d.Log, a)
+	return a, true
+}
+
+// direct is subscribed to the game's events when the game has a director.
+// It runs after recordStats, so the wave's record is complete.
+func (g *Game) direct(e Event) {
+	if e, ok := e.(WaveCompleted); ok {
+		if a, changed := g.Director.observe(g, e.Wave); changed {
+			g.Events.Publish(DifficultyAdjusted{Adjustment: a})
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// newDirectorGame returns a game on level 1 with the director on.
+func newDirectorGame(t *testing.T) *Game {
+	t.Helper()
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Director = true
+	settings.Seed = 1
+	g := NewGameFromLevelAndSettings(&level, settings)
+	if g.Director == nil {
+		t.Fatal("Settings with the director on made a game without one")
+	}
+	return g
+}
+
+// completeWave records a wave result and publishes its completion.
+func completeWave(g *Game, wave, kills, leaks, healthLost int) {
+	w := g.Stats.wave(wave)
+	w.Kills, w.Leaks, w.HealthLost = kills, leaks, healthLost
+	g.Events.Publish(WaveCompleted{Wave: wave})
+}
+
+func TestDirectorAdjust(t *testing.T) {
+	wave := Wave{Spawns: []Spawn{
+		{Type: entities.EnemyBug},
+		{Type: entities.EnemyBug, Delay: 1},
+		{Type: entities.EnemyGremlin, Delay: 1, HealthMult: 2},
+		{Type: entities.EnemyBoss, Delay: 2},
+	}}
+	d := NewDirector()
+	if got := d.Adjust(wave); len(got.Spawns) != 4 || got.Spawns[2].HealthMult != 2 {
+		t.Fatalf("A director without pressure changed the wave: %+v", got.Spawns)
+	}
+
+	d.Pressure = 1
+	got := d.Adjust(wave)
+	if len(got.Spawns) != 5 || got.Spawns[4].Type != entities.EnemyBug || got.Spawns[4].Delay != directorExtraDelay {
+		t.Fatalf("Spawns %+v, want one extra bug after the wave", got.Spawns)
+	}
+	if s := got.Spawns[2]; s.HealthMult != 2*1.3 || s.Delay != 0.7 {
+		t.Errorf("Gremlin health %v delay %v, want 2.6 and 0.7", s.HealthMult, s.Delay)
+	}
+	if wave.Spawns[1].Delay != 1 {
+		t.Error("Adjust changed the wave it was given")
+	}
+
+	// Pressure below zero makes waves easier but never smaller
+	d.Pressure = -1
+	got = d.Adjust(wave)
+	if len(got.Spawns) != 4 || got.Spawns[0].HealthMult != 0.7 || got.Spawns[1].Delay != 1.3 {
+		t.Errorf("Spawns %+v, want weaker and sparser", got.Spawns)
+	}
+}
+
+func TestDirectorFollowsThePlayer(t *testing.T) {
+	g := newDirectorGame(t)
+	var adjustments []DirectorAdjustment
+	g.Events.Subscribe(func(e Event) {
+		if e, ok := e.(DifficultyAdjusted); ok {
+			adjustments = append(adjustments, e.Adjustment)
+		}
+	})
+
+	completeWave(g, 1, 10, 0, 0)
+	if g.Director.Pressure <= 0 || len(adjustments) != 1 {
+		t.Fatalf("After a clean wave pressure %v with %d adjustments", g.Director.Pressure, len(adjustments))
+	}
+	cruising := g.Director.Pressure
+
+	// Solving challenges on top of a clean wave pushes harder
+	g.Stats.challenges = ChallengeReport{Attempted: 2, Succeeded: 2}
+	completeWave(g, 2, 10, 0, 0)
+	if g.Director.Pressure-cruising <= cruising {
+		t.Errorf("Pressure rose %v with challenges solved, want more than %v", g.Director.Pressure-cruising, cruising)
+	}
+
+	// A wave that leaks badly, and worse than the one before, eases off
+	before := g.Director.Pressure
+	completeWave(g, 3, 5, 3, 12)
+	completeWave(g, 4, 4, 4, 20)
+	if g.Director.Pressure >= 0 || g.Director.Pressure >= before {
+		t.Errorf("Pressure %v after two bad waves, want below zero", g.Director.Pressure)
+	}
+	last := adjustments[len(adjustments)-1]
+	if last.Wave != 4 || last.Reason != "4 leaked for 20 health, losing more health" {
+		t.Errorf("Last adjustment %+v", last)
+	}
+	if r := g.Report(); len(r.Director) != len(adjustments) {
+		t.Errorf("Report lists %d adjustments, want %d", len(r.Director), len(adjustments))
+	}
+}
+
+func TestDirectorStaysInBounds(t *testing.T) {
+	g := newDirectorGame(t)
+	for wave := 1; wave <= 20; wave++ {
+		completeWave(g, wave, 10, 0, 0)
+	}
+	if g.Director.Pressure != 1 {
+		t.Fatalf("Pressure %v after 20 clean waves, want the cap of 1", g.Director.Pressure)
+	}
+	logged := len(g.Director.Log)
+	completeWave(g, 21, 10, 0, 0)
+	if len(g.Director.Log) != logged {
+		t.Error("A wave that changed nothing was logged")
+	}
+
+	for wave := 22; wave <= 40; wave++ {
+		completeWave(g, wave, 0, 10, 50)
+	}
+	if g.Director.Pressure != -1 {
+		t.Errorf("Pressure %v after 19 lost waves, want the floor of -1", g.Director.Pressure)
+	}
+}
+
+func TestDirectorAdjustsUpcomingWave(t *testing.T) {
+	g := newDirectorGame(t)
+	designed, _ := g.NextWave()
+	g.Director.Pressure = 1
+	wave, _ := g.NextWave()
+	if len(wave.Spawns) <= len(designed.Spawns) {
+		t.Fatalf("Upcoming wave has %d spawns, want more than the %d designed", len(wave.Spawns), len(designed.Spawns))
+	}
+
+	g.Step()
+	if e := g.Enemies[0]; e.MaxHealth <= entities.EnemyTypes[e.Type].Health {
+		t.Errorf("Spawned enemy has %d health, want more than %d", e.MaxHealth, entities.EnemyTypes[e.Type].Health)
+	}
+}
+
+func TestDirectorReusesAdjustedWaves(t *testing.T) {
+	g := newDirectorGame(t)
+	g.Director.Pressure = 0.5
+	current, next := g.wave(1), g.wave(2)
+	if &g.wave(1).Spawns[0] != &current.Spawns[0] || &g.wave(2).Spawns[0] != &next.Spawns[0] {
+		t.Error("Adjusted the same waves again at the same pressure")
+	}
+
+	g.Director.Pressure = 1
+	if wave := g.wave(1); len(wave.Spawns) <= len(current.Spawns) {
+		t.Errorf("Wave 1 has %d spawns at full pressure, want more than %d", len(wave.Spawns), len(current.Spawns))
+	}
+}
@@ -2,12 +2,30 @@ package engine
 
 import "math"
 
-// EconomyConfig holds configuration for the game's gold economy.
+// EconomyConfig holds configuration for the game's gold economy. The tags
+// name the fields of a custom difficulty profile.
 type EconomyConfig struct {
-	MobGoldMultiplier     float64 // 0.25 = 25% of original mob gold
-	WaveBonusMultiplier   float64 // 0.50 = 50% of original wave bonus
-	ChallengeBaseGold     int     // Base gold for difficulty 1 challenges
-	ChallengeSpeedMaxMult float64 // Max speed bonus multiplier (2.0)
+	MobGoldMultiplier     float64 `yaml:"mob_gold_multiplier" json:"mob_gold_multiplier"`           // 0.25 = 25% of original mob gold
+	WaveBonusMultiplier   float64 `yaml:"wave_bonus_multiplier" json:"wave_bonus_multiplier"`       // 0.50 = 50% of original wave bonus
+	ChallengeBaseGold     int     `yaml:"challenge_base_gold" json:"challenge_base_gold"`           // Base gold for difficulty 1 challenges
+	ChallengeSpeedMaxMult float64 `yaml:"challenge_speed_max_mult" json:"challenge_speed_max_mult"` // Max speed bonus multiplier (2.0)
+	EarlyCallGoldPerSec   float64 `yaml:"early_call_gold_per_sec" json:"early_call_gold_per_sec"`   // Gold per second of wave countdown skipped by calling the next wave early
+	AbilityStrength       float64 `yaml:"ability_strength" json:"ability_strength"`                 // Strength of a tower ability charged by a perfect challenge at par time
+	AbilityDuration       float64 `yaml:"ability_duration" json:"ability_duration"`                 // Seconds a charged tower ability lasts
+
+	// Challenge charges: each tower defense challenge uses one
+	ChallengeCharges        int     `yaml:"challenge_charges" json:"challenge_charges"`                   // Charges at the start of a game
+	ChallengeChargeCap      int     `yaml:"challenge_charge_cap" json:"challenge_charge_cap"`             // Most charges that can be banked; 0 leaves challenges unlimited
+	ChallengeChargeTime     float64 `yaml:"challenge_charge_time" json:"challenge_charge_time"`           // Seconds of play that earn a charge
+	ChallengeChargesPerWave int     `yaml:"challenge_charges_per_wave" json:"challenge_charges_per_wave"` // Charges earned by completing a wave
+
+	// Interest paid on banked gold when a wave is completed
+	InterestRate float64 `yaml:"interest_rate" json:"interest_rate,omitempty"` // 0.05 = 5% of the gold held
+	InterestCap  int     `yaml:"interest_cap" json:"interest_cap,omitempty"`   // Most interest paid per wave; 0 leaves it uncapped
+
+	// Scaling of what the game costs and how much enemies take; 0 counts as 1
+	TowerCostMult   float64 `yaml:"tower_cost_mult" json:"tower_cost_mult,omitempty"`     // Applies to towers, upgrades and branches
+	EnemyHealthMult float64 `yaml:"enemy_health_mult" json:"enemy_health_mult,omitempty"` // Applies to every enemy spawned
 }
 
 // Difficulty presets.
@@ -24,6 +42,17 @@ func DefaultEconomyConfig() EconomyConfig {
 		WaveBonusMultiplier:   0.50,
 		ChallengeBaseGold:     25,
 		ChallengeSpeedMaxMult: 2.0,
+		EarlyCallGoldPerSec:   5.0,
+		AbilityStrength:       0.5,
+		AbilityDuration:       15.0,
+
+		ChallengeCharges:        2,
+		ChallengeChargeCap:      3,
+		ChallengeChargeTime:     30.0,
+		ChallengeChargesPerWave: 1,
+
+		TowerCostMult:   1.0,
+		EnemyHealthMult: 1.0,
 	}
 }
 
@@ -36,6 +65,19 @@ func EconomyConfigForDifficulty(difficulty string) EconomyConfig {
 			WaveBonusMultiplier:   0.75, // 75% wave bonus
 			ChallengeBaseGold:     25,
 			ChallengeSpeedMaxMult: 2.0,
+			EarlyCallGoldPerSec:   5.0,
+			AbilityStrength:       0.5,
+			AbilityDuration:       20.0, // Abilities last longer
+
+			ChallengeCharges:        3,
+			ChallengeChargeCap:      5,
+			ChallengeChargeTime:     20.0,
+			ChallengeChargesPerWave: 1,
+
+			InterestRate:    0.05, // Saving up pays a little
+			InterestCap:     25,
+			TowerCostMult:   0.9,
+			EnemyHealthMult: 0.85,
 		}
 	case DifficultyHard:
 		return EconomyConfig{
@@ -43,6 +85,17 @@ func EconomyConfigForDifficulty(difficulty string) EconomyConfig {
 			WaveBonusMultiplier:   0.25, // 25% wave bonus
 			ChallengeBaseGold:     25,
 			ChallengeSpeedMaxMult: 2.0,
+			EarlyCallGoldPerSec:   8.0, // Rushing pays more when gold is scarce
+			AbilityStrength:       0.5,
+			AbilityDuration:       12.0,
+
+			ChallengeCharges:        1,
+			ChallengeChargeCap:      2,
+			ChallengeChargeTime:     45.0,
+			ChallengeChargesPerWave: 1,
+
+			TowerCostMult:   1.15,
+			EnemyHealthMult: 1.25,
 		}
 	default: // Normal
 		return DefaultEconomyConfig()
@@ -67,6 +120,52 @@ func (e EconomyConfig) CalculateWaveBonus(baseBonus int) int {
 	return bonus
 }
 
+// CalculateEarlyCallBonus calculates the gold for calling the next wave
+// with remaining seconds of the countdown left.
+func (e EconomyConfig) CalculateEarlyCallBonus(remaining float64) int {
+	if remaining <= 0 {
+		return 0
+	}
+	return int(math.Round(remaining * e.EarlyCallGoldPerSec))
+}
+
+// CalculateInterest calculates the interest paid on gold banked when a
+// wave is completed.
+func (e EconomyConfig) CalculateInterest(gold int) int {
+	if gold <= 0 || e.InterestRate <= 0 {
+		return 0
+	}
+	interest := int(math.Floor(float64(gold) * e.InterestRate))
+	if e.InterestCap > 0 {
+		interest = min(interest, e.InterestCap)
+	}
+	return interest
+}
+
+// CalculateTowerCost scales the base cost of a tower, upgrade or branch.
+func (e EconomyConfig) CalculateTowerCost(baseCost int) int {
+	return scale(baseCost, e.TowerCostMult)
+}
+
+// CalculateEnemyHealth scales the base health of an enemy.
+func (e EconomyConfig) CalculateEnemyHealth(baseHealth int) int {
+	return max(1, scale(baseHealth, e.EnemyHealthMult))
+}
+
+// scale multiplies n by mult, rounding to the nearest whole number. A mult
+// of 0 leaves n as it is, so partial configs keep the base values. The
+// product is first rounded to scaleUnit, so halves round up even when mult
+// isn't exact in binary (50 × 1.15 is 57.4999… as a float).
+func scale(n int, mult float64) int {
+	if mult <= 0 {
+		return n
+	}
+	return int(math.Round(math.Round(float64(n)*mult/scaleUnit) * scaleUnit))
+}
+
+// scaleUnit is the precision scale works to before rounding.
+const scaleUnit = 1e-6
+
 // CalculateSpeedBonus calculates the speed bonus multiplier for challenge completion
 // Returns a multiplier between 1.0 and ChallengeSpeedMaxMult.
 func (e EconomyConfig) CalculateSpeedBonus(timeMs, parTimeMs int) float64 {
@@ -94,6 +193,18 @@ func (e EconomyConfig) CalculateSpeedBonus(timeMs, parTimeMs int) float64 {
 	return bonus
 }
 
+// CalculateAbilityStrength calculates the strength of the ability a
+// successful challenge charges its tower with. Efficiency and speed bonus
+// scale it like challenge gold; 0 stands for a challenge that didn't
+// measure them.
+func (e EconomyConfig) CalculateAbilityStrength(efficiency, speedBonus float64) float64 {
+	if efficiency <= 0 {
+		efficiency = 1.0
+	}
+	speedBonus = max(speedBonus, 1.0)
+	return e.AbilityStrength * (0.5 + efficiency*0.5) * speedBonus
+}
+
 // CalculateChallengeGold calculates the total gold reward for a challenge.
 func (e EconomyConfig) CalculateChallengeGold(baseGold, difficulty int, efficiency, speedBonus float64) int {
 	// Difficulty multiplier: 1.0 + (difficulty * 0.25)
@@ -198,6 +198,97 @@ func TestCalculateChallengeGold(t *testing.T) {
 	}
 }
 
+func TestCalculateEarlyCallBonus(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		remaining float64
+		expected  int
+	}{
+		{"full countdown", 5.0, 3.0, 15},
+		{"half a second", 5.0, 0.5, 3}, // 2.5 -> 3 (rounded)
+		{"hard difficulty", 8.0, 2.0, 16},
+		{"countdown over", 5.0, 0, 0},
+		{"no bonus configured", 0, 3.0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := EconomyConfig{EarlyCallGoldPerSec: tt.perSecond}
+			result := config.CalculateEarlyCallBonus(tt.remaining)
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculateAbilityStrength(t *testing.T) {
+	tests := []struct {
+		name       string
+		efficiency float64
+		speedBonus float64
+		expected   float64
+	}{
+		{"perfect at par", 1.0, 1.0, 0.5},
+		{"half efficiency", 0.5, 1.0, 0.375},
+		{"perfect and twice as fast", 1.0, 1.5, 0.75},
+		{"max speed bonus", 1.0, 2.0, 1.0},
+		{"not measured", 0, 0, 0.5},
+	}
+
+	config := DefaultEconomyConfig()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := config.CalculateAbilityStrength(tt.efficiency, tt.speedBonus)
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCalculateInterest(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		cap      int
+		gold     int
+		expected int
+	}{
+		{"five percent", 0.05, 0, 250, 12}, // 12.5 -> 12 (rounded down)
+		{"capped", 0.05, 10, 400, 10},
+		{"no gold", 0.05, 10, 0, 0},
+		{"no interest configured", 0, 0, 400, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := EconomyConfig{InterestRate: tt.rate, InterestCap: tt.cap}
+			result := config.CalculateInterest(tt.gold)
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCostAndHealthMultipliers(t *testing.T) {
+	config := EconomyConfig{TowerCostMult: 1.5, EnemyHealthMult: 0.01}
+	if cost := config.CalculateTowerCost(45); cost != 68 { // 67.5 -> 68 (rounded)
+		t.Errorf("expected tower cost 68, got %d", cost)
+	}
+	if health := config.CalculateEnemyHealth(20); health != 1 {
+		t.Errorf("expected enemy health floored at 1, got %d", health)
+	}
+
+	// Unset multipliers leave the base values
+	var unset EconomyConfig
+	if unset.CalculateTowerCost(50) != 50 || unset.CalculateEnemyHealth(20) != 20 {
+		t.Error("expected unset multipliers to count as 1")
+	}
+}
+
 func TestGameUsesEconomyConfig(t *testing.T) {
 	// Test that NewGame uses default economy
 	game := NewGame(20, 14)
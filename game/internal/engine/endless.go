@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// Endless mode tuning. A wave gets a budget of threat points that grows
+// with the wave number and spends it on enemies; once a wave is at the
+// spawn cap, further growth only comes from the health multiplier.
+const (
+	endlessBaseBudget   = 2
+	endlessWaveBudget   = 4
+	endlessMaxSpawns    = 30
+	endlessHealthGrowth = 0.08 // Extra enemy health per wave
+	endlessUnlockEvery  = 3    // Waves between new enemy types joining the pool
+	endlessBossEvery    = 10
+	endlessSpawnDelay   = 0.8
+
+	// Score per wave survived and per challenge solved
+	endlessWaveScore      = 100
+	endlessChallengeScore = 25
+)
+
+// endlessRoster is every non-boss enemy, walkers from weakest to strongest
+// and then flyers; endless waves add them to the level's own enemies one by
+// one.
+var endlessRoster = []entities.EnemyType{
+	entities.EnemyMite,
+	entities.EnemyBug,
+	entities.EnemySpecter,
+	entities.EnemyGremlin,
+	entities.EnemyCrawler,
+	entities.EnemyDaemon,
+	entities.EnemyMoth,
+	entities.EnemyDragon,
+}
+
+// enemyCost is the budget an enemy takes up in a generated wave, by its health.
+func enemyCost(t entities.EnemyType) int {
+	return max(1, entities.EnemyTypes[t].Health/5)
+}
+
+// EndlessHealthMultiplier returns how much tougher enemies are in a wave.
+func EndlessHealthMultiplier(waveNum int) float64 {
+	return 1 + endlessHealthGrowth*float64(waveNum-1)
+}
+
+// EndlessScore scores an endless run by waves survived and challenges solved.
+func EndlessScore(wavesSurvived, challengesSolved int) int {
+	return wavesSurvived*endlessWaveScore + challengesSolved*endlessChallengeScore
+}
+
+// endlessPool returns the enemies endless wave waveNum draws from: the
+// level's own, plus one more from the roster every endlessUnlockEvery waves.
+func endlessPool(level *Level, waveNum int) []entities.EnemyType {
+	pool := slices.Clone(level.EnemyTypes)
+	pool = slices.DeleteFunc(pool, func(t entities.EnemyType) bool { return t == entities.EnemyBoss })
+	unlocked := (waveNum - 1) / endlessUnlockEvery
+	for _, t := range endlessRoster {
+		if unlocked == 0 {
+			break
+		}
+		if !slices.Contains(pool, t) {
+			pool = append(pool, t)
+			unlocked--
+		}
+	}
+	if len(pool) == 0 {
+		pool = append(pool, endlessRoster[0])
+	}
+	return pool
+}
+
+// EndlessWave generates wave waveNum of an endless run on level. The same
+// seed and wave number always give the same wave. Spawns take turns on the level's paths.
+func EndlessWave(level *Level, seed uint64, waveNum int) Wave {
+	budget := endlessBaseBudget + endlessWaveBudget*waveNum
+	boss := waveNum%endlessBossEvery == 0
+	spawns := budgetSpawns(NewWaveRNG(seed, waveNum), endlessPool(level, waveNum), budget,
+		EndlessHealthMultiplier(waveNum), boss)
+	paths := level.PathNames()
+	for i := range spawns {
+		spawns[i].Path = paths[i%len(paths)]
+	}
+	return Wave{
+		Number:    waveNum,
+		Spawns:    spawns,
+		BonusGold: 20 + waveNum*5,
+	}
+}
+
+// EndlessWaveFunc returns the wave generator for an endless run on level.
+func EndlessWaveFunc(level *Level, seed uint64) WaveFunc {
+	return cachedWaveFunc(func(waveNum int) Wave {
+		return EndlessWave(level, seed, waveNum)
+	})
+}
+
+// budgetSpawns spends a threat budget on random enemies from pool, up to
+// endlessMaxSpawns. A boss wave opens with a boss that takes half the budget.
+func budgetSpawns(rng *rand.Rand, pool []entities.EnemyType, budget int, mult float64, boss bool) []Spawn {
+	spawns := make([]Spawn, 0, endlessMaxSpawns)
+	if boss {
+		spawns = append(spawns, Spawn{Type: entities.EnemyBoss, HealthMult: mult})
+		budget /= 2
+	}
+	for budget > 0 && len(spawns) < endlessMaxSpawns {
+		affordable := slices.DeleteFunc(slices.Clone(pool), func(t entities.EnemyType) bool {
+			return enemyCost(t) > budget
+		})
+		if len(affordable) == 0 {
+			break
+		}
+		t := affordable[rng.IntN(len(affordable))]
+		spawns = append(spawns, Spawn{Type: t, Delay: endlessSpawnDelay, HealthMult: mult})
+		budget -= enemyCost(t)
+	}
+	if len(spawns) == 0 {
+		spawns = append(spawns, Spawn{Type: pool[0], HealthMult: mult})
+	}
+	spawns[0].Delay = 0
+	return spawns
+}
+
+// waveCacheSize is how many waves a waveCache keeps: the game asks for the
+// current wave every update, and the wave preview for the one after it.
+const waveCacheSize = 2
+
+// cachedWave is a wave kept by a waveCache under its wave number.
+type cachedWave struct {
+	num  int
+	wave Wave
+}
+
+// waveCache keeps the waves asked for most recently, newest first.
+type waveCache []cachedWave
+
+// get returns wave n, calling generate only if it isn't kept.
+func (c *waveCache) get(n int, generate func() Wave) Wave {
+	for _, e := range *c {
+		if e.num == n {
+			return e.wave
+		}
+	}
+	wave := generate()
+	*c = append(waveCache{{num: n, wave: wave}}, (*c)[:min(len(*c), waveCacheSize-1)]...)
+	return wave
+}
+
+// cachedWaveFunc wraps a wave generator that is costly to call.
+func cachedWaveFunc(generate WaveFunc) WaveFunc {
+	var cache waveCache
+	return func(waveNum int) Wave {
+		return cache.get(waveNum, func() Wave { return generate(waveNum) })
+	}
+}
+
+// WavesSurvived counts the waves the player has fully beaten.
+func (g *Game) WavesSurvived() int {
+	if g.WaveComplete {
+		return g.Wave
+	}
+	return g.Wave - 1
+}
+
+// EndlessScore scores the game as an endless run.
+func (g *Game) EndlessScore() int {
+	return EndlessScore(g.WavesSurvived(), g.Stats.challenges.Succeeded)
+}
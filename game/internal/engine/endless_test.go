@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestEndlessWaveDeterministic(t *testing.T) {
+	level := Level1()
+	for _, wave := range []int{1, 7, 25} {
+		a := EndlessWave(&level, 42, wave)
+		b := EndlessWave(&level, 42, wave)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("Wave %d differs between calls with the same seed", wave)
+		}
+	}
+}
+
+func TestEndlessWavesScale(t *testing.T) {
+	level := Level1()
+	prevCount := 0
+	for wave := 1; wave <= 9; wave += 4 {
+		w := EndlessWave(&level, 1, wave)
+		if len(w.Spawns) < prevCount {
+			t.Errorf("Wave %d has %d spawns, fewer than the wave before (%d)", wave, len(w.Spawns), prevCount)
+		}
+		prevCount = len(w.Spawns)
+		for _, spawn := range w.Spawns {
+			if spawn.HealthMult != EndlessHealthMultiplier(wave) {
+				t.Errorf("Wave %d spawn has health multiplier %v, want %v", wave, spawn.HealthMult, EndlessHealthMultiplier(wave))
+			}
+		}
+	}
+
+	if late := EndlessWave(&level, 1, 200); len(late.Spawns) > endlessMaxSpawns {
+		t.Errorf("Wave 200 has %d spawns, cap is %d", len(late.Spawns), endlessMaxSpawns)
+	}
+	if EndlessHealthMultiplier(50) <= EndlessHealthMultiplier(10) {
+		t.Error("Expected the health multiplier to keep growing")
+	}
+}
+
+func TestEndlessWaveUsesEveryPath(t *testing.T) {
+	level := Level11()
+	used := make(map[string]bool)
+	for _, spawn := range EndlessWave(&level, 3, 5).Spawns {
+		used[spawn.Path] = true
+	}
+	if len(used) != len(level.PathNames()) {
+		t.Errorf("Endless wave used paths %v, want all of %q", used, level.PathNames())
+	}
+}
+
+func TestEndlessPoolUnlocksEnemies(t *testing.T) {
+	level := Level1() // Mites and bugs
+	if pool := endlessPool(&level, 1); len(pool) != 2 {
+		t.Errorf("Wave 1 pool = %v, want the level's own enemies", pool)
+	}
+	late := endlessPool(&level, 100)
+	if len(late) != len(endlessRoster) {
+		t.Errorf("Late pool = %v, want the whole roster", late)
+	}
+
+	boss := EndlessWave(&level, 1, endlessBossEvery)
+	if boss.Spawns[0].Type != entities.EnemyBoss {
+		t.Errorf("Wave %d should open with a boss, got %v", endlessBossEvery, boss.Spawns[0].Type)
+	}
+}
+
+func TestEndlessGameOnlyEndsInDefeat(t *testing.T) {
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Seed = 3
+	settings.Endless = true
+	g := NewGameFromLevelAndSettings(&level, settings)
+
+	// Enough towers to outlast as many procedural waves as the level has
+	for _, x := range []int{2, 4, 6, 8} {
+		g.Apply(Action{Kind: ActionPlaceTower, X: x, Y: 6})
+	}
+	for g.Tick < maxSimulationTicks && g.State == StatePlaying {
+		g.Step()
+	}
+
+	if g.State != StateGameOver {
+		t.Fatalf("Expected the endless run to end in defeat, state %v", g.State)
+	}
+	if g.Wave <= level.TotalWaves {
+		t.Errorf("Expected to get past the level's %d waves, lost on wave %d", level.TotalWaves, g.Wave)
+	}
+
+	r := g.Report()
+	if !r.Endless || r.Wave != g.Wave || r.Survived != g.WavesSurvived() {
+		t.Errorf("Unexpected endless report %+v", r)
+	}
+	if r.Score != EndlessScore(g.WavesSurvived(), 0) {
+		t.Errorf("Score = %d, want %d", r.Score, EndlessScore(g.WavesSurvived(), 0))
+	}
+}
+
+func TestSpawnHealthMultiplier(t *testing.T) {
+	g := NewGame(20, 14)
+	g.spawnEnemy(Spawn{Type: entities.EnemyBug, HealthMult: 2.5})
+
+	want := entities.EnemyTypes[entities.EnemyBug].Health * 5 / 2
+	if e := g.Enemies[0]; e.MaxHealth != want || e.Health != want {
+		t.Errorf("Scaled enemy has %d/%d health, want %d", e.Health, e.MaxHealth, want)
+	}
+}
+
+func TestCachedWaveFuncKeepsCurrentAndNext(t *testing.T) {
+	calls := 0
+	waves := cachedWaveFunc(func(waveNum int) Wave {
+		calls++
+		return Wave{Number: waveNum}
+	})
+	// The update loop and the wave preview take turns asking
+	for range 3 {
+		waves(4)
+		waves(5)
+	}
+	if calls != 2 {
+		t.Errorf("Generated %d waves, want 2", calls)
+	}
+	waves(6)
+	waves(5)
+	if calls != 3 {
+		t.Errorf("Generated %d waves, want wave 6 added without losing wave 5", calls)
+	}
+}
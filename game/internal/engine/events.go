@@ -0,0 +1,186 @@
+package engine
+
+import (
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// Event is something that happened in the game. The concrete types below are
+// the whole set; subscribers switch on them.
+type Event interface {
+	gameEvent()
+}
+
+// GoldSource says where a gold change came from. The values match the
+// "source" field of the gold_update RPC notification.
+type GoldSource string
+
+const (
+	GoldSourceMob       GoldSource = "mob"
+	GoldSourceWaveBonus GoldSource = "wave_bonus"
+	GoldSourceChallenge GoldSource = "challenge"
+	GoldSourceTower     GoldSource = "tower"
+	GoldSourceUpgrade   GoldSource = "upgrade"
+	GoldSourceEarlyCall GoldSource = "early_call"
+	GoldSourceRefund    GoldSource = "refund" // Selling a tower or undoing a purchase
+	GoldSourceInterest  GoldSource = "interest"
+)
+
+// EnemySpawned is published when an enemy enters the path.
+type EnemySpawned struct {
+	Enemy *entities.Enemy
+}
+
+// EnemyDamaged is published when a projectile hits an enemy. Damage is what
+// the hit actually took off, so overkill isn't counted. Tower is nil if the
+// tower is gone by the time the projectile lands.
+type EnemyDamaged struct {
+	Enemy  *entities.Enemy
+	Tower  *entities.Tower
+	Damage int
+}
+
+// EnemyKilled is published when a tower's projectile kills an enemy. Tower is
+// nil if the tower is gone by the time the projectile lands.
+type EnemyKilled struct {
+	Enemy *entities.Enemy
+	Tower *entities.Tower
+	Gold  int
+}
+
+// EnemyLeaked is published when an enemy reaches the end of the path.
+type EnemyLeaked struct {
+	Enemy  *entities.Enemy
+	Damage int
+}
+
+// TowerPlaced is published when a tower is bought.
+type TowerPlaced struct {
+	Tower *entities.Tower
+	Cost  int
+}
+
+// TowerRemoved is published when a tower is sold or its purchase undone.
+type TowerRemoved struct {
+	Tower  *entities.Tower
+	Refund int
+}
+
+// TowerFired is published when a tower launches a projectile.
+type TowerFired struct {
+	Tower      *entities.Tower
+	Projectile *entities.Projectile
+}
+
+// TowerUpgraded is published when a tower is upgraded; Tower.Level is the new level.
+type TowerUpgraded struct {
+	Tower *entities.Tower
+	Cost  int
+}
+
+// TowerCharged is published when a successful challenge charges a tower
+// with its ability.
+type TowerCharged struct {
+	Tower *entities.Tower
+}
+
+// GoldChanged is published for every change to the player's gold.
+type GoldChanged struct {
+	Gold   int // Balance after the change
+	Delta  int // Negative for spending
+	Source GoldSource
+	// SpeedBonus is the challenge speed multiplier for challenge gold, else 0
+	SpeedBonus float64
+}
+
+// ChallengeEnded is published when a tower defense challenge is resolved.
+type ChallengeEnded struct {
+	Success  bool
+	Gold     int
+	Category string // "" if the challenge was started without one
+}
+
+// ChallengeChargesChanged is published when the player gains or uses a
+// challenge charge.
+type ChallengeChargesChanged struct {
+	Charges int
+}
+
+// WaveStarted is published when the first enemy of a wave spawns.
+type WaveStarted struct {
+	Wave int
+}
+
+// WaveCompleted is published when every enemy of a wave is dead or leaked.
+type WaveCompleted struct {
+	Wave  int
+	Bonus int
+}
+
+// DifficultyAdjusted is published when the director changes the upcoming
+// waves.
+type DifficultyAdjusted struct {
+	Adjustment DirectorAdjustment
+}
+
+// BossPhaseChanged is published when a boss starts a phase, and with
+// BossPhaseShieldBroken when a challenge breaks its shield.
+type BossPhaseChanged struct {
+	Boss *Boss
+	Kind BossPhaseKind
+}
+
+func (EnemySpawned) gameEvent()            {}
+func (EnemyDamaged) gameEvent()            {}
+func (EnemyKilled) gameEvent()             {}
+func (EnemyLeaked) gameEvent()             {}
+func (TowerPlaced) gameEvent()             {}
+func (TowerRemoved) gameEvent()            {}
+func (TowerFired) gameEvent()              {}
+func (TowerUpgraded) gameEvent()           {}
+func (TowerCharged) gameEvent()            {}
+func (GoldChanged) gameEvent()             {}
+func (ChallengeEnded) gameEvent()          {}
+func (ChallengeChargesChanged) gameEvent() {}
+func (WaveStarted) gameEvent()             {}
+func (WaveCompleted) gameEvent()           {}
+func (DifficultyAdjusted) gameEvent()      {}
+func (BossPhaseChanged) gameEvent()        {}
+
+// EventBus delivers game events to subscribers synchronously, on the
+// goroutine that updates the game, in subscription order.
+type EventBus struct {
+	handlers map[int]func(Event)
+	order    []int
+	nextID   int
+}
+
+// NewEventBus creates a bus with no subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[int]func(Event))}
+}
+
+// Subscribe registers a handler for all events and returns a function that
+// removes it again.
+func (b *EventBus) Subscribe(handler func(Event)) func() {
+	b.nextID++
+	id := b.nextID
+	b.handlers[id] = handler
+	b.order = append(b.order, id)
+	return func() {
+		delete(b.handlers, id)
+		// Copy rather than edit in place: a Publish may be iterating order
+		b.order = slices.DeleteFunc(slices.Clone(b.order), func(other int) bool { return other == id })
+	}
+}
+
+// Publish delivers an event to every subscriber. Handlers may subscribe or
+// unsubscribe while it runs; new subscribers get events from the next call.
+func (b *EventBus) Publish(e Event) {
+	for _, id := range b.order {
+		if handler, ok := b.handlers[id]; ok {
+			handler(e)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// recordEvents subscribes to g and collects everything it publishes.
+func recordEvents(g *Game) *[]Event {
+	events := make([]Event, 0)
+	g.Events.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+	return &events
+}
+
+func TestEventBusOrderAndUnsubscribe(t *testing.T) {
+	bus := NewEventBus()
+	var got []string
+	unsubscribeA := bus.Subscribe(func(Event) { got = append(got, "a") })
+	bus.Subscribe(func(Event) { got = append(got, "b") })
+
+	bus.Publish(WaveStarted{Wave: 1})
+	unsubscribeA()
+	bus.Publish(WaveStarted{Wave: 2})
+
+	want := []string{"a", "b", "b"}
+	if len(got) != len(want) {
+		t.Fatalf("Handlers ran %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Handlers ran %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestEventBusUnsubscribeDuringPublish(t *testing.T) {
+	bus := NewEventBus()
+	calls := 0
+	var unsubscribe func()
+	unsubscribe = bus.Subscribe(func(Event) {
+		calls++
+		unsubscribe()
+	})
+	bus.Subscribe(func(Event) { calls++ })
+
+	bus.Publish(WaveStarted{Wave: 1})
+	bus.Publish(WaveStarted{Wave: 2})
+
+	if calls != 3 {
+		t.Errorf("Expected 3 handler calls, got %d", calls)
+	}
+}
+
+func TestPlaceAndUpgradePublishEvents(t *testing.T) {
+	g := NewGame(20, 14)
+	events := recordEvents(g)
+	g.CursorX, g.CursorY = 0, 0
+	g.SelectedTower = entities.TowerArrow
+
+	g.PlaceTower()
+	g.UpgradeTower()
+
+	placeCost := entities.TowerTypes[entities.TowerArrow].Cost
+	var placed, upgraded int
+	spent := 0
+	for _, e := range *events {
+		switch e := e.(type) {
+		case TowerPlaced:
+			placed++
+			if e.Cost != placeCost {
+				t.Errorf("TowerPlaced cost = %d, want %d", e.Cost, placeCost)
+			}
+		case TowerUpgraded:
+			upgraded++
+			if e.Tower.Level != 1 {
+				t.Errorf("TowerUpgraded level = %d, want 1", e.Tower.Level)
+			}
+		case GoldChanged:
+			if e.Source != GoldSourceTower && e.Source != GoldSourceUpgrade {
+				t.Errorf("Unexpected gold source %q", e.Source)
+			}
+			if e.Gold != g.Gold && e.Source == GoldSourceUpgrade {
+				t.Errorf("GoldChanged balance = %d, want %d", e.Gold, g.Gold)
+			}
+			spent -= e.Delta
+		}
+	}
+	if placed != 1 || upgraded != 1 {
+		t.Errorf("Got %d TowerPlaced and %d TowerUpgraded, want 1 each", placed, upgraded)
+	}
+	if spent != 200-g.Gold {
+		t.Errorf("GoldChanged deltas add up to %d spent, gold went down by %d", spent, 200-g.Gold)
+	}
+}
+
+func TestKillPublishesEvents(t *testing.T) {
+	g := NewGame(20, 14)
+	g.CursorX, g.CursorY = 1, 4
+	g.PlaceTower()
+	events := recordEvents(g)
+
+	g.SpawnEnemy(entities.EnemyBug)
+	g.Enemies[0].Health = 1
+	for i := 0; i < 100 && !g.Enemies[0].Dead; i++ {
+		g.Update(0.1)
+	}
+	if !g.Enemies[0].Dead {
+		t.Fatal("Enemy never died")
+	}
+
+	var spawned, killed, mobGold int
+	for _, e := range *events {
+		switch e := e.(type) {
+		case EnemySpawned:
+			spawned++
+		case EnemyKilled:
+			killed++
+			if e.Tower != g.Towers[0] {
+				t.Error("EnemyKilled does not name the tower that fired")
+			}
+		case GoldChanged:
+			if e.Source == GoldSourceMob {
+				mobGold += e.Delta
+			}
+		}
+	}
+	// The game's own first wave may spawn alongside the test enemy
+	if spawned < 1 || killed != 1 {
+		t.Errorf("Got %d EnemySpawned and %d EnemyKilled, want the test enemy killed once", spawned, killed)
+	}
+	if want := g.Economy.CalculateMobGold(entities.EnemyTypes[entities.EnemyBug].GoldValue); mobGold != want {
+		t.Errorf("Mob gold = %d, want %d", mobGold, want)
+	}
+}
+
+func TestLeakAndWavePublishEvents(t *testing.T) {
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Seed = 1
+	g := NewGameFromLevelAndSettings(&level, settings)
+	events := recordEvents(g)
+
+	for g.Tick < maxSimulationTicks && g.Wave == 1 && g.State == StatePlaying {
+		g.Step()
+	}
+
+	var started, completed, leaked, damage int
+	for _, e := range *events {
+		switch e := e.(type) {
+		case WaveStarted:
+			started++
+		case WaveCompleted:
+			completed++
+			if e.Wave != 1 {
+				t.Errorf("WaveCompleted wave = %d, want 1", e.Wave)
+			}
+		case EnemyLeaked:
+			leaked++
+			damage += e.Damage
+		}
+	}
+	if started != 1 || completed != 1 {
+		t.Errorf("Got %d WaveStarted and %d WaveCompleted, want 1 each", started, completed)
+	}
+	if leaked == 0 || damage != settings.StartingHealth-g.Health {
+		t.Errorf("Got %d leaks for %d damage, health went down by %d", leaked, damage, settings.StartingHealth-g.Health)
+	}
+}
+
+func TestChallengeGoldSource(t *testing.T) {
+	g := NewGame(20, 14)
+	g.Apply(Action{Kind: ActionStartChallenge, Category: "movement"})
+	events := recordEvents(g)
+
+	g.Apply(Action{Kind: ActionEndChallenge, Success: true, Gold: 30, SpeedBonus: 1.5})
+
+	if len(*events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(*events))
+	}
+	gold, ok := (*events)[0].(GoldChanged)
+	if !ok || gold.Source != GoldSourceChallenge || gold.Delta != 30 || gold.SpeedBonus != 1.5 {
+		t.Errorf("Expected challenge gold of 30 at 1.5x, got %+v", (*events)[0])
+	}
+	if ended, ok := (*events)[1].(ChallengeEnded); !ok || !ended.Success || ended.Gold != 30 || ended.Category != "movement" {
+		t.Errorf("Expected a successful movement ChallengeEnded, got %+v", (*events)[1])
+	}
+	if g.ChallengeCategory != "" {
+		t.Errorf("ChallengeCategory = %q after the challenge", g.ChallengeCategory)
+	}
+}
+
+func TestTutorialFollowsGameEvents(t *testing.T) {
+	g := NewGame(20, 14)
+	tutorial := NewTutorial()
+	tutorial.Start()
+	tutorial.Attach(g)
+
+	// Game events don't complete steps that wait for a key press
+	g.SpawnEnemy(entities.EnemyBug)
+	g.CursorX, g.CursorY = 0, 0
+	g.PlaceTower()
+	if tutorial.CurrentStep != 0 {
+		t.Fatalf("Game events advanced an any_key step to %d", tutorial.CurrentStep)
+	}
+
+	for tutorial.CurrentStepData().WaitFor != "place_tower" {
+		tutorial.Advance()
+	}
+	step := tutorial.CurrentStep
+	g.CursorX, g.CursorY = 0, 1
+	g.PlaceTower()
+	if tutorial.CurrentStep != step+1 {
+		t.Errorf("Placing a tower left the tutorial on step %d, want %d", tutorial.CurrentStep, step+1)
+	}
+}
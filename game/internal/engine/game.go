@@ -1,6 +1,10 @@
 package engine
 
 import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
 	"github.com/keyforge/keyforge/internal/entities"
 )
 
@@ -34,12 +38,16 @@ type Game struct {
 	MaxHealth  int
 	Wave       int
 	TotalWaves int
+	Endless    bool // Every wave is procedural, from wave 1 on; the game only ends in defeat
 
 	Path        []entities.Position
+	Paths       map[string][]entities.Position // Extra routes by name, as on the level
+	Maze        *Maze                          // Open-field level; Path is then the live route from spawn to exit
 	Towers      []*entities.Tower
 	Enemies     []*entities.Enemy
 	Projectiles []*entities.Projectile
 	Effects     *entities.EffectManager
+	Bosses      []*Boss // Bosses on the field, in spawn order
 
 	// Cursor for tower placement
 	CursorX int
@@ -58,6 +66,9 @@ type Game struct {
 	WaveCountdown float64  // countdown between waves
 	WaveFunc      WaveFunc // Custom wave generator for this level
 
+	// Director adapting upcoming waves to the player; nil plays them as designed
+	Director *Director
+
 	// Economy configuration
 	Economy EconomyConfig
 
@@ -65,14 +76,45 @@ type Game struct {
 	GameSpeed GameSpeed
 
 	// Challenge state
-	ChallengeActive bool // indicates a challenge is being solved
+	ChallengeActive   bool            // indicates a challenge is being solved
+	ChallengeTower    *entities.Tower // Tower the challenge was started on, charged if it succeeds
+	ChallengeCategory string          // Category of the running challenge, if known
+	ChallengeCharges  int             // Challenges the player can start, see charges.go
+	chargeTimer       float64         // Seconds towards the next charge
+
+	// Build phase: before the first wave and between waves the simulation
+	// is frozen until the player starts the wave, and tower changes can be
+	// undone and redone
+	BuildPhase bool // Build phases are on for this game
+	Building   bool // A build phase is running
+	undoStack  []buildCommand
+	redoStack  []buildCommand
 
 	// Status message for UI display
 	StatusMessage string
 
-	// ID counters
-	nextEnemyID int
-	nextTowerID int
+	// Events publishes what happens in the game to the UI, stats and RPC
+	Events *EventBus
+
+	// Stats collects combat and economy statistics for the end-of-game report
+	Stats *GameStats
+
+	// Level and difficulty the game was created from, empty for NewGame
+	LevelID    string
+	Difficulty string
+
+	// Determinism: Seed reproduces every random draw through RNG, and Tick
+	// counts fixed simulation steps so inputs can be logged against it
+	Seed        uint64
+	RNG         *rand.Rand
+	Tick        uint64
+	accumulator float64 // real time not yet consumed by a fixed step
+
+	// ID allocators, per game so concurrent games never share IDs
+	enemyIDs      entities.IDAllocator
+	enemyRoutes   map[int][]entities.Position // Each enemy's own route on a maze, by enemy ID
+	towerIDs      entities.IDAllocator
+	projectileIDs entities.IDAllocator
 }
 
 // NewGame creates a new game with default settings.
@@ -95,6 +137,8 @@ func NewGameWithEconomy(width, height int, economy EconomyConfig) *Game {
 		Enemies:         make([]*entities.Enemy, 0),
 		Projectiles:     make([]*entities.Projectile, 0),
 		Effects:         entities.NewEffectManager(),
+		Events:          NewEventBus(),
+		Stats:           NewGameStats(),
 		CursorX:         width / 2,
 		CursorY:         height / 2,
 		SelectedTower:   entities.TowerArrow,
@@ -107,10 +151,12 @@ func NewGameWithEconomy(width, height int, economy EconomyConfig) *Game {
 		Economy:         economy,
 		GameSpeed:       SpeedNormal,
 		ChallengeActive: false,
-		nextEnemyID:     0,
-		nextTowerID:     0,
 	}
+	g.ChallengeCharges = g.Economy.ChallengeCharges
 	g.Path = g.createDefaultPath()
+	g.SetSeed(NewSeed())
+	g.Events.Subscribe(g.addEffects)
+	g.Events.Subscribe(g.recordStats)
 	return g
 }
 
@@ -128,10 +174,15 @@ func NewGameFromLevelAndSettings(level *Level, settings GameSettings) *Game {
 		Wave:            1,
 		TotalWaves:      level.TotalWaves,
 		Path:            level.Path,
+		Paths:           level.Paths,
 		Towers:          make([]*entities.Tower, 0),
 		Enemies:         make([]*entities.Enemy, 0),
 		Projectiles:     make([]*entities.Projectile, 0),
 		Effects:         entities.NewEffectManager(),
+		Events:          NewEventBus(),
+		Stats:           NewGameStats(),
+		LevelID:         level.ID,
+		Difficulty:      settings.Difficulty,
 		CursorX:         level.GridWidth / 2,
 		CursorY:         level.GridHeight / 2,
 		SelectedTower:   level.AllowedTowers[0], // Default to first allowed tower
@@ -144,24 +195,83 @@ func NewGameFromLevelAndSettings(level *Level, settings GameSettings) *Game {
 		Economy:         settings.GetEconomyConfig(),
 		GameSpeed:       settings.GameSpeed,
 		ChallengeActive: false,
-		nextEnemyID:     0,
-		nextTowerID:     0,
+	}
+	g.ChallengeCharges = g.Economy.ChallengeCharges
+	seed := settings.Seed
+	if seed == 0 {
+		seed = NewSeed()
+	}
+	g.SetSeed(seed)
+	if settings.BuildPhase {
+		g.BuildPhase = true
+		g.Building = true
+	}
+	if settings.Endless {
+		g.Endless = true
+		g.WaveFunc = EndlessWaveFunc(level, seed)
+	}
+	if level.Maze != nil {
+		g.Maze = level.Maze
+		g.enemyRoutes = make(map[int][]entities.Position)
+		g.replanMaze()
+	}
+	g.Events.Subscribe(g.addEffects)
+	g.Events.Subscribe(g.recordStats)
+	if settings.Director {
+		g.Director = NewDirector()
+		g.Events.Subscribe(g.direct)
 	}
 	return g
 }
 
+// SetSeed reseeds the game's random source. Call it before the first tick;
+// reseeding mid-game only makes the remainder of the run reproducible.
+func (g *Game) SetSeed(seed uint64) {
+	g.Seed = seed
+	g.RNG = NewRNG(seed)
+}
+
 // createDefaultPath creates a winding path across the map.
 func (g *Game) createDefaultPath() []entities.Position {
 	// Use the same path as the classic level
 	return classicPath()
 }
 
-// IsOnPath checks if a position is part of the path.
+// PathFor returns the named path, or the main path for "" or a name the
+// level doesn't have.
+func (g *Game) PathFor(name string) []entities.Position {
+	if path, ok := g.Paths[name]; ok {
+		return path
+	}
+	return g.Path
+}
+
+// enemyPath returns the route an enemy is walking.
+func (g *Game) enemyPath(e *entities.Enemy) []entities.Position {
+	if g.Maze != nil {
+		return g.enemyRoutes[e.ID]
+	}
+	return g.PathFor(e.Path)
+}
+
+// AllPaths returns the main path followed by the named paths. Paths may
+// share cells where they split or merge.
+func (g *Game) AllPaths() [][]entities.Position {
+	return allPaths(g.Path, g.Paths)
+}
+
+// IsOnPath checks if a position is part of any path. On a maze only the
+// spawn and exit are; the route between them moves with the towers.
 func (g *Game) IsOnPath(x, y int) bool {
-	for _, p := range g.Path {
-		px, py := p.IntPos()
-		if px == x && py == y {
-			return true
+	if g.Maze != nil {
+		return g.isMazeEnd(x, y)
+	}
+	for _, path := range g.AllPaths() {
+		for _, p := range path {
+			px, py := p.IntPos()
+			if px == x && py == y {
+				return true
+			}
 		}
 	}
 	return false
@@ -189,6 +299,16 @@ func (g *Game) GetTowerAt(x, y int) *entities.Tower {
 	return nil
 }
 
+// towerByID returns the tower with the given ID, or nil if it is gone.
+func (g *Game) towerByID(id int) *entities.Tower {
+	for _, t := range g.Towers {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
 // CanPlaceTower checks if a tower can be placed at the position.
 func (g *Game) CanPlaceTower(x, y int) bool {
 	if x < 0 || x >= g.Width || y < 0 || y >= g.Height {
@@ -200,71 +320,162 @@ func (g *Game) CanPlaceTower(x, y int) bool {
 	if g.HasTower(x, y) {
 		return false
 	}
+	if g.Maze != nil {
+		return g.canWallMaze(x, y)
+	}
 	return true
 }
 
+// TowerCost returns what placing a tower of the given type costs under the
+// game's economy.
+func (g *Game) TowerCost(towerType entities.TowerType) int {
+	return g.Economy.CalculateTowerCost(entities.TowerTypes[towerType].Cost)
+}
+
+// UpgradeCost returns what the tower's next upgrade costs under the game's
+// economy: the cheapest branch when a branch is next, or 0 if maxed.
+func (g *Game) UpgradeCost(t *entities.Tower) int {
+	return g.Economy.CalculateTowerCost(t.UpgradeCost())
+}
+
+// BranchCost returns what specialising into a branch costs under the game's
+// economy.
+func (g *Game) BranchCost(branch entities.TowerBranch) int {
+	return g.Economy.CalculateTowerCost(branch.Upgrade.Cost)
+}
+
 // PlaceTower attempts to place a tower at the cursor position.
 func (g *Game) PlaceTower() bool {
-	info := entities.TowerTypes[g.SelectedTower]
-	if g.Gold < info.Cost {
+	cost := g.TowerCost(g.SelectedTower)
+	if g.Gold < cost {
 		return false
 	}
 	if !g.CanPlaceTower(g.CursorX, g.CursorY) {
 		return false
 	}
 
-	g.nextTowerID++
-	tower := entities.NewTower(g.nextTowerID, g.SelectedTower, entities.Position{
+	tower := entities.NewTower(g.towerIDs.Next(), g.SelectedTower, entities.Position{
 		X: float64(g.CursorX),
 		Y: float64(g.CursorY),
 	})
-	g.Towers = append(g.Towers, tower)
-	g.Gold -= info.Cost
+	c := &placeCommand{tower: tower, cost: cost}
+	c.redo(g)
+	g.record(c)
 	return true
 }
 
-// UpgradeTower attempts to upgrade the tower at cursor position.
+// UpgradeTower attempts to upgrade the tower at cursor position to its next
+// tier. Once the tiers are done, SpecializeTower picks a branch instead.
 func (g *Game) UpgradeTower() bool {
 	tower := g.GetTowerAt(g.CursorX, g.CursorY)
-	if tower == nil || !tower.CanUpgrade() {
+	if tower == nil || !tower.CanUpgrade() || tower.NeedsBranch() {
 		return false
 	}
-	cost := tower.UpgradeCost()
+	return g.buyUpgrade(tower, g.UpgradeCost(tower), "")
+}
+
+// SpecializeTower upgrades the tower at cursor position into the named branch.
+func (g *Game) SpecializeTower(branch string) bool {
+	tower := g.GetTowerAt(g.CursorX, g.CursorY)
+	if tower == nil || !tower.NeedsBranch() {
+		return false
+	}
+	info, ok := tower.Info().Branch(branch)
+	if !ok {
+		return false
+	}
+	return g.buyUpgrade(tower, g.BranchCost(info), info.Name)
+}
+
+func (g *Game) buyUpgrade(tower *entities.Tower, cost int, branch string) bool {
 	if g.Gold < cost {
 		return false
 	}
-	tower.Upgrade()
-	g.Gold -= cost
+	c := &upgradeCommand{tower: tower, cost: cost, branch: branch, level: tower.Level,
+		damage: tower.Damage, rng: tower.Range, cooldown: tower.Cooldown}
+	c.redo(g)
+	g.record(c)
 	return true
 }
 
-// SpawnEnemy spawns an enemy at the start of the path.
+// SpawnEnemy spawns an enemy at the start of the main path.
 func (g *Game) SpawnEnemy(enemyType entities.EnemyType) {
-	if len(g.Path) == 0 {
+	g.spawnEnemy(Spawn{Type: enemyType})
+}
+
+// spawnEnemy spawns a wave entry at the start of its path, scaling the
+// enemy's health as it and the economy ask.
+func (g *Game) spawnEnemy(spawn Spawn) {
+	path := g.PathFor(spawn.Path)
+	if len(path) == 0 {
 		return
 	}
-	g.nextEnemyID++
-	enemy := entities.NewEnemy(g.nextEnemyID, enemyType, g.Path[0])
+	enemy := entities.NewEnemy(g.enemyIDs.Next(), spawn.Type, path[0])
+	if _, ok := g.Paths[spawn.Path]; ok {
+		enemy.Path = spawn.Path
+	}
+	if g.Maze != nil {
+		g.enemyRoutes[enemy.ID] = slices.Clone(path)
+	}
+	if spawn.HealthMult > 0 {
+		enemy.MaxHealth = max(1, int(math.Round(float64(enemy.MaxHealth)*spawn.HealthMult)))
+	}
+	enemy.MaxHealth = g.Economy.CalculateEnemyHealth(enemy.MaxHealth)
+	enemy.Health = enemy.MaxHealth
 	g.Enemies = append(g.Enemies, enemy)
+	if phases, ok := BossPhases[spawn.Type]; ok {
+		g.Bosses = append(g.Bosses, &Boss{Enemy: enemy, Phases: phases})
+	}
+	g.Events.Publish(EnemySpawned{Enemy: enemy})
+}
+
+// Advance feeds elapsed wall-clock time into the fixed-timestep accumulator
+// and runs as many FixedTimestep updates as it covers. It returns the number
+// of steps taken. Frame jitter only changes when a step happens, never what
+// it computes, so the same inputs on the same ticks replay identically.
+func (g *Game) Advance(realDt float64) int {
+	g.accumulator += realDt
+	if limit := FixedTimestep * maxStepsPerAdvance; g.accumulator > limit {
+		g.accumulator = limit
+	}
+
+	steps := 0
+	for g.accumulator >= FixedTimestep {
+		g.accumulator -= FixedTimestep
+		g.Step()
+		steps++
+	}
+	return steps
+}
+
+// Step runs exactly one fixed simulation step.
+func (g *Game) Step() {
+	g.Tick++
+	g.Update(FixedTimestep)
 }
 
 // Update advances the game state by dt seconds.
+// Prefer Advance or Step; variable dt makes runs irreproducible.
 func (g *Game) Update(dt float64) {
 	// Always update effects (even when paused for visual continuity)
 	g.Effects.Update(dt)
 
-	// Game continues during challenges (ChallengeActive state) but not when paused
-	if g.State != StatePlaying && g.State != StateChallengeActive {
+	// Game continues during challenges (ChallengeActive state) but not when
+	// paused or building
+	if g.State != StatePlaying && g.State != StateChallengeActive || g.Building {
 		return
 	}
 
 	// Apply game speed multiplier
 	scaledDt := dt * float64(g.GameSpeed)
 
-	// Update wave spawning
+	// Earn challenge charges, then update wave spawning
+	g.updateChallengeCharges(scaledDt)
 	g.updateWaveSpawning(scaledDt)
 
-	// Update enemies
+	// Update boss abilities and tower auras, then enemies
+	g.updateBosses(scaledDt)
+	g.applySlowAuras()
 	g.updateEnemies(scaledDt)
 
 	// Update towers and create projectiles
@@ -280,18 +491,22 @@ func (g *Game) Update(dt float64) {
 // StartChallenge marks a challenge as active (game continues running)
 // Used for standalone mode where internal vim editor handles the challenge.
 func (g *Game) StartChallenge() {
-	if g.State == StatePlaying {
+	if g.CanStartChallenge() {
+		g.useChallengeCharge()
 		g.State = StateChallengeActive
 		g.ChallengeActive = true
+		g.ChallengeTower = g.GetTowerAt(g.CursorX, g.CursorY)
 	}
 }
 
 // StartChallengeWaiting marks a challenge as waiting (game paused)
 // Used for nvim mode where user edits in a real Neovim buffer.
 func (g *Game) StartChallengeWaiting() {
-	if g.State == StatePlaying {
+	if g.CanStartChallenge() {
+		g.useChallengeCharge()
 		g.State = StateChallengeWaiting
 		g.ChallengeActive = true
+		g.ChallengeTower = g.GetTowerAt(g.CursorX, g.CursorY)
 	}
 }
 
@@ -300,47 +515,138 @@ func (g *Game) EndChallenge() {
 	if g.State == StateChallengeActive || g.State == StateChallengeWaiting {
 		g.State = StatePlaying
 		g.ChallengeActive = false
+		g.ChallengeTower = nil
+		g.ChallengeCategory = ""
 	}
 }
 
 // AddChallengeGold adds gold from completing a challenge.
 func (g *Game) AddChallengeGold(gold int) {
-	g.Gold += gold
+	g.addGold(gold, GoldSourceChallenge, 0)
+}
+
+// addGold changes the player's gold and publishes the change.
+func (g *Game) addGold(delta int, source GoldSource, speedBonus float64) {
+	g.Gold += delta
+	g.Events.Publish(GoldChanged{Gold: g.Gold, Delta: delta, Source: source, SpeedBonus: speedBonus})
+}
+
+// addEffects turns game events into visual effects.
+func (g *Game) addEffects(e Event) {
+	switch e := e.(type) {
+	case EnemyKilled:
+		g.Effects.Add(entities.EffectExplosion, e.Enemy.Pos)
+	case EnemyLeaked:
+		// Escape effect at the exit the enemy took
+		if path := g.enemyPath(e.Enemy); len(path) > 0 {
+			g.Effects.Add(entities.EffectHit, path[len(path)-1])
+		}
+	case TowerUpgraded:
+		g.Effects.Add(entities.EffectLevelUp, e.Tower.Pos)
+	}
+}
+
+// UpcomingWave returns the number of the next wave to start: the one after
+// the current wave, or the first while the build phase holds it back.
+func (g *Game) UpcomingWave() int {
+	if g.Building && !g.WaveComplete {
+		return g.Wave
+	}
+	return g.Wave + 1
+}
+
+// NextWave returns the next wave to start, or false if the current wave is
+// the last.
+func (g *Game) NextWave() (Wave, bool) {
+	n := g.UpcomingWave()
+	if !g.Endless && n > g.TotalWaves {
+		return Wave{}, false
+	}
+	return g.wave(n), true
+}
+
+// CanCallWave reports whether the next wave can be called early: the
+// current wave is cleared and the countdown to the next is running.
+func (g *Game) CanCallWave() bool {
+	_, ok := g.NextWave()
+	return ok && !g.Building && g.WaveComplete && g.WaveCountdown > 0 &&
+		(g.State == StatePlaying || g.State == StateChallengeActive)
+}
+
+// CallNextWave starts the next wave without waiting out the countdown and
+// pays a bonus for the seconds skipped. It reports whether the wave started.
+func (g *Game) CallNextWave() bool {
+	if !g.CanCallWave() {
+		return false
+	}
+	if bonus := g.Economy.CalculateEarlyCallBonus(g.WaveCountdown); bonus > 0 {
+		g.addGold(bonus, GoldSourceEarlyCall, 0)
+	}
+	g.startNextWave()
+	return true
+}
+
+func (g *Game) startNextWave() {
+	g.WaveComplete = false
+	g.Wave++
+	g.SpawnIndex = 0
+	g.WaveCountdown = 3.0
+}
+
+// waveFunc returns the level's wave function, or the default waves.
+func (g *Game) waveFunc() WaveFunc {
+	if g.WaveFunc == nil {
+		return GetWave
+	}
+	return g.WaveFunc
+}
+
+// wave returns wave n as it will be played, adjusted by the director.
+func (g *Game) wave(n int) Wave {
+	wave := g.waveFunc()(n)
+	if g.Director != nil {
+		wave = g.Director.adjusted(n, wave)
+	}
+	return wave
 }
 
 func (g *Game) updateWaveSpawning(dt float64) {
 	if g.WaveComplete {
 		g.WaveCountdown -= dt
 		if g.WaveCountdown <= 0 {
-			g.WaveComplete = false
-			g.Wave++
-			g.SpawnIndex = 0
-			g.WaveCountdown = 3.0
+			g.startNextWave()
 		}
 		return
 	}
 
-	// Use level's wave function if set, otherwise use default
-	waveFunc := g.WaveFunc
-	if waveFunc == nil {
-		waveFunc = GetWave
-	}
-	wave := waveFunc(g.Wave)
+	wave := g.wave(g.Wave)
 
 	if g.SpawnIndex >= len(wave.Spawns) {
 		// Check if wave is complete (all enemies dead)
 		if len(g.Enemies) == 0 {
 			g.WaveComplete = true
 			// Apply economy multiplier to wave bonus
-			g.Gold += g.Economy.CalculateWaveBonus(wave.BonusGold)
+			bonus := g.Economy.CalculateWaveBonus(wave.BonusGold)
+			g.addGold(bonus, GoldSourceWaveBonus, 0)
+			if interest := g.Economy.CalculateInterest(g.Gold); interest > 0 {
+				g.addGold(interest, GoldSourceInterest, 0)
+			}
+			g.Events.Publish(WaveCompleted{Wave: g.Wave, Bonus: bonus})
+			g.addChallengeCharges(g.Economy.ChallengeChargesPerWave)
+			if _, ok := g.NextWave(); ok && g.BuildPhase {
+				g.Building = true
+			}
 		}
 		return
 	}
 
 	g.WaveTimer -= dt
 	if g.WaveTimer <= 0 {
+		if g.SpawnIndex == 0 {
+			g.Events.Publish(WaveStarted{Wave: g.Wave})
+		}
 		spawn := wave.Spawns[g.SpawnIndex]
-		g.SpawnEnemy(spawn.Type)
+		g.spawnEnemy(spawn)
 		g.SpawnIndex++
 		if g.SpawnIndex < len(wave.Spawns) {
 			g.WaveTimer = wave.Spawns[g.SpawnIndex].Delay
@@ -352,9 +658,10 @@ func (g *Game) updateEnemies(dt float64) {
 	aliveEnemies := make([]*entities.Enemy, 0, len(g.Enemies))
 	for _, enemy := range g.Enemies {
 		if enemy.Dead {
+			delete(g.enemyRoutes, enemy.ID)
 			continue
 		}
-		reachedEnd := enemy.Update(dt, g.Path)
+		reachedEnd := enemy.Update(dt, g.enemyPath(enemy))
 		if reachedEnd {
 			// Damage player based on remaining health
 			damage := int(float64(enemy.MaxHealth) * enemy.HealthPercent() * 0.1)
@@ -363,13 +670,12 @@ func (g *Game) updateEnemies(dt float64) {
 			}
 			g.Health -= damage
 			enemy.Dead = true
-			// Add escape effect at end of path
-			if len(g.Path) > 0 {
-				g.Effects.Add(entities.EffectHit, g.Path[len(g.Path)-1])
-			}
+			g.Events.Publish(EnemyLeaked{Enemy: enemy, Damage: damage})
 		}
 		if !enemy.Dead {
 			aliveEnemies = append(aliveEnemies, enemy)
+		} else {
+			delete(g.enemyRoutes, enemy.ID)
 		}
 	}
 	g.Enemies = aliveEnemies
@@ -377,15 +683,26 @@ func (g *Game) updateEnemies(dt float64) {
 
 func (g *Game) updateTowers(dt float64) {
 	for _, tower := range g.Towers {
-		projectile := tower.Update(dt, g.Enemies)
+		projectile := tower.Update(dt, g.Enemies, &g.projectileIDs)
 		if projectile != nil {
-			g.Projectiles = append(g.Projectiles, projectile)
-			// Add tower fire effect
-			g.Effects.Add(entities.EffectTowerFire, tower.Pos)
+			g.fire(tower, projectile)
+			if tower.Special() == entities.SpecialMultiShot {
+				if second := tower.FindTargetExcept(g.Enemies, tower.Target); second != nil {
+					g.fire(tower, entities.NewProjectile(g.projectileIDs.Next(), tower, second))
+				}
+			}
 		}
 	}
 }
 
+// fire launches a tower's projectile.
+func (g *Game) fire(tower *entities.Tower, projectile *entities.Projectile) {
+	g.Projectiles = append(g.Projectiles, projectile)
+	// Add tower fire effect
+	g.Effects.Add(entities.EffectTowerFire, tower.Pos)
+	g.Events.Publish(TowerFired{Tower: tower, Projectile: projectile})
+}
+
 func (g *Game) updateProjectiles(dt float64) {
 	activeProjectiles := make([]*entities.Projectile, 0, len(g.Projectiles))
 	for _, proj := range g.Projectiles {
@@ -394,16 +711,9 @@ func (g *Game) updateProjectiles(dt float64) {
 			// Find enemy at target and deal damage
 			for _, enemy := range g.Enemies {
 				if enemy.ID == proj.TargetID && !enemy.Dead {
-					killed := enemy.TakeDamage(proj.Damage)
-					// Add hit effect
-					g.Effects.Add(entities.EffectHit, enemy.Pos)
-					if killed {
-						// Apply economy multiplier to mob gold
-						baseGold := enemy.Info().GoldValue
-						g.Gold += g.Economy.CalculateMobGold(baseGold)
-						// Add explosion effect for kill
-						g.Effects.Add(entities.EffectExplosion, enemy.Pos)
-					}
+					tower := g.towerByID(proj.TowerID)
+					g.damageEnemy(enemy, proj.Damage, tower)
+					g.hitSpecial(tower, enemy, proj.Damage)
 					break
 				}
 			}
@@ -415,12 +725,37 @@ func (g *Game) updateProjectiles(dt float64) {
 	g.Projectiles = activeProjectiles
 }
 
+// damageEnemy deals a tower's damage to an enemy, paying out and crediting
+// the tower if it dies. Tower is nil if it is gone by now.
+func (g *Game) damageEnemy(enemy *entities.Enemy, damage int, tower *entities.Tower) {
+	health := enemy.Health
+	boss := g.bossFor(enemy)
+	if boss != nil {
+		damage = boss.absorb(damage)
+	}
+	killed := enemy.TakeDamage(damage)
+	// Add hit effect
+	g.Effects.Add(entities.EffectHit, enemy.Pos)
+	g.Events.Publish(EnemyDamaged{Enemy: enemy, Tower: tower, Damage: health - enemy.Health})
+	if killed {
+		if tower != nil {
+			tower.Kills++
+		}
+		// Apply economy multiplier to mob gold
+		gold := g.Economy.CalculateMobGold(enemy.Info().GoldValue)
+		g.addGold(gold, GoldSourceMob, 0)
+		g.Events.Publish(EnemyKilled{Enemy: enemy, Tower: tower, Gold: gold})
+	} else if boss != nil {
+		g.advanceBoss(boss)
+	}
+}
+
 func (g *Game) checkGameEnd() {
 	if g.Health <= 0 {
 		g.Health = 0
 		g.State = StateGameOver
 	}
-	if g.Wave > g.TotalWaves && len(g.Enemies) == 0 {
+	if !g.Endless && g.Wave > g.TotalWaves && len(g.Enemies) == 0 {
 		g.State = StateVictory
 	}
 }
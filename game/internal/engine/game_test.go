@@ -151,6 +151,43 @@ func TestSpawnEnemy(t *testing.T) {
 	}
 }
 
+func TestSpawnOnNamedPath(t *testing.T) {
+	level := Level11()
+	g := NewGameFromLevelAndSettings(&level, DefaultGameSettings())
+	g.WaveFunc = func(int) Wave { return Wave{} } // Only the enemies spawned here
+
+	south := level.Paths["south"]
+	g.spawnEnemy(Spawn{Type: entities.EnemyBug, Path: "south"})
+	g.spawnEnemy(Spawn{Type: entities.EnemyBug, Path: "nowhere"})
+	if enemy := g.Enemies[0]; enemy.Pos != south[0] || enemy.Path != "south" {
+		t.Errorf("Expected the enemy on the south path at %v, got %q at %v", south[0], enemy.Path, enemy.Pos)
+	}
+	if enemy := g.Enemies[1]; enemy.Pos != g.Path[0] || enemy.Path != "" {
+		t.Errorf("Unknown path should fall back to the main path, got %q at %v", enemy.Path, enemy.Pos)
+	}
+
+	x, y := south[0].IntPos()
+	if !g.IsOnPath(x, y) || g.CanPlaceTower(x, y) {
+		t.Error("Cells of a named path should count as path")
+	}
+
+	exits := make(map[string]entities.Position)
+	g.Events.Subscribe(func(e Event) {
+		if leaked, ok := e.(EnemyLeaked); ok {
+			exits[leaked.Enemy.Path] = leaked.Enemy.Pos
+		}
+	})
+	for range 60 * 60 {
+		g.Step()
+		if len(g.Enemies) == 0 {
+			break
+		}
+	}
+	if exits["south"] != south[len(south)-1] || exits[""] != g.Path[len(g.Path)-1] {
+		t.Errorf("Enemies left at %v, want the ends of their paths", exits)
+	}
+}
+
 func TestMoveCursor(t *testing.T) {
 	g := NewGame(20, 14)
 
@@ -262,6 +299,60 @@ func TestGameUpdatePaused(t *testing.T) {
 	}
 }
 
+func TestAdvanceFixedTimestep(t *testing.T) {
+	g := NewGame(20, 14)
+
+	if steps := g.Advance(FixedTimestep / 2); steps != 0 {
+		t.Errorf("Advance(half step) = %d steps, want 0", steps)
+	}
+	if steps := g.Advance(FixedTimestep); steps != 1 {
+		t.Errorf("Advance(one step) with half step carried = %d steps, want 1", steps)
+	}
+	if g.Tick != 1 {
+		t.Errorf("Tick = %d, want 1", g.Tick)
+	}
+
+	// A long stall is capped instead of replayed in full
+	if steps := g.Advance(10); steps != maxStepsPerAdvance {
+		t.Errorf("Advance(10s) = %d steps, want %d", steps, maxStepsPerAdvance)
+	}
+}
+
+func TestAdvanceIgnoresFrameJitter(t *testing.T) {
+	steady := NewGame(20, 14)
+	jittery := NewGame(20, 14)
+	steady.SpawnEnemy(entities.EnemyBug)
+	jittery.SpawnEnemy(entities.EnemyBug)
+
+	for range 120 {
+		steady.Step()
+	}
+	frames := []float64{0.004, 0.031, 0.012, 0.020, 0.009}
+	for i := 0; jittery.Tick < steady.Tick; i++ {
+		jittery.Advance(frames[i%len(frames)])
+	}
+
+	if jittery.Tick != steady.Tick {
+		t.Fatalf("Tick = %d, want %d", jittery.Tick, steady.Tick)
+	}
+	if jittery.Enemies[0].Pos != steady.Enemies[0].Pos {
+		t.Errorf("Enemy at %v with jittered frames, want %v", jittery.Enemies[0].Pos, steady.Enemies[0].Pos)
+	}
+}
+
+func TestSetSeedReproducesRNG(t *testing.T) {
+	a := NewGame(20, 14)
+	b := NewGame(20, 14)
+	a.SetSeed(42)
+	b.SetSeed(42)
+
+	for range 10 {
+		if x, y := a.RNG.Uint64(), b.RNG.Uint64(); x != y {
+			t.Fatalf("same seed diverged: %d != %d", x, y)
+		}
+	}
+}
+
 func TestGameOver(t *testing.T) {
 	g := NewGame(20, 14)
 	g.Health = 1
@@ -505,3 +596,46 @@ func TestMobGoldReducedWithEconomy(t *testing.T) {
 		}
 	}
 }
+
+func TestNextWavePreview(t *testing.T) {
+	g := NewGame(20, 14)
+	next, ok := g.NextWave()
+	if !ok || len(next.Spawns) != len(GetWave(2).Spawns) {
+		t.Fatalf("NextWave() = %d spawns, %v; want wave 2", len(next.Spawns), ok)
+	}
+
+	g.Wave = g.TotalWaves
+	if _, ok := g.NextWave(); ok {
+		t.Error("Expected no next wave on the last wave")
+	}
+	g.Endless = true
+	if _, ok := g.NextWave(); !ok {
+		t.Error("Expected a next wave in endless mode")
+	}
+}
+
+func TestCallNextWaveEarly(t *testing.T) {
+	g := NewGame(20, 14)
+	if g.CallNextWave() {
+		t.Fatal("Called a wave while the current one is still running")
+	}
+
+	g.WaveComplete = true
+	g.WaveCountdown = 2.0
+	gold := g.Gold
+	if !g.CallNextWave() {
+		t.Fatal("Could not call the next wave during the countdown")
+	}
+	if bonus := g.Economy.CalculateEarlyCallBonus(2.0); g.Gold != gold+bonus || bonus == 0 {
+		t.Errorf("Gold = %d, want %d + %d bonus", g.Gold, gold, bonus)
+	}
+	if g.Wave != 2 || g.WaveComplete || g.SpawnIndex != 0 {
+		t.Errorf("Wave = %d complete = %v spawn = %d, want wave 2 starting", g.Wave, g.WaveComplete, g.SpawnIndex)
+	}
+
+	g.Wave = g.TotalWaves
+	g.WaveComplete = true
+	if g.Apply(Action{Kind: ActionCallWave}) {
+		t.Error("Called a wave past the last one")
+	}
+}
@@ -1,6 +1,11 @@
 package engine
 
-import "github.com/keyforge/keyforge/internal/entities"
+import (
+	"maps"
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
 
 // LevelDifficulty indicates the skill level required for a level.
 type LevelDifficulty string
@@ -22,6 +27,8 @@ type Level struct {
 	GridWidth     int
 	GridHeight    int
 	Path          []entities.Position
+	Paths         map[string][]entities.Position // Extra routes by name; spawns without a path follow Path
+	Maze          *Maze                          // Open-field level; Path is the route before any towers
 	TotalWaves    int
 	WaveFunc      WaveFunc
 	AllowedTowers []entities.TowerType
@@ -48,6 +55,8 @@ func NewLevelRegistry() *LevelRegistry {
 			Level8(),
 			Level9(),
 			Level10(),
+			Level11(),
+			Level12(),
 		},
 	}
 }
@@ -57,16 +66,43 @@ func (r *LevelRegistry) GetAll() []Level {
 	return r.levels
 }
 
-// GetByID returns a level by its ID, or nil if not found.
+// GetByID returns a level by its ID, or nil if not found. Random level IDs
+// generate their level again.
 func (r *LevelRegistry) GetByID(id string) *Level {
 	for i := range r.levels {
 		if r.levels[i].ID == id {
 			return &r.levels[i]
 		}
 	}
+	if difficulty, seed, ok := ParseRandomLevelID(id); ok {
+		if level, err := GenerateLevel(difficulty, seed); err == nil {
+			return &level
+		}
+	}
 	return nil
 }
 
+// PathNames returns the names a spawn can use on the level: "" for the main
+// path, then the named paths in order.
+func (l *Level) PathNames() []string {
+	return append([]string{""}, slices.Sorted(maps.Keys(l.Paths))...)
+}
+
+// AllPaths returns the main path followed by the named paths. Paths may
+// share cells where they split or merge.
+func (l *Level) AllPaths() [][]entities.Position {
+	return allPaths(l.Path, l.Paths)
+}
+
+// allPaths lists a main path and then named paths in name order.
+func allPaths(main []entities.Position, named map[string][]entities.Position) [][]entities.Position {
+	paths := [][]entities.Position{main}
+	for _, name := range slices.Sorted(maps.Keys(named)) {
+		paths = append(paths, named[name])
+	}
+	return paths
+}
+
 // Count returns the number of available levels.
 func (r *LevelRegistry) Count() int {
 	return len(r.levels)
@@ -398,7 +434,7 @@ func Level8() Level {
 		TotalWaves:    9,
 		WaveFunc:      level8Wave,
 		AllowedTowers: AllTowers,
-		EnemyTypes:    []entities.EnemyType{entities.EnemyCrawler, entities.EnemySpecter, entities.EnemyDaemon},
+		EnemyTypes:    []entities.EnemyType{entities.EnemyCrawler, entities.EnemySpecter, entities.EnemyDaemon, entities.EnemyMoth},
 		Difficulty:    LevelDifficultyAdvanced,
 	}
 }
@@ -457,8 +493,10 @@ func Level9() Level {
 		TotalWaves:    10,
 		WaveFunc:      level9Wave,
 		AllowedTowers: AllTowers,
-		EnemyTypes:    []entities.EnemyType{entities.EnemySpecter, entities.EnemyDaemon},
-		Difficulty:    LevelDifficultyAdvanced,
+		EnemyTypes: []entities.EnemyType{
+			entities.EnemySpecter, entities.EnemyDaemon, entities.EnemyMoth, entities.EnemyDragon,
+		},
+		Difficulty: LevelDifficultyAdvanced,
 	}
 }
 
@@ -527,6 +565,8 @@ func Level10() Level {
 			entities.EnemySpecter,
 			entities.EnemyDaemon,
 			entities.EnemyBoss,
+			entities.EnemyMoth,
+			entities.EnemyDragon,
 		},
 		Difficulty: LevelDifficultyAdvanced,
 	}
@@ -574,6 +614,80 @@ func level10Path() []entities.Position {
 	return path
 }
 
+// Level11 - Two spawn points merge into one trunk that splits to two exits.
+func Level11() Level {
+	return Level{
+		ID:          "level-11",
+		Name:        "The Crossroads",
+		Description: "Enemies come from two sides, meet in the middle and split again.",
+		GridWidth:   24,
+		GridHeight:  14,
+		Path:        level11Route(2, 3),
+		Paths: map[string][]entities.Position{
+			"north-low":  level11Route(2, 11),
+			"south":      level11Route(12, 11),
+			"south-high": level11Route(12, 3),
+		},
+		TotalWaves:    10,
+		WaveFunc:      level11Wave,
+		AllowedTowers: AllTowers,
+		EnemyTypes:    []entities.EnemyType{entities.EnemyGremlin, entities.EnemySpecter, entities.EnemyCrawler},
+		Difficulty:    LevelDifficultyAdvanced,
+	}
+}
+
+// Level12 - Open field: no path, the towers build the maze.
+func Level12() Level {
+	maze := &Maze{
+		Spawn: entities.Position{X: 0, Y: 7},
+		Exit:  entities.Position{X: 19, Y: 7},
+	}
+	return Level{
+		ID:            "level-12",
+		Name:          "Open Field",
+		Description:   "No path at all. Your towers are the walls; make the maze long.",
+		GridWidth:     20,
+		GridHeight:    14,
+		Path:          FindRoute(20, 14, maze.Spawn, maze.Exit, func(int, int) bool { return false }),
+		Maze:          maze,
+		TotalWaves:    8,
+		WaveFunc:      level12Wave,
+		AllowedTowers: AllTowers,
+		EnemyTypes:    []entities.EnemyType{entities.EnemyBug, entities.EnemyGremlin, entities.EnemyCrawler},
+		Difficulty:    LevelDifficultyAdvanced,
+	}
+}
+
+// level11Route runs from the spawn on row spawnY down or up column 6 to the
+// trunk on row 7, along it to column 15 and out to the exit on row exitY.
+func level11Route(spawnY, exitY int) []entities.Position {
+	return pathThrough([2]int{0, spawnY}, [2]int{6, spawnY}, [2]int{6, 7}, [2]int{15, 7}, [2]int{15, exitY}, [2]int{23, exitY})
+}
+
+// pathThrough returns the cells of straight runs between corners.
+func pathThrough(corners ...[2]int) []entities.Position {
+	path := []entities.Position{{X: float64(corners[0][0]), Y: float64(corners[0][1])}}
+	for i := 1; i < len(corners); i++ {
+		x, y := corners[i-1][0], corners[i-1][1]
+		for x != corners[i][0] || y != corners[i][1] {
+			x += sign(corners[i][0] - x)
+			y += sign(corners[i][1] - y)
+			path = append(path, entities.Position{X: float64(x), Y: float64(y)})
+		}
+	}
+	return path
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
 // ClassicLevel returns Level5 for backward compatibility.
 func ClassicLevel() Level {
 	return Level5()
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/keyforge/keyforge/internal/entities"
@@ -100,16 +102,16 @@ func TestClassicLevel(t *testing.T) {
 func TestLevelRegistry(t *testing.T) {
 	registry := NewLevelRegistry()
 
-	t.Run("has exactly 10 levels", func(t *testing.T) {
-		if registry.Count() != 10 {
-			t.Errorf("Expected exactly 10 levels, got %d", registry.Count())
+	t.Run("has exactly 12 levels", func(t *testing.T) {
+		if registry.Count() != 12 {
+			t.Errorf("Expected exactly 12 levels, got %d", registry.Count())
 		}
 	})
 
-	t.Run("GetAll returns all 10 levels", func(t *testing.T) {
+	t.Run("GetAll returns all 12 levels", func(t *testing.T) {
 		levels := registry.GetAll()
-		if len(levels) != 10 {
-			t.Errorf("Expected GetAll to return 10 levels, got %d", len(levels))
+		if len(levels) != 12 {
+			t.Errorf("Expected GetAll to return 12 levels, got %d", len(levels))
 		}
 	})
 
@@ -117,6 +119,7 @@ func TestLevelRegistry(t *testing.T) {
 		expectedIDs := []string{
 			"level-1", "level-2", "level-3", "level-4", "level-5",
 			"level-6", "level-7", "level-8", "level-9", "level-10",
+			"level-11", "level-12",
 		}
 		for _, id := range expectedIDs {
 			level := registry.GetByID(id)
@@ -311,11 +314,12 @@ func TestLevelEnemyPools(t *testing.T) {
 		{"level-5", []entities.EnemyType{entities.EnemyBug, entities.EnemyGremlin, entities.EnemySpecter}},
 		{"level-6", []entities.EnemyType{entities.EnemyGremlin, entities.EnemyCrawler, entities.EnemyDaemon}},
 		{"level-7", []entities.EnemyType{entities.EnemyGremlin, entities.EnemySpecter, entities.EnemyDaemon}},
-		{"level-8", []entities.EnemyType{entities.EnemyCrawler, entities.EnemySpecter, entities.EnemyDaemon}},
-		{"level-9", []entities.EnemyType{entities.EnemySpecter, entities.EnemyDaemon}},
+		{"level-8", []entities.EnemyType{entities.EnemyCrawler, entities.EnemySpecter, entities.EnemyDaemon, entities.EnemyMoth}},
+		{"level-9", []entities.EnemyType{entities.EnemySpecter, entities.EnemyDaemon, entities.EnemyMoth, entities.EnemyDragon}},
 		{"level-10", []entities.EnemyType{
 			entities.EnemyBug, entities.EnemyGremlin, entities.EnemyCrawler,
 			entities.EnemySpecter, entities.EnemyDaemon, entities.EnemyBoss,
+			entities.EnemyMoth, entities.EnemyDragon,
 		}},
 	}
 
@@ -441,3 +445,45 @@ func TestLevelWaveEnemiesMatchPool(t *testing.T) {
 		})
 	}
 }
+
+func TestLevelNamedPaths(t *testing.T) {
+	for _, level := range NewLevelRegistry().GetAll() {
+		t.Run(level.Name, func(t *testing.T) {
+			for name, path := range level.Paths {
+				if err := ValidatePath(path, level.GridWidth, level.GridHeight); err != nil {
+					t.Errorf("Path %q: %v", name, err)
+				}
+			}
+
+			names := level.PathNames()
+			for waveNum := 1; waveNum <= level.TotalWaves; waveNum++ {
+				for _, spawn := range level.WaveFunc(waveNum).Spawns {
+					if !slices.Contains(names, spawn.Path) {
+						t.Errorf("Wave %d spawns on unknown path %q", waveNum, spawn.Path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLevel11PathsSplitAndMerge(t *testing.T) {
+	level := Level11()
+	if got := level.PathNames(); !slices.Equal(got, []string{"", "north-low", "south", "south-high"}) {
+		t.Fatalf("PathNames() = %q", got)
+	}
+
+	starts := make(map[entities.Position]bool)
+	exits := make(map[entities.Position]bool)
+	trunk := entities.Position{X: 10, Y: 7}
+	for _, path := range append([][]entities.Position{level.Path}, slices.Collect(maps.Values(level.Paths))...) {
+		starts[path[0]] = true
+		exits[path[len(path)-1]] = true
+		if !slices.Contains(path, trunk) {
+			t.Errorf("Path starting at %v skips the shared trunk", path[0])
+		}
+	}
+	if len(starts) != 2 || len(exits) != 2 {
+		t.Errorf("Expected 2 spawn points and 2 exits, got %d and %d", len(starts), len(exits))
+	}
+}
@@ -0,0 +1,310 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// Random levels use the same grid as the hand-made beginner levels.
+const (
+	RandomLevelWidth  = 20
+	RandomLevelHeight = 14
+
+	randomLevelPrefix   = "random-"
+	maxRandomLevelSeed  = 999_999 // Seeds stay short enough to read out to a teammate
+	pathAttempts        = 300
+	pathMinSegment      = 2
+	pathMaxSegment      = 4
+	pathLengthTolerance = 0.1
+)
+
+// randomLevelProfile is what a generated level looks like at a difficulty.
+// Shorter paths give towers less time to shoot.
+type randomLevelProfile struct {
+	pathLength int
+	waves      int
+	pool       []entities.EnemyType
+	baseBudget int
+	waveBudget int
+	finalBoss  bool
+}
+
+var randomLevelProfiles = map[LevelDifficulty]randomLevelProfile{
+	LevelDifficultyBeginner: {
+		pathLength: 32,
+		waves:      5,
+		pool:       []entities.EnemyType{entities.EnemyMite, entities.EnemyBug},
+		baseBudget: 3,
+		waveBudget: 3,
+	},
+	LevelDifficultyIntermediate: {
+		pathLength: 26,
+		waves:      7,
+		pool:       []entities.EnemyType{entities.EnemyMite, entities.EnemyBug, entities.EnemySpecter, entities.EnemyGremlin},
+		baseBudget: 4,
+		waveBudget: 4,
+	},
+	LevelDifficultyAdvanced: {
+		pathLength: 22,
+		waves:      10,
+		pool: []entities.EnemyType{
+			entities.EnemyBug, entities.EnemySpecter, entities.EnemyGremlin,
+			entities.EnemyCrawler, entities.EnemyDaemon,
+		},
+		baseBudget: 6,
+		waveBudget: 5,
+		finalBoss:  true,
+	},
+}
+
+// NewLevelSeed returns a fresh seed for a random level.
+func NewLevelSeed() uint64 {
+	return NewSeed()%maxRandomLevelSeed + 1
+}
+
+// RandomLevelID names the level generated from a seed at a difficulty, so a
+// replay or a shared ID can generate it again.
+func RandomLevelID(difficulty LevelDifficulty, seed uint64) string {
+	return fmt.Sprintf("%s%s-%d", randomLevelPrefix, difficulty, seed)
+}
+
+// ParseRandomLevelID reverses RandomLevelID.
+func ParseRandomLevelID(id string) (LevelDifficulty, uint64, bool) {
+	rest, ok := strings.CutPrefix(id, randomLevelPrefix)
+	if !ok {
+		return "", 0, false
+	}
+	name, seedText, ok := strings.Cut(rest, "-")
+	if !ok {
+		return "", 0, false
+	}
+	difficulty := LevelDifficulty(name)
+	if _, known := randomLevelProfiles[difficulty]; !known {
+		return "", 0, false
+	}
+	seed, err := strconv.ParseUint(seedText, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return difficulty, seed, true
+}
+
+// GenerateLevel builds a level from a seed: a path from the left edge to the
+// right edge close to the difficulty's target length, and waves drawn from
+// the difficulty's enemies. The same seed and difficulty always give the
+// same level.
+func GenerateLevel(difficulty LevelDifficulty, seed uint64) (Level, error) {
+	profile, ok := randomLevelProfiles[difficulty]
+	if !ok {
+		return Level{}, fmt.Errorf("unknown difficulty %q", difficulty)
+	}
+	rng := NewRNG(seed)
+	path := GeneratePath(rng, RandomLevelWidth, RandomLevelHeight, profile.pathLength)
+	if path == nil {
+		return Level{}, fmt.Errorf("no path found for seed %d", seed)
+	}
+
+	return Level{
+		ID:            RandomLevelID(difficulty, seed),
+		Name:          "Random Level",
+		Description:   fmt.Sprintf("Generated from seed %d.", seed),
+		GridWidth:     RandomLevelWidth,
+		GridHeight:    RandomLevelHeight,
+		Path:          path,
+		TotalWaves:    profile.waves,
+		WaveFunc:      randomLevelWaves(profile, seed),
+		AllowedTowers: AllTowers,
+		EnemyTypes:    profile.pool,
+		Difficulty:    difficulty,
+	}, nil
+}
+
+// randomLevelWaves returns the waves of a generated level.
+func randomLevelWaves(profile randomLevelProfile, seed uint64) WaveFunc {
+	return cachedWaveFunc(func(waveNum int) Wave {
+		boss := profile.finalBoss && waveNum == profile.waves
+		return Wave{
+			Number:    waveNum,
+			Spawns:    budgetSpawns(NewWaveRNG(seed, waveNum), profile.pool, profile.baseBudget+profile.waveBudget*waveNum, 1, boss),
+			BonusGold: 15 + waveNum*5,
+		}
+	})
+}
+
+// GeneratePath makes random orthogonal paths from the left edge to the right
+// edge of a width x height grid and returns the one closest to length
+// cells, or nil if every attempt ran into a dead end. Paths never touch
+// themselves, not even side by side, which keeps buildable cells around
+// every turn; the top and bottom rows stay free.
+func GeneratePath(rng *rand.Rand, width, height, length int) []entities.Position {
+	var best []entities.Position
+	for range pathAttempts {
+		path := walkPath(rng, width, height, length)
+		if path == nil {
+			continue
+		}
+		if best == nil || abs(len(path)-length) < abs(len(best)-length) {
+			best = path
+		}
+		if float64(abs(len(best)-length)) <= float64(length)*pathLengthTolerance {
+			break
+		}
+	}
+	return best
+}
+
+// pathWalk is one attempt at a path.
+type pathWalk struct {
+	width, height int
+	cells         []entities.Position
+	used          map[[2]int]bool
+}
+
+// pathDirections a path can take, with how often a segment picks each:
+// right, down, up, left. Favoring vertical segments makes paths wind.
+var (
+	pathDirections = [][2]int{{1, 0}, {0, 1}, {0, -1}, {-1, 0}}
+	pathWeights    = []int{2, 3, 3, 1}
+)
+
+// walkPath walks segments in random directions until it reaches the right
+// edge. It heads straight for the edge once the remaining length is used up.
+func walkPath(rng *rand.Rand, width, height, length int) []entities.Position {
+	w := &pathWalk{width: width, height: height, used: make(map[[2]int]bool)}
+	w.add(0, 1+rng.IntN(height-2))
+	dir := pathDirections[0]
+
+	for {
+		x, y := w.last()
+		if x == width-1 {
+			return w.cells
+		}
+		if len(w.cells) > 2*length {
+			return nil
+		}
+		next, ok := w.pickDirection(rng, dir, length-len(w.cells) <= width-1-x)
+		if !ok {
+			return nil
+		}
+		dir = next
+		for steps := pathMinSegment + rng.IntN(pathMaxSegment-pathMinSegment+1); steps > 0; steps-- {
+			x, y = w.last()
+			if !w.free(x+dir[0], y+dir[1]) {
+				break
+			}
+			w.add(x+dir[0], y+dir[1])
+			if x+dir[0] == width-1 {
+				break
+			}
+		}
+	}
+}
+
+// pickDirection picks where the next segment goes. It never reverses, and
+// heads right whenever it can once the path is long enough.
+func (w *pathWalk) pickDirection(rng *rand.Rand, current [2]int, finish bool) ([2]int, bool) {
+	x, y := w.last()
+	if finish && w.free(x+1, y) {
+		return pathDirections[0], true
+	}
+	options := make([][2]int, 0, 16)
+	for i, d := range pathDirections {
+		if d[0] == -current[0] && d[1] == -current[1] || !w.free(x+d[0], y+d[1]) {
+			continue
+		}
+		for range pathWeights[i] {
+			options = append(options, d)
+		}
+	}
+	if len(options) == 0 {
+		return [2]int{}, false
+	}
+	return options[rng.IntN(len(options))], true
+}
+
+func (w *pathWalk) add(x, y int) {
+	w.cells = append(w.cells, entities.Position{X: float64(x), Y: float64(y)})
+	w.used[[2]int{x, y}] = true
+}
+
+func (w *pathWalk) last() (int, int) {
+	return w.cells[len(w.cells)-1].IntPos()
+}
+
+// free reports whether the path can continue onto x,y: inside the grid,
+// off the top and bottom rows and the left edge, and not next to any cell of
+// the path but the one it comes from.
+func (w *pathWalk) free(x, y int) bool {
+	if x < 1 || x >= w.width || y < 1 || y >= w.height-1 || w.used[[2]int{x, y}] {
+		return false
+	}
+	lx, ly := w.last()
+	for _, d := range pathDirections {
+		nx, ny := x+d[0], y+d[1]
+		if (nx != lx || ny != ly) && w.used[[2]int{nx, ny}] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePath checks that a path is a chain of orthogonal steps inside a
+// width x height grid that never crosses itself, and that every turn has a
+// buildable cell next to it.
+func ValidatePath(path []entities.Position, width, height int) error {
+	if len(path) < 2 {
+		return errors.New("path is too short")
+	}
+	onPath := make(map[[2]int]bool, len(path))
+	for i, pos := range path {
+		x, y := pos.IntPos()
+		if x < 0 || x >= width || y < 0 || y >= height {
+			return fmt.Errorf("cell %d (%d,%d) is outside the grid", i, x, y)
+		}
+		if onPath[[2]int{x, y}] {
+			return fmt.Errorf("cell %d (%d,%d) crosses the path", i, x, y)
+		}
+		onPath[[2]int{x, y}] = true
+		if i > 0 {
+			px, py := path[i-1].IntPos()
+			if abs(x-px)+abs(y-py) != 1 {
+				return fmt.Errorf("step %d from (%d,%d) to (%d,%d) is not orthogonal", i, px, py, x, y)
+			}
+		}
+	}
+
+	for i := 1; i < len(path)-1; i++ {
+		px, py := path[i-1].IntPos()
+		x, y := path[i].IntPos()
+		nx, ny := path[i+1].IntPos()
+		if nx-x == x-px && ny-y == y-py {
+			continue // Straight on, not a turn
+		}
+		buildable := false
+		for dx := -1; dx <= 1 && !buildable; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				cx, cy := x+dx, y+dy
+				if cx >= 0 && cx < width && cy >= 0 && cy < height && !onPath[[2]int{cx, cy}] {
+					buildable = true
+					break
+				}
+			}
+		}
+		if !buildable {
+			return fmt.Errorf("turn at (%d,%d) has no buildable cell next to it", x, y)
+		}
+	}
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
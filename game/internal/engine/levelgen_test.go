@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestGenerateLevelPaths(t *testing.T) {
+	for difficulty, profile := range randomLevelProfiles {
+		for seed := uint64(1); seed <= 50; seed++ {
+			level, err := GenerateLevel(difficulty, seed)
+			if err != nil {
+				t.Fatalf("GenerateLevel(%s, %d) error = %v", difficulty, seed, err)
+			}
+			if err := ValidatePath(level.Path, level.GridWidth, level.GridHeight); err != nil {
+				t.Errorf("%s seed %d: %v", difficulty, seed, err)
+			}
+			first, last := level.Path[0], level.Path[len(level.Path)-1]
+			if first.X != 0 || int(last.X) != level.GridWidth-1 {
+				t.Errorf("%s seed %d: path runs from x=%v to x=%v, want edge to edge", difficulty, seed, first.X, last.X)
+			}
+			if diff := abs(len(level.Path) - profile.pathLength); diff > profile.pathLength/4 {
+				t.Errorf("%s seed %d: path has %d cells, target %d", difficulty, seed, len(level.Path), profile.pathLength)
+			}
+		}
+	}
+}
+
+func TestGenerateLevelDeterministic(t *testing.T) {
+	a, errA := GenerateLevel(LevelDifficultyIntermediate, 1234)
+	b, errB := GenerateLevel(LevelDifficultyIntermediate, 1234)
+	if errA != nil || errB != nil {
+		t.Fatalf("GenerateLevel() errors: %v, %v", errA, errB)
+	}
+	if !reflect.DeepEqual(a.Path, b.Path) {
+		t.Error("Same seed generated different paths")
+	}
+	for wave := 1; wave <= a.TotalWaves; wave++ {
+		if !reflect.DeepEqual(a.WaveFunc(wave), b.WaveFunc(wave)) {
+			t.Errorf("Same seed generated different wave %d", wave)
+		}
+	}
+
+	c, _ := GenerateLevel(LevelDifficultyIntermediate, 1235)
+	if reflect.DeepEqual(a.Path, c.Path) {
+		t.Error("Different seeds generated the same path")
+	}
+}
+
+func TestGenerateLevelWavesMatchDifficulty(t *testing.T) {
+	beginner, _ := GenerateLevel(LevelDifficultyBeginner, 9)
+	advanced, _ := GenerateLevel(LevelDifficultyAdvanced, 9)
+
+	if beginner.TotalWaves >= advanced.TotalWaves {
+		t.Errorf("Beginner has %d waves, advanced %d", beginner.TotalWaves, advanced.TotalWaves)
+	}
+	for wave := 1; wave <= beginner.TotalWaves; wave++ {
+		for _, spawn := range beginner.WaveFunc(wave).Spawns {
+			if !slices.Contains(beginner.EnemyTypes, spawn.Type) {
+				t.Errorf("Beginner wave %d spawns %v, outside its pool", wave, spawn.Type)
+			}
+		}
+	}
+	final := advanced.WaveFunc(advanced.TotalWaves)
+	if final.Spawns[0].Type != entities.EnemyBoss {
+		t.Errorf("Advanced final wave should open with a boss, got %v", final.Spawns[0].Type)
+	}
+}
+
+func TestRandomLevelIDRoundTrip(t *testing.T) {
+	id := RandomLevelID(LevelDifficultyAdvanced, 4242)
+	difficulty, seed, ok := ParseRandomLevelID(id)
+	if !ok || difficulty != LevelDifficultyAdvanced || seed != 4242 {
+		t.Errorf("ParseRandomLevelID(%q) = %v, %d, %v", id, difficulty, seed, ok)
+	}
+	for _, bad := range []string{"level-1", "random-expert-1", "random-beginner-x", "random-beginner"} {
+		if _, _, ok := ParseRandomLevelID(bad); ok {
+			t.Errorf("ParseRandomLevelID(%q) accepted a bad ID", bad)
+		}
+	}
+
+	level := NewLevelRegistry().GetByID(id)
+	if level == nil || level.ID != id {
+		t.Fatalf("Registry didn't generate %s", id)
+	}
+}
+
+func TestValidatePathRejectsBadPaths(t *testing.T) {
+	cross := pathOf([2]int{0, 1}, [2]int{1, 1}, [2]int{1, 2}, [2]int{0, 2}, [2]int{0, 1})
+	if err := ValidatePath(cross, 5, 5); err == nil {
+		t.Error("Accepted a path that crosses itself")
+	}
+	gap := pathOf([2]int{0, 1}, [2]int{2, 1})
+	if err := ValidatePath(gap, 5, 5); err == nil {
+		t.Error("Accepted a path with a gap")
+	}
+	outside := pathOf([2]int{0, 1}, [2]int{-1, 1})
+	if err := ValidatePath(outside, 5, 5); err == nil {
+		t.Error("Accepted a path leaving the grid")
+	}
+}
+
+func pathOf(cells ...[2]int) []entities.Position {
+	path := make([]entities.Position, 0, len(cells))
+	for _, c := range cells {
+		path = append(path, entities.Position{X: float64(c[0]), Y: float64(c[1])})
+	}
+	return path
+}
@@ -0,0 +1,204 @@
+package engine
+
+import (
+	"container/heap"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// Maze is the layout of an open-field level. There is no fixed path: enemies
+// walk the shortest route from Spawn to Exit around the towers, which act
+// as walls, and re-plan whenever a tower is placed.
+type Maze struct {
+	Spawn entities.Position
+	Exit  entities.Position
+}
+
+// gridCell is a cell of the grid in integer coordinates.
+type gridCell struct{ x, y int }
+
+func cellOf(p entities.Position) gridCell {
+	x, y := p.IntPos()
+	return gridCell{x, y}
+}
+
+func (c gridCell) pos() entities.Position {
+	return entities.Position{X: float64(c.x), Y: float64(c.y)}
+}
+
+func (c gridCell) distance(o gridCell) int {
+	return abs(c.x-o.x) + abs(c.y-o.y)
+}
+
+// routeDirections are tried in this order, so ties between equally short
+// routes always break the same way.
+var routeDirections = []gridCell{{1, 0}, {0, 1}, {0, -1}, {-1, 0}}
+
+// FindRoute returns the shortest orthogonal route from start to goal on a
+// width x height grid, both ends included, that avoids blocked cells. It
+// returns nil if blocked cells cut goal off from start.
+func FindRoute(width, height int, start, goal entities.Position, blocked func(x, y int) bool) []entities.Position {
+	from, to := cellOf(start), cellOf(goal)
+	inGrid := func(c gridCell) bool { return c.x >= 0 && c.x < width && c.y >= 0 && c.y < height }
+	if !inGrid(from) || !inGrid(to) {
+		return nil
+	}
+
+	// A* with a Manhattan heuristic
+	open := &routeQueue{}
+	cost := map[gridCell]int{from: 0}
+	came := make(map[gridCell]gridCell)
+	heap.Push(open, routeNode{cell: from, priority: from.distance(to)})
+	for open.Len() > 0 {
+		node := heap.Pop(open).(routeNode)
+		current := node.cell
+		if current == to {
+			route := []entities.Position{to.pos()}
+			for current != from {
+				current = came[current]
+				route = append(route, current.pos())
+			}
+			for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+				route[i], route[j] = route[j], route[i]
+			}
+			return route
+		}
+		if node.cost > cost[current] {
+			continue // Stale entry, a shorter way here was found since
+		}
+		for _, d := range routeDirections {
+			next := gridCell{current.x + d.x, current.y + d.y}
+			if !inGrid(next) || (next != to && blocked(next.x, next.y)) {
+				continue
+			}
+			nextCost := cost[current] + 1
+			if known, ok := cost[next]; ok && known <= nextCost {
+				continue
+			}
+			cost[next] = nextCost
+			came[next] = current
+			heap.Push(open, routeNode{cell: next, cost: nextCost, priority: nextCost + next.distance(to), seq: open.pushed})
+		}
+	}
+	return nil
+}
+
+// routeNode is a cell waiting in the A* open set.
+type routeNode struct {
+	cell     gridCell
+	cost     int
+	priority int
+	seq      int // Push order; breaks priority ties first in, first out
+}
+
+type routeQueue struct {
+	nodes  []routeNode
+	pushed int
+}
+
+func (q *routeQueue) Len() int { return len(q.nodes) }
+
+func (q *routeQueue) Less(i, j int) bool {
+	if q.nodes[i].priority != q.nodes[j].priority {
+		return q.nodes[i].priority < q.nodes[j].priority
+	}
+	return q.nodes[i].seq < q.nodes[j].seq
+}
+
+func (q *routeQueue) Swap(i, j int) { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+
+func (q *routeQueue) Push(x any) {
+	q.nodes = append(q.nodes, x.(routeNode))
+	q.pushed++
+}
+
+func (q *routeQueue) Pop() any {
+	last := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return last
+}
+
+// mazeRoute returns the shortest route from a cell to the exit around the
+// towers, or nil if they wall the exit off.
+func (g *Game) mazeRoute(from entities.Position) []entities.Position {
+	return FindRoute(g.Width, g.Height, from, g.Maze.Exit, g.HasTower)
+}
+
+// isMazeEnd reports whether x,y is the spawn or the exit of the maze.
+func (g *Game) isMazeEnd(x, y int) bool {
+	c := gridCell{x, y}
+	return c == cellOf(g.Maze.Spawn) || c == cellOf(g.Maze.Exit)
+}
+
+// enemyNextCell is the cell an enemy is walking into on its route, which
+// a re-planned route has to start from.
+func (g *Game) enemyNextCell(e *entities.Enemy) gridCell {
+	route := g.enemyRoutes[e.ID]
+	return cellOf(route[min(e.PathIndex+1, len(route)-1)])
+}
+
+// canWallMaze reports whether a tower at x,y leaves the exit reachable
+// from the spawn and from every walking enemy, and stays out of their way.
+func (g *Game) canWallMaze(x, y int) bool {
+	wall := gridCell{x, y}
+	if g.isMazeEnd(x, y) {
+		return false
+	}
+	for _, e := range g.Enemies {
+		if e.Dead || e.Flying() {
+			continue
+		}
+		route := g.enemyRoutes[e.ID]
+		if wall == cellOf(route[min(e.PathIndex, len(route)-1)]) || wall == g.enemyNextCell(e) {
+			return false
+		}
+	}
+
+	// One search from the exit finds every cell that can still reach it
+	reachable := map[gridCell]bool{cellOf(g.Maze.Exit): true}
+	queue := []gridCell{cellOf(g.Maze.Exit)}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, d := range routeDirections {
+			next := gridCell{c.x + d.x, c.y + d.y}
+			if next.x < 0 || next.x >= g.Width || next.y < 0 || next.y >= g.Height ||
+				reachable[next] || next == wall || g.HasTower(next.x, next.y) {
+				continue
+			}
+			reachable[next] = true
+			queue = append(queue, next)
+		}
+	}
+	if !reachable[cellOf(g.Maze.Spawn)] {
+		return false
+	}
+	for _, e := range g.Enemies {
+		if !e.Dead && !e.Flying() && !reachable[g.enemyNextCell(e)] {
+			return false
+		}
+	}
+	return true
+}
+
+// replanMaze routes new spawns and every enemy on the field around the
+// current towers. Enemies keep the part of their route they walked and the
+// cell they are stepping into, so they never jump and their progress still
+// says how far they have come.
+func (g *Game) replanMaze() {
+	if route := g.mazeRoute(g.Maze.Spawn); route != nil {
+		g.Path = route
+	}
+	for _, e := range g.Enemies {
+		if e.Flying() {
+			continue // Flyers cross the field in a straight line
+		}
+		old := g.enemyRoutes[e.ID]
+		next := min(e.PathIndex+1, len(old)-1)
+		rest := g.mazeRoute(old[next])
+		if rest == nil {
+			continue
+		}
+		g.enemyRoutes[e.ID] = append(old[:next:next], rest...)
+	}
+}
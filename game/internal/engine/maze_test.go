@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func pos(x, y int) entities.Position {
+	return entities.Position{X: float64(x), Y: float64(y)}
+}
+
+func TestFindRoute(t *testing.T) {
+	open := func(int, int) bool { return false }
+	route := FindRoute(5, 3, pos(0, 1), pos(4, 1), open)
+	if len(route) != 5 || route[0] != pos(0, 1) || route[4] != pos(4, 1) {
+		t.Fatalf("Open grid route = %v, want the straight line", route)
+	}
+
+	wall := func(x, y int) bool { return x == 2 && y <= 1 }
+	route = FindRoute(5, 3, pos(0, 1), pos(4, 1), wall)
+	if len(route) != 7 {
+		t.Errorf("Route around the wall has %d cells, want 7", len(route))
+	}
+	if err := ValidatePath(route, 5, 3); err != nil {
+		t.Errorf("Route isn't a valid path: %v", err)
+	}
+	if slices.ContainsFunc(route, func(p entities.Position) bool {
+		x, y := p.IntPos()
+		return wall(x, y)
+	}) {
+		t.Errorf("Route walks through the wall: %v", route)
+	}
+
+	blocked := func(x, _ int) bool { return x == 2 }
+	if route := FindRoute(5, 3, pos(0, 1), pos(4, 1), blocked); route != nil {
+		t.Errorf("Expected no route through a full wall, got %v", route)
+	}
+}
+
+func newMazeGame(t *testing.T) *Game {
+	t.Helper()
+	level := Level12()
+	g := NewGameFromLevelAndSettings(&level, DefaultGameSettings())
+	g.Gold = 10_000
+	g.WaveFunc = func(int) Wave { return Wave{} } // Only the enemies a test spawns
+	return g
+}
+
+func placeAt(g *Game, x, y int) bool {
+	g.CursorX, g.CursorY = x, y
+	return g.PlaceTower()
+}
+
+func TestMazeTowersReroute(t *testing.T) {
+	g := newMazeGame(t)
+	straight := len(g.Path)
+
+	if g.CanPlaceTower(0, 7) || g.CanPlaceTower(19, 7) {
+		t.Error("Towers can't go on the spawn or exit")
+	}
+	if !g.CanPlaceTower(5, 7) {
+		t.Fatal("Expected the open route to be buildable")
+	}
+	if !placeAt(g, 5, 7) {
+		t.Fatal("PlaceTower() on the route failed")
+	}
+	if len(g.Path) <= straight || slices.Contains(g.Path, pos(5, 7)) {
+		t.Errorf("Route should bend around the new tower, got %d cells: %v", len(g.Path), g.Path)
+	}
+}
+
+func TestMazeRejectsBlockingTower(t *testing.T) {
+	g := newMazeGame(t)
+	// Wall off column 10 but for the bottom cell
+	for y := range g.Height - 1 {
+		if !placeAt(g, 10, y) {
+			t.Fatalf("Placing wall at 10,%d failed", y)
+		}
+	}
+	if g.CanPlaceTower(10, g.Height-1) {
+		t.Error("A tower closing the last gap must be rejected")
+	}
+	if placeAt(g, 10, g.Height-1) {
+		t.Error("PlaceTower() sealed off the exit")
+	}
+	if !slices.Contains(g.Path, pos(10, g.Height-1)) {
+		t.Error("The route should run through the gap")
+	}
+}
+
+func TestMazeEnemiesReplanMidWalk(t *testing.T) {
+	g := newMazeGame(t)
+	g.SpawnEnemy(entities.EnemyBug)
+	enemy := g.Enemies[0]
+	enemy.MaxHealth, enemy.Health = 1_000_000, 1_000_000 // Walks past every tower
+	for range 30 {
+		g.Step()
+	}
+
+	x, y := enemy.Pos.IntPos()
+	if g.CanPlaceTower(x, y) || g.CanPlaceTower(x+1, y) {
+		t.Error("Towers can't go where an enemy stands or steps next")
+	}
+	if !placeAt(g, x+3, y) {
+		t.Fatal("Placing a tower ahead of the enemy failed")
+	}
+	if slices.Contains(g.enemyRoutes[enemy.ID][enemy.PathIndex:], pos(x+3, y)) {
+		t.Error("Enemy route still runs through the new tower")
+	}
+
+	var leaked *entities.Enemy
+	g.Events.Subscribe(func(e Event) {
+		if l, ok := e.(EnemyLeaked); ok {
+			leaked = l.Enemy
+		}
+	})
+	for range 60 * 60 {
+		g.Step()
+		ex, ey := enemy.Pos.IntPos()
+		if g.HasTower(ex, ey) {
+			t.Fatalf("Enemy walked into the tower at %d,%d", ex, ey)
+		}
+		if leaked != nil {
+			break
+		}
+	}
+	if leaked != enemy || enemy.Pos != g.Maze.Exit {
+		t.Errorf("Enemy should leave through the exit, ended at %v", enemy.Pos)
+	}
+	if len(g.enemyRoutes) != 0 {
+		t.Error("Routes of gone enemies should be dropped")
+	}
+}
+
+func TestMazeFlyersIgnoreWalls(t *testing.T) {
+	g := newMazeGame(t)
+	g.SpawnEnemy(entities.EnemyMoth)
+	moth := g.Enemies[0]
+	moth.MaxHealth, moth.Health = 1_000_000, 1_000_000
+	g.Step()
+
+	if !placeAt(g, 1, 7) {
+		t.Error("A flyer shouldn't keep towers off the cells below it")
+	}
+	for range 60 * 30 {
+		g.Step()
+		if len(g.Enemies) == 0 {
+			break
+		}
+		if _, y := moth.Pos.IntPos(); y != 7 {
+			t.Fatalf("Moth left the straight line at %v", moth.Pos)
+		}
+	}
+	if len(g.Enemies) != 0 {
+		t.Error("Moth never reached the exit")
+	}
+}
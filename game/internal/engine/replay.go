@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ReplayVersion is bumped whenever a change to the simulation would make
+// older replays play back differently.
+const ReplayVersion = 2
+
+// Replay is everything needed to reproduce a game: the level, the settings
+// (including the seed) and every player action with the tick it landed on.
+type Replay struct {
+	Version  int          `json:"version"`
+	LevelID  string       `json:"level_id"`
+	Settings GameSettings `json:"settings"`
+	Actions  []Action     `json:"actions"`
+	// Outcome as recorded, so a replay can be checked against its playback
+	FinalTick  uint64 `json:"final_tick"`
+	FinalWave  int    `json:"final_wave"`
+	FinalState string `json:"final_state,omitempty"`
+}
+
+// NewReplay starts an empty replay for a game created from level and settings.
+// settings.Seed must be the seed the game actually runs with.
+func NewReplay(level *Level, settings GameSettings) *Replay {
+	return &Replay{
+		Version:  ReplayVersion,
+		LevelID:  level.ID,
+		Settings: settings,
+		Actions:  make([]Action, 0),
+	}
+}
+
+// Finish stamps the replay with the game's final tick and outcome.
+func (r *Replay) Finish(g *Game) {
+	r.FinalTick = g.Tick
+	r.FinalWave = g.Wave
+	r.FinalState = g.outcome()
+}
+
+// Save writes the replay as JSON.
+func (r *Replay) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadReplay reads a replay written by Save.
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing replay: %w", err)
+	}
+	if r.Version != ReplayVersion {
+		return nil, fmt.Errorf("replay version %d, this build plays version %d", r.Version, ReplayVersion)
+	}
+	if r.Settings.Seed == 0 {
+		return nil, errors.New("replay has no seed")
+	}
+	return &r, nil
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// playActions runs a game for ticks steps, applying each action on its tick.
+func playActions(level *Level, settings GameSettings, actions []Action, ticks uint64) *Game {
+	g := NewGameFromLevelAndSettings(level, settings)
+	next := 0
+	for g.Tick < ticks {
+		for next < len(actions) && actions[next].Tick <= g.Tick {
+			g.Apply(actions[next])
+			next++
+		}
+		g.Step()
+	}
+	return g
+}
+
+func TestApply(t *testing.T) {
+	g := NewGame(20, 14)
+	g.Gold = 1000
+	g.CursorX, g.CursorY = 0, 0
+
+	tests := []struct {
+		name   string
+		action Action
+		want   bool
+	}{
+		{"move", Action{Kind: ActionMoveCursor, DX: 1}, true},
+		{"move into wall", Action{Kind: ActionMoveCursor, DY: -1}, false},
+		{"select", Action{Kind: ActionSelectTower, Tower: entities.TowerLSP}, true},
+		{"place", Action{Kind: ActionPlaceTower, X: 0, Y: 0}, true},
+		{"place on tower", Action{Kind: ActionPlaceTower, X: 0, Y: 0}, false},
+		{"upgrade", Action{Kind: ActionUpgradeTower, X: 0, Y: 0}, true},
+		{"end without challenge", Action{Kind: ActionEndChallenge, Success: true, Gold: 50}, false},
+		{"start challenge", Action{Kind: ActionStartChallenge}, true},
+		{"end challenge", Action{Kind: ActionEndChallenge, Success: true, Gold: 50}, true},
+		{"pause", Action{Kind: ActionTogglePause}, true},
+	}
+	for _, tt := range tests {
+		if got := g.Apply(tt.action); got != tt.want {
+			t.Errorf("%s: Apply() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if g.GetTowerAt(0, 0) == nil || g.GetTowerAt(0, 0).Type != entities.TowerLSP {
+		t.Error("Expected an LSP tower at 0,0")
+	}
+	if g.State != StatePaused {
+		t.Errorf("State = %v, want paused", g.State)
+	}
+}
+
+func TestReplayReproducesGame(t *testing.T) {
+	level := ClassicLevel()
+	settings := DefaultGameSettings()
+	settings.Seed = 99
+	actions := []Action{
+		{Tick: 0, Kind: ActionPlaceTower, X: 3, Y: 3},
+		{Tick: 30, Kind: ActionStartChallenge},
+		{Tick: 200, Kind: ActionEndChallenge, Success: true, Gold: 40},
+		{Tick: 201, Kind: ActionPlaceTower, X: 5, Y: 3},
+		{Tick: 400, Kind: ActionUpgradeTower, X: 3, Y: 3},
+	}
+
+	replay := NewReplay(&level, settings)
+	replay.Actions = actions
+	original := playActions(&level, settings, actions, 1200)
+	replay.Finish(original)
+
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("LoadReplay() error = %v", err)
+	}
+
+	replayed := playActions(&level, loaded.Settings, loaded.Actions, loaded.FinalTick)
+	if replayed.Gold != original.Gold || replayed.Health != original.Health || replayed.Wave != original.Wave {
+		t.Errorf("replayed gold/health/wave = %d/%d/%d, want %d/%d/%d",
+			replayed.Gold, replayed.Health, replayed.Wave, original.Gold, original.Health, original.Wave)
+	}
+	if len(replayed.Enemies) != len(original.Enemies) || len(replayed.Towers) != len(original.Towers) {
+		t.Errorf("replayed %d enemies, %d towers; want %d, %d",
+			len(replayed.Enemies), len(replayed.Towers), len(original.Enemies), len(original.Towers))
+	}
+}
+
+func TestLoadReplayRejectsOtherVersions(t *testing.T) {
+	level := ClassicLevel()
+	settings := DefaultGameSettings()
+	settings.Seed = 1
+	replay := NewReplay(&level, settings)
+	replay.Version = ReplayVersion + 1
+
+	path := filepath.Join(t.TempDir(), "future.json")
+	if err := replay.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := LoadReplay(path); err == nil {
+		t.Error("LoadReplay() accepted a replay from another version")
+	}
+}
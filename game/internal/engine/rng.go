@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"math/rand/v2"
+)
+
+// FixedTimestep is the simulation step in seconds. Game.Advance feeds real
+// elapsed time into an accumulator and always steps the simulation by this
+// amount, so a run depends only on the seed and the tick each input lands on.
+const FixedTimestep = 1.0 / 60.0
+
+// maxStepsPerAdvance caps the catch-up work after a long stall (suspended
+// terminal, debugger) so the game does not freeze replaying the backlog.
+const maxStepsPerAdvance = 15
+
+// Random streams derived from one seed. Challenge selection runs on the UI
+// and RPC side, so it gets its own generator and never shifts the sequence
+// the simulation draws from. Procedural waves draw from one stream per wave,
+// so a wave looks the same however often it is generated.
+const (
+	rngStreamGame uint64 = iota + 1
+	rngStreamChallenges
+	rngStreamWaves
+)
+
+// NewSeed returns a fresh random seed for a game that was not given one.
+func NewSeed() uint64 {
+	return rand.Uint64() //nolint:gosec // gameplay randomness, not security sensitive
+}
+
+// NewRNG returns the simulation's random generator for a seed.
+func NewRNG(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, rngStreamGame)) //nolint:gosec // deterministic by design
+}
+
+// NewChallengeRNG returns the challenge selection generator for a seed.
+func NewChallengeRNG(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, rngStreamChallenges)) //nolint:gosec // deterministic by design
+}
+
+// NewWaveRNG returns the generator for procedural wave waveNum of a seed.
+func NewWaveRNG(seed uint64, waveNum int) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, rngStreamWaves<<32|uint64(waveNum))) //nolint:gosec // deterministic by design
+}
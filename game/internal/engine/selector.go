@@ -1,8 +1,8 @@
 package engine
 
 import (
-	"crypto/rand"
-	"math/big"
+	"math"
+	"math/rand/v2"
 )
 
 const (
@@ -19,6 +19,32 @@ type ChallengeSelector struct {
 	manager            *ChallengeManager
 	recentChallengeIDs []string // Ring buffer, max DefaultMaxRecentChallenges
 	recentCategories   []string // Ring buffer, max DefaultMaxRecentCategories
+	results            map[string]categoryResult
+	rng                *rand.Rand
+}
+
+// categoryResult counts the player's challenges in one category.
+type categoryResult struct {
+	attempts  int
+	successes int
+}
+
+// CategoryChoice is one of a tower's challenge categories as the category
+// chooser lists it.
+type CategoryChoice struct {
+	Category  string
+	Count     int // Challenges available at the difficulty
+	Reward    int // Average base gold of those challenges
+	Attempts  int
+	Successes int
+}
+
+// Accuracy returns the share of attempts that succeeded, 0 before any.
+func (c CategoryChoice) Accuracy() float64 {
+	if c.Attempts == 0 {
+		return 0
+	}
+	return float64(c.Successes) / float64(c.Attempts)
 }
 
 // NewChallengeSelector creates a new selector wrapping the given manager.
@@ -27,9 +53,17 @@ func NewChallengeSelector(cm *ChallengeManager) *ChallengeSelector {
 		manager:            cm,
 		recentChallengeIDs: make([]string, 0, DefaultMaxRecentChallenges),
 		recentCategories:   make([]string, 0, DefaultMaxRecentCategories),
+		results:            make(map[string]categoryResult),
+		rng:                NewChallengeRNG(NewSeed()),
 	}
 }
 
+// SetRNG replaces the selector's random source, e.g. with NewChallengeRNG(seed)
+// so the same seed picks the same challenges in the same order.
+func (cs *ChallengeSelector) SetRNG(rng *rand.Rand) {
+	cs.rng = rng
+}
+
 // GetChallenge returns a challenge matching criteria while avoiding repetition.
 // If category is empty, any category is allowed but recently-used categories are penalized.
 func (cs *ChallengeSelector) GetChallenge(category string, maxDifficulty int) *Challenge {
@@ -61,12 +95,76 @@ func (cs *ChallengeSelector) GetChallenge(category string, maxDifficulty int) *C
 	return selected
 }
 
-// Reset clears all history (call when starting a new game).
+// Reset clears the repetition history (call when starting a new game).
+// The player's results per category are kept for the session.
 func (cs *ChallengeSelector) Reset() {
 	cs.recentChallengeIDs = cs.recentChallengeIDs[:0]
 	cs.recentCategories = cs.recentCategories[:0]
 }
 
+// Categories returns every challenge category in alphabetical order.
+func (cs *ChallengeSelector) Categories() []string {
+	return cs.manager.SortedCategories()
+}
+
+// RecordResult counts a finished challenge towards the player's accuracy in
+// its category.
+func (cs *ChallengeSelector) RecordResult(category string, success bool) {
+	if category == "" {
+		return
+	}
+	r := cs.results[category]
+	r.attempts++
+	if success {
+		r.successes++
+	}
+	cs.results[category] = r
+}
+
+// Choices lists categories with how many challenges each has up to
+// maxDifficulty, what they pay and how the player has done in them.
+func (cs *ChallengeSelector) Choices(categories []string, maxDifficulty int) []CategoryChoice {
+	choices := make([]CategoryChoice, 0, len(categories))
+	for _, category := range categories {
+		r := cs.results[category]
+		candidates := cs.manager.GetCandidates(category, maxDifficulty)
+		gold := 0
+		for _, c := range candidates {
+			gold += c.GoldBase
+		}
+		choice := CategoryChoice{
+			Category:  category,
+			Count:     len(candidates),
+			Attempts:  r.attempts,
+			Successes: r.successes,
+		}
+		if len(candidates) > 0 {
+			choice.Reward = int(math.Round(float64(gold) / float64(len(candidates))))
+		}
+		choices = append(choices, choice)
+	}
+	return choices
+}
+
+// NextCategory picks one of categories for a player who didn't choose. It
+// rotates through them: recently played categories are penalized like in
+// GetChallenge, and categories the player is less accurate in weigh more.
+// The pick is the heaviest category, so it can be previewed before the
+// challenge starts. Categories without challenges up to maxDifficulty are
+// skipped; it returns "" if none has any.
+func (cs *ChallengeSelector) NextCategory(categories []string, maxDifficulty int) string {
+	best, bestWeight := "", 0.0
+	for _, c := range cs.Choices(categories, maxDifficulty) {
+		if c.Count == 0 {
+			continue
+		}
+		if w := cs.categoryWeight(c.Category) * (2 - c.Accuracy()); w > bestWeight {
+			best, bestWeight = c.Category, w
+		}
+	}
+	return best
+}
+
 // filterRecent removes challenges that appear in recentChallengeIDs.
 func (cs *ChallengeSelector) filterRecent(candidates []*Challenge) []*Challenge {
 	recentSet := make(map[string]bool, len(cs.recentChallengeIDs))
@@ -164,23 +262,11 @@ func (cs *ChallengeSelector) weightedRandom(candidates []*Challenge, weights []f
 
 	if totalWeight <= 0 {
 		// All weights zero - fallback to uniform random
-		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidates))))
-		if err != nil {
-			return candidates[0]
-		}
-		return candidates[n.Int64()]
+		return candidates[cs.rng.IntN(len(candidates))]
 	}
 
 	// Generate random value in [0, totalWeight)
-	// Using crypto/rand for consistency with existing code
-	// Scale up for integer precision (multiply by 10000 for 4 decimal places)
-	const scale = 10000
-	maxInt := int64(totalWeight * scale)
-	n, err := rand.Int(rand.Reader, big.NewInt(maxInt))
-	if err != nil {
-		return candidates[0]
-	}
-	r := float64(n.Int64()) / scale
+	r := cs.rng.Float64() * totalWeight
 
 	// Find the selected candidate
 	var cumulative float64
@@ -248,3 +248,94 @@ func TestGetCandidates(t *testing.T) {
 		}
 	}
 }
+
+func TestSelectorSeededSequence(t *testing.T) {
+	cm, err := NewChallengeManager()
+	if err != nil {
+		t.Fatalf("NewChallengeManager() error = %v", err)
+	}
+
+	a := NewChallengeSelector(cm)
+	b := NewChallengeSelector(cm)
+	a.SetRNG(NewChallengeRNG(7))
+	b.SetRNG(NewChallengeRNG(7))
+
+	for i := range 40 {
+		ca, cb := a.GetChallenge("", 3), b.GetChallenge("", 3)
+		if ca.ID != cb.ID {
+			t.Fatalf("selection %d: %s != %s with the same seed", i, ca.ID, cb.ID)
+		}
+	}
+}
+
+func TestSelectorChoicesTrackAccuracy(t *testing.T) {
+	cm, err := NewChallengeManager()
+	if err != nil {
+		t.Fatalf("NewChallengeManager() error = %v", err)
+	}
+
+	cs := NewChallengeSelector(cm)
+	cs.RecordResult("movement", true)
+	cs.RecordResult("movement", false)
+	cs.RecordResult("movement", true)
+	cs.RecordResult("", true) // Ignored
+	cs.Reset()
+
+	choices := cs.Choices([]string{"movement", "folding", "no-such-category"}, 0)
+	if len(choices) != 3 {
+		t.Fatalf("Expected 3 choices, got %d", len(choices))
+	}
+	if c := choices[0]; c.Count != len(cm.GetChallengesByCategory("movement")) || c.Attempts != 3 || c.Successes != 2 {
+		t.Errorf("movement choice = %+v, want all its challenges and 2 of 3 solved", c)
+	}
+	if acc := choices[0].Accuracy(); acc < 0.66 || acc > 0.67 {
+		t.Errorf("Accuracy() = %v, want 2/3", acc)
+	}
+	if c := choices[1]; c.Attempts != 0 || c.Accuracy() != 0 {
+		t.Errorf("folding choice = %+v, want no attempts", c)
+	}
+	if choices[0].Reward < 20 || choices[0].Reward > 100 {
+		t.Errorf("movement Reward = %d, want the average base gold", choices[0].Reward)
+	}
+	if choices[2].Count != 0 || choices[2].Reward != 0 {
+		t.Errorf("Unknown category has %d challenges worth %dg", choices[2].Count, choices[2].Reward)
+	}
+}
+
+func TestSelectorNextCategoryRotates(t *testing.T) {
+	cm, err := NewChallengeManager()
+	if err != nil {
+		t.Fatalf("NewChallengeManager() error = %v", err)
+	}
+
+	cs := NewChallengeSelector(cm)
+	categories := []string{"movement", "buffer-management", "window-management", "quickfix", "folding", "no-such-category"}
+
+	// The player has mastered movement, so the rotation favours the rest
+	for range 10 {
+		cs.RecordResult("movement", true)
+	}
+	picked := make(map[string]int)
+	previous := ""
+	for range 200 {
+		category := cs.NextCategory(categories, 0)
+		if category == previous {
+			t.Fatalf("Rotation picked %s twice in a row", category)
+		}
+		picked[category]++
+		cs.GetChallenge(category, 0)
+		previous = category
+	}
+	if picked["no-such-category"] > 0 {
+		t.Error("Rotation picked a category without challenges")
+	}
+	for _, category := range categories[1:5] {
+		if picked[category] <= picked["movement"] {
+			t.Errorf("%s picked %d times, movement %d; want the weaker categories more often", category, picked[category], picked["movement"])
+		}
+	}
+
+	if got := cs.NextCategory([]string{"no-such-category"}, 0); got != "" {
+		t.Errorf("NextCategory() = %q without any challenges, want \"\"", got)
+	}
+}
@@ -33,10 +33,24 @@ func (s GameSpeed) String() string {
 
 // GameSettings holds all configurable game settings.
 type GameSettings struct {
-	Difficulty     string    // "easy", "normal", "hard"
-	GameSpeed      GameSpeed // Time multiplier
-	StartingGold   int       // Initial gold (100-500)
-	StartingHealth int       // Initial health (50-200)
+	Difficulty     string    `json:"difficulty"`            // "easy", "normal", "hard" or a custom profile's name
+	GameSpeed      GameSpeed `json:"game_speed"`            // Time multiplier
+	StartingGold   int       `json:"starting_gold"`         // Initial gold (100-500)
+	StartingHealth int       `json:"starting_health"`       // Initial health (50-200)
+	Seed           uint64    `json:"seed"`                  // Random seed; 0 picks a fresh one per game
+	Endless        bool      `json:"endless,omitempty"`     // Procedural waves until the player loses
+	BuildPhase     bool      `json:"build_phase,omitempty"` // Freeze before each wave to build with undo
+	Director       bool      `json:"director,omitempty"`    // Adapt upcoming waves to how the player is doing
+
+	// Economy of a custom difficulty profile, nil for the built-in presets.
+	// Kept in the settings so replays play back with it.
+	Economy *EconomyConfig `json:"economy,omitempty"`
+}
+
+// UseProfile selects a custom difficulty profile.
+func (s *GameSettings) UseProfile(p DifficultyProfile) {
+	economy := p.Economy
+	s.Difficulty, s.Economy = p.Name, &economy
 }
 
 // DefaultGameSettings returns settings with sensible defaults.
@@ -51,12 +65,13 @@ func DefaultGameSettings() GameSettings {
 
 // Validate ensures settings are within valid ranges.
 func (s *GameSettings) Validate() {
-	// Validate difficulty
-	switch s.Difficulty {
-	case DifficultyEasy, DifficultyNormal, DifficultyHard:
-		// Valid
-	default:
-		s.Difficulty = DifficultyNormal
+	// Validate difficulty: a built-in preset, or a custom profile that
+	// brings its economy along
+	switch {
+	case IsBuiltinDifficulty(s.Difficulty):
+		s.Economy = nil
+	case s.Economy == nil || s.Difficulty == "" || checkEconomy(*s.Economy) != nil:
+		s.Difficulty, s.Economy = DifficultyNormal, nil
 	}
 
 	// Validate game speed
@@ -90,5 +105,8 @@ func (s *GameSettings) Validate() {
 
 // GetEconomyConfig returns the economy configuration based on difficulty.
 func (s *GameSettings) GetEconomyConfig() EconomyConfig {
+	if s.Economy != nil {
+		return *s.Economy
+	}
 	return EconomyConfigForDifficulty(s.Difficulty)
 }
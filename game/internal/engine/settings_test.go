@@ -105,6 +105,23 @@ func TestGameSettingsValidate(t *testing.T) {
 		}
 	})
 
+	t.Run("keeps a custom profile with its economy", func(t *testing.T) {
+		var settings GameSettings
+		settings.UseProfile(DifficultyProfile{Name: "brutal", Economy: EconomyConfigForDifficulty(DifficultyHard)})
+		settings.Validate()
+		if settings.Difficulty != "brutal" || settings.Economy == nil {
+			t.Errorf("Expected the brutal profile preserved, got %s", settings.Difficulty)
+		}
+	})
+
+	t.Run("corrects a custom name without an economy", func(t *testing.T) {
+		settings := GameSettings{Difficulty: "brutal"}
+		settings.Validate()
+		if settings.Difficulty != DifficultyNormal {
+			t.Errorf("Expected difficulty corrected to normal, got %s", settings.Difficulty)
+		}
+	})
+
 	t.Run("corrects invalid game speed", func(t *testing.T) {
 		settings := GameSettings{GameSpeed: 3.0}
 		settings.Validate()
@@ -231,6 +248,7 @@ func TestNewGameFromLevelAndSettings(t *testing.T) {
 		GameSpeed:      SpeedDouble,
 		StartingGold:   300,
 		StartingHealth: 150,
+		Seed:           1234,
 	}
 
 	game := NewGameFromLevelAndSettings(&level, settings)
@@ -244,6 +262,12 @@ func TestNewGameFromLevelAndSettings(t *testing.T) {
 		}
 	})
 
+	t.Run("uses settings seed", func(t *testing.T) {
+		if game.Seed != settings.Seed {
+			t.Errorf("Expected seed %d, got %d", settings.Seed, game.Seed)
+		}
+	})
+
 	t.Run("uses settings starting gold", func(t *testing.T) {
 		if game.Gold != settings.StartingGold {
 			t.Errorf("Expected gold %d, got %d", settings.StartingGold, game.Gold)
@@ -0,0 +1,291 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// maxSimulationTicks stops a simulation that never ends, e.g. a level whose
+// enemies can't reach the end: one hour of game time.
+const maxSimulationTicks = 60 * 60 * 60
+
+// BuildStep is one entry of a scripted build order: from the start of Wave,
+// place Tower at X,Y, or upgrade the tower there when Upgrade is set. Once
+// the tiers are done an upgrade has to name the Branch to take. Steps run
+// in order, each waiting for its wave and for enough gold.
+type BuildStep struct {
+	Wave    int    `yaml:"wave" json:"wave"`
+	Tower   string `yaml:"tower,omitempty" json:"tower,omitempty"`
+	X       int    `yaml:"x" json:"x"`
+	Y       int    `yaml:"y" json:"y"`
+	Upgrade bool   `yaml:"upgrade,omitempty" json:"upgrade,omitempty"`
+	Branch  string `yaml:"branch,omitempty" json:"branch,omitempty"`
+}
+
+// UnbuiltStep is a build step the simulation could not carry out.
+type UnbuiltStep struct {
+	BuildStep
+	Reason string `json:"reason"`
+}
+
+// WaveStats summarizes one wave, from its start until the next one begins.
+type WaveStats struct {
+	Wave       int     `json:"wave"`
+	HealthLost int     `json:"health_lost"`
+	GoldStart  int     `json:"gold_start"`
+	GoldEnd    int     `json:"gold_end"`
+	GoldSpent  int     `json:"gold_spent"`
+	Kills      int     `json:"kills"`
+	Seconds    float64 `json:"seconds"`
+}
+
+// TowerStats summarizes one tower at the end of a simulation.
+type TowerStats struct {
+	ID         int    `json:"id"`
+	Type       string `json:"type"`
+	X          int    `json:"x"`
+	Y          int    `json:"y"`
+	Level      int    `json:"level"`
+	PlacedWave int    `json:"placed_wave"`
+	Kills      int    `json:"kills"`
+}
+
+// SimulationResult is the outcome of a headless run.
+type SimulationResult struct {
+	Level      string        `json:"level"`
+	Difficulty string        `json:"difficulty"`
+	Seed       uint64        `json:"seed"`
+	Won        bool          `json:"won"`
+	FinalWave  int           `json:"final_wave"`
+	Health     int           `json:"health"`
+	Gold       int           `json:"gold"`
+	Seconds    float64       `json:"seconds"`
+	Waves      []WaveStats   `json:"waves"`
+	Towers     []TowerStats  `json:"towers"`
+	Unbuilt    []UnbuiltStep `json:"unbuilt,omitempty"`
+
+	Director []DirectorAdjustment `json:"director,omitempty"` // Changes the director made, in order
+}
+
+// ParseBuildOrder reads a YAML list of build steps and checks tower names.
+func ParseBuildOrder(data []byte) ([]BuildStep, error) {
+	var order []BuildStep
+	if err := yaml.Unmarshal(data, &order); err != nil {
+		return nil, err
+	}
+	for i, step := range order {
+		if step.Upgrade {
+			continue
+		}
+		if _, ok := entities.TowerTypeByName(step.Tower); !ok {
+			return nil, fmt.Errorf("step %d: unknown tower %q", i+1, step.Tower)
+		}
+	}
+	return order, nil
+}
+
+// simulation tracks a headless run as it steps through the game.
+type simulation struct {
+	game    *Game
+	level   *Level
+	order   []BuildStep
+	next    int
+	placed  map[int]int // Tower ID -> wave it was placed in
+	current WaveStats
+	open    bool // current holds a wave still in progress
+	result  SimulationResult
+}
+
+// Simulate plays a level with a build order as fast as possible, without a
+// UI or challenges, and reports per-wave and per-tower statistics.
+func Simulate(level *Level, settings GameSettings, order []BuildStep) SimulationResult {
+	g := NewGameFromLevelAndSettings(level, settings)
+	s := &simulation{
+		game:   g,
+		level:  level,
+		order:  order,
+		placed: make(map[int]int),
+		result: SimulationResult{
+			Level:      level.ID,
+			Difficulty: settings.Difficulty,
+			Seed:       g.Seed,
+		},
+	}
+	g.Events.Subscribe(s.observe)
+	s.startWave()
+
+	for g.Tick < maxSimulationTicks && g.State != StateGameOver && g.State != StateVictory {
+		s.build()
+		wave := g.Wave
+		g.Step()
+		if g.Wave != wave {
+			s.endWave()
+			if g.Wave <= g.TotalWaves {
+				s.startWave()
+			}
+		}
+	}
+	s.endWave()
+
+	return s.finish()
+}
+
+// SimulateSeeds runs one simulation per seed concurrently, for balance sweeps
+// over the randomness in a level. Results are in the order of seeds.
+func SimulateSeeds(level *Level, settings GameSettings, order []BuildStep, seeds []uint64) []SimulationResult {
+	results := make([]SimulationResult, len(seeds))
+	var wg sync.WaitGroup
+	for i, seed := range seeds {
+		wg.Go(func() {
+			runSettings := settings
+			runSettings.Seed = seed
+			results[i] = Simulate(level, runSettings, order)
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+func (s *simulation) startWave() {
+	s.open = true
+	s.current = WaveStats{
+		Wave:       s.game.Wave,
+		HealthLost: s.game.Health,
+		GoldStart:  s.game.Gold,
+		Seconds:    float64(s.game.Tick) * FixedTimestep,
+	}
+}
+
+// endWave closes the current wave's stats; the start values stored by
+// startWave become deltas.
+func (s *simulation) endWave() {
+	if !s.open {
+		return
+	}
+	s.open = false
+	w := s.current
+	w.HealthLost -= s.game.Health
+	w.GoldEnd = s.game.Gold
+	w.Seconds = float64(s.game.Tick)*FixedTimestep - w.Seconds
+	s.result.Waves = append(s.result.Waves, w)
+}
+
+// observe collects the wave's kills and spending from game events.
+func (s *simulation) observe(e Event) {
+	switch e := e.(type) {
+	case EnemyKilled:
+		s.current.Kills++
+	case GoldChanged:
+		if e.Delta < 0 {
+			s.current.GoldSpent -= e.Delta
+		}
+	case TowerPlaced:
+		s.placed[e.Tower.ID] = s.game.Wave
+	}
+}
+
+// build carries out due build steps in order. A step that can never run is
+// skipped; one that only lacks gold holds up the rest of the order.
+func (s *simulation) build() {
+	for s.next < len(s.order) && s.order[s.next].Wave <= s.game.Wave {
+		step := s.order[s.next]
+		done, reason := s.try(step)
+		if !done && reason == "" {
+			return
+		}
+		if !done {
+			s.result.Unbuilt = append(s.result.Unbuilt, UnbuiltStep{BuildStep: step, Reason: reason})
+		}
+		s.next++
+	}
+}
+
+// try attempts a build step. It returns a reason when the step can never succeed.
+func (s *simulation) try(step BuildStep) (bool, string) {
+	g := s.game
+	tick := g.Tick
+
+	if step.Upgrade {
+		return s.tryUpgrade(step)
+	}
+
+	towerType, _ := entities.TowerTypeByName(step.Tower)
+	switch {
+	case !slices.Contains(s.level.AllowedTowers, towerType):
+		return false, "tower not allowed on this level"
+	case !g.CanPlaceTower(step.X, step.Y):
+		return false, "position blocked"
+	case g.Gold < g.TowerCost(towerType):
+		return false, ""
+	}
+	g.Apply(Action{Tick: tick, Kind: ActionSelectTower, Tower: towerType})
+	if !g.Apply(Action{Tick: tick, Kind: ActionPlaceTower, X: step.X, Y: step.Y}) {
+		return false, "placement failed"
+	}
+	return true, ""
+}
+
+// tryUpgrade attempts an upgrade step: the next tier, or the step's branch.
+func (s *simulation) tryUpgrade(step BuildStep) (bool, string) {
+	g := s.game
+	tower := g.GetTowerAt(step.X, step.Y)
+	switch {
+	case tower == nil:
+		return false, "no tower to upgrade"
+	case !tower.CanUpgrade():
+		return false, "tower fully upgraded"
+	case tower.NeedsBranch() && step.Branch == "":
+		return false, "tower needs a branch"
+	case !tower.NeedsBranch() && step.Branch != "":
+		return false, "tower can't take a branch yet"
+	}
+	cost := g.UpgradeCost(tower)
+	if step.Branch != "" {
+		branch, ok := tower.Info().Branch(step.Branch)
+		if !ok {
+			return false, "unknown branch"
+		}
+		cost = g.BranchCost(branch)
+	}
+	if g.Gold < cost {
+		return false, ""
+	}
+	if !g.Apply(Action{Tick: g.Tick, Kind: ActionUpgradeTower, X: step.X, Y: step.Y, Branch: step.Branch}) {
+		return false, "upgrade failed"
+	}
+	return true, ""
+}
+
+func (s *simulation) finish() SimulationResult {
+	g := s.game
+	for _, step := range s.order[s.next:] {
+		s.result.Unbuilt = append(s.result.Unbuilt, UnbuiltStep{BuildStep: step, Reason: "never affordable or wave not reached"})
+	}
+
+	s.result.Won = g.State == StateVictory
+	s.result.FinalWave = min(g.Wave, g.TotalWaves)
+	s.result.Health = g.Health
+	s.result.Gold = g.Gold
+	s.result.Seconds = float64(g.Tick) * FixedTimestep
+	if g.Director != nil {
+		s.result.Director = slices.Clone(g.Director.Log)
+	}
+	for _, t := range g.Towers {
+		x, y := t.Pos.IntPos()
+		s.result.Towers = append(s.result.Towers, TowerStats{
+			ID:         t.ID,
+			Type:       t.Info().Name,
+			X:          x,
+			Y:          y,
+			Level:      t.Level,
+			PlacedWave: s.placed[t.ID],
+			Kills:      t.Kills,
+		})
+	}
+	return s.result
+}
@@ -0,0 +1,127 @@
+package engine
+
+import "testing"
+
+func TestParseBuildOrder(t *testing.T) {
+	order, err := ParseBuildOrder([]byte(`
+- {wave: 1, tower: Arrow, x: 3, y: 3}
+- {wave: 2, upgrade: true, x: 3, y: 3}
+`))
+	if err != nil {
+		t.Fatalf("ParseBuildOrder() error = %v", err)
+	}
+	if len(order) != 2 || !order[1].Upgrade || order[0].Tower != "Arrow" {
+		t.Errorf("ParseBuildOrder() = %+v", order)
+	}
+
+	if _, err := ParseBuildOrder([]byte(`- {wave: 1, tower: ballista, x: 0, y: 0}`)); err == nil {
+		t.Error("ParseBuildOrder() accepted an unknown tower")
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Seed = 1
+	path := level.Path[0]
+	order := []BuildStep{
+		{Wave: 1, Tower: "arrow", X: 3, Y: 3},
+		{Wave: 1, Tower: "arrow", X: 6, Y: 5},
+		{Wave: 2, Tower: "arrow", X: int(path.X), Y: int(path.Y)},
+		{Wave: 3, Upgrade: true, X: 3, Y: 3},
+	}
+
+	result := Simulate(&level, settings, order)
+
+	if !result.Won {
+		t.Errorf("Expected a win with two towers on level 1, result: %+v", result)
+	}
+	if len(result.Waves) != level.TotalWaves {
+		t.Errorf("Got stats for %d waves, want %d", len(result.Waves), level.TotalWaves)
+	}
+	if len(result.Towers) != 2 || result.Towers[0].Level != 1 {
+		t.Errorf("Towers = %+v, want two with the first upgraded", result.Towers)
+	}
+	kills := 0
+	for _, w := range result.Waves {
+		kills += w.Kills
+	}
+	if kills == 0 || kills != result.Towers[0].Kills+result.Towers[1].Kills {
+		t.Errorf("Wave kills %d don't match tower kills %+v", kills, result.Towers)
+	}
+	if len(result.Unbuilt) != 1 || result.Unbuilt[0].Reason != "position blocked" {
+		t.Errorf("Unbuilt = %+v, want the step on the path", result.Unbuilt)
+	}
+
+	again := Simulate(&level, settings, order)
+	if again.Gold != result.Gold || again.Seconds != result.Seconds {
+		t.Error("Simulate() is not deterministic for the same seed")
+	}
+}
+
+func TestSimulateBranchSteps(t *testing.T) {
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Seed = 1
+	order := []BuildStep{
+		{Wave: 1, Tower: "arrow", X: 3, Y: 3},
+		{Wave: 1, Upgrade: true, X: 3, Y: 3},
+		{Wave: 1, Upgrade: true, X: 3, Y: 3},
+		{Wave: 1, Tower: "arrow", X: 6, Y: 5},
+		{Wave: 2, Upgrade: true, X: 3, Y: 3, Branch: "Rapid"},
+	}
+
+	result := Simulate(&level, settings, order)
+
+	if len(result.Unbuilt) != 1 || result.Unbuilt[0].Reason != "tower needs a branch" {
+		t.Fatalf("Unbuilt = %+v, want only the upgrade without a branch", result.Unbuilt)
+	}
+	if len(result.Towers) != 2 || result.Towers[0].Level != 2 {
+		t.Errorf("Towers = %+v, want two with the first specialised", result.Towers)
+	}
+}
+
+func TestSimulateHealthLost(t *testing.T) {
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Seed = 1
+
+	result := Simulate(&level, settings, nil)
+
+	lost := 0
+	for _, w := range result.Waves {
+		lost += w.HealthLost
+	}
+	if lost == 0 {
+		t.Error("Expected enemies to leak with no towers built")
+	}
+	if lost != settings.StartingHealth-result.Health {
+		t.Errorf("Health lost across waves = %d, want %d", lost, settings.StartingHealth-result.Health)
+	}
+}
+
+func TestSimulateSeedsConcurrently(t *testing.T) {
+	level := Level2()
+	order := []BuildStep{
+		{Wave: 1, Tower: "arrow", X: 3, Y: 3},
+		{Wave: 2, Tower: "lsp", X: 6, Y: 5},
+	}
+	seeds := []uint64{1, 2, 3, 1, 2, 3, 1, 2}
+
+	results := SimulateSeeds(&level, DefaultGameSettings(), order, seeds)
+
+	for i, r := range results {
+		if r.Seed != seeds[i] {
+			t.Errorf("results[%d].Seed = %d, want %d", i, r.Seed, seeds[i])
+		}
+		first := results[i%3]
+		if r.Gold != first.Gold || r.Health != first.Health || r.Seconds != first.Seconds {
+			t.Errorf("seed %d run %d diverged from its first run", seeds[i], i)
+		}
+		for j, tower := range r.Towers {
+			if tower.ID != j+1 {
+				t.Errorf("run %d: tower %d has ID %d; IDs leaked between games", i, j, tower.ID)
+			}
+		}
+	}
+}
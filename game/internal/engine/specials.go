@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+const (
+	pierceRadius = 1.0 // Cells behind the target a piercing shot reaches
+	pierceHits   = 2   // Enemies behind the target a piercing shot hits
+	splashRadius = 1.5 // Cells around the target a splash hits
+	chainRange   = 3.0 // Cells a chained hit can jump
+)
+
+// applySlowAuras marks every enemy in range of a slowing tower as slowed
+// for this step.
+func (g *Game) applySlowAuras() {
+	for _, e := range g.Enemies {
+		e.Slowed = false
+		for _, t := range g.Towers {
+			if t.Special() == entities.SpecialSlowAura && !t.Disabled() && t.InRange(e.Pos) &&
+				(!e.Flying() || t.Info().AntiAir) {
+				e.Slowed = true
+				break
+			}
+		}
+	}
+}
+
+// hitSpecial adds what a tower's branch and ability do when its projectile
+// lands on target. A sold tower's projectiles have neither.
+func (g *Game) hitSpecial(tower *entities.Tower, target *entities.Enemy, damage int) {
+	if tower == nil {
+		return
+	}
+	if tower.Charged(entities.AbilityPierce) && tower.Special() != entities.SpecialPierce {
+		g.pierce(tower, target, int(float64(damage)*min(tower.AbilityStrength, 1)))
+	}
+	switch tower.Special() {
+	case entities.SpecialPierce:
+		g.pierce(tower, target, damage)
+	case entities.SpecialSplash:
+		for _, e := range g.enemiesNear(tower, target.Pos, splashRadius, target) {
+			g.damageEnemy(e, damage/2, tower)
+		}
+	case entities.SpecialChain:
+		if near := g.enemiesNear(tower, target.Pos, chainRange, target); len(near) > 0 {
+			g.damageEnemy(near[0], damage/2, tower)
+		}
+	default:
+		// Other specials change targeting or movement, not hits
+	}
+}
+
+// pierce deals damage to the enemies right behind target.
+func (g *Game) pierce(tower *entities.Tower, target *entities.Enemy, damage int) {
+	hits := 0
+	for _, e := range g.enemiesNear(tower, target.Pos, pierceRadius, target) {
+		if hits == pierceHits {
+			break
+		}
+		if progress(e) < progress(target) {
+			g.damageEnemy(e, damage, tower)
+			hits++
+		}
+	}
+}
+
+// chargeTower gives the tower the challenge was started on its ability,
+// scaled by how well the challenge went.
+func (g *Game) chargeTower(efficiency, speedBonus float64) {
+	tower := g.ChallengeTower
+	if tower == nil || g.towerByID(tower.ID) == nil {
+		return
+	}
+	tower.Charge(g.Economy.CalculateAbilityStrength(efficiency, speedBonus), g.Economy.AbilityDuration)
+	g.Events.Publish(TowerCharged{Tower: tower})
+}
+
+// enemiesNear returns the living enemies within radius of pos other than
+// skip that the tower can hit, nearest first.
+func (g *Game) enemiesNear(tower *entities.Tower, pos entities.Position, radius float64, skip *entities.Enemy) []*entities.Enemy {
+	var near []*entities.Enemy
+	for _, e := range g.Enemies {
+		if e != skip && !e.Dead && math.Sqrt(pos.Distance(e.Pos)) <= radius && (!e.Flying() || tower.Info().AntiAir) {
+			near = append(near, e)
+		}
+	}
+	slices.SortStableFunc(near, func(a, b *entities.Enemy) int {
+		return cmp.Compare(pos.Distance(a.Pos), pos.Distance(b.Pos))
+	})
+	return near
+}
+
+// progress is how far an enemy has come, as towers compare it.
+func progress(e *entities.Enemy) float64 {
+	return float64(e.PathIndex) + e.PathProg
+}
@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// newBranchGame returns a game with one tower of the given branch at 5,5
+// and no waves.
+func newBranchGame(t *testing.T, towerType entities.TowerType, branch string) (*Game, *entities.Tower) {
+	t.Helper()
+	g := NewGame(20, 20)
+	g.WaveFunc = func(int) Wave { return Wave{} }
+	g.Path = nil // Keep the whole grid buildable
+	g.Gold = 10_000
+	g.SelectedTower = towerType
+	g.CursorX, g.CursorY = 5, 5
+	if !g.PlaceTower() || !g.UpgradeTower() {
+		t.Fatal("Could not place and upgrade the tower")
+	}
+	if !g.Apply(Action{Kind: ActionUpgradeTower, X: 5, Y: 5, Branch: branch}) {
+		t.Fatalf("Could not take the %s branch", branch)
+	}
+	return g, g.Towers[0]
+}
+
+// enemyAt adds a stationary enemy of the given type.
+func enemyAt(g *Game, enemyType entities.EnemyType, x, y float64) *entities.Enemy {
+	e := entities.NewEnemy(g.enemyIDs.Next(), enemyType, entities.Position{X: x, Y: y})
+	e.Speed = 0
+	g.Enemies = append(g.Enemies, e)
+	return e
+}
+
+func TestSpecializeTower(t *testing.T) {
+	g, tower := newBranchGame(t, entities.TowerArrow, "piercing")
+	if tower.Branch != "Piercing" {
+		t.Fatalf("Branch = %q, want Piercing", tower.Branch)
+	}
+	g.CursorX, g.CursorY = 5, 5
+	if g.UpgradeTower() || g.SpecializeTower("Rapid") {
+		t.Error("A specialised tower upgraded again")
+	}
+	if r := g.Report(); r.Towers[0].Branch != "Piercing" {
+		t.Errorf("Report branch = %q, want Piercing", r.Towers[0].Branch)
+	}
+	if tower.Paid == 0 {
+		t.Fatal("Paid = 0 after placing, upgrading and specialising")
+	}
+	if want := int(float64(tower.Paid)*sellRefund + 0.5); g.SellValue(tower) != want {
+		t.Errorf("SellValue() = %d, want %d", g.SellValue(tower), want)
+	}
+}
+
+func TestSpecializeUndoInBuildPhase(t *testing.T) {
+	g := newBuildGame(t)
+	x, y := buildSpot(t, g)
+	placeAt(g, x, y)
+	g.UpgradeTower()
+	tower := g.GetTowerAt(x, y)
+	damage := tower.Damage
+	if !g.SpecializeTower("Rapid") {
+		t.Fatal("Could not specialise")
+	}
+	if !g.Undo() || tower.Branch != "" || tower.Damage != damage || !tower.NeedsBranch() {
+		t.Fatalf("Undo left branch %q damage %d", tower.Branch, tower.Damage)
+	}
+	if !g.Redo() || tower.Branch != "Rapid" {
+		t.Errorf("Redo left branch %q, want Rapid", tower.Branch)
+	}
+}
+
+func TestMultiShotFiresTwice(t *testing.T) {
+	g, _ := newBranchGame(t, entities.TowerArrow, "Rapid")
+	first := enemyAt(g, entities.EnemyDaemon, 6, 5)
+	second := enemyAt(g, entities.EnemyDaemon, 4, 5)
+	g.updateTowers(0.01)
+	if len(g.Projectiles) != 2 || g.Projectiles[0].TargetID == g.Projectiles[1].TargetID {
+		t.Fatalf("Rapid tower fired %d projectiles, want one at each of %d and %d", len(g.Projectiles), first.ID, second.ID)
+	}
+}
+
+func TestHitSpecials(t *testing.T) {
+	tests := []struct {
+		name   string
+		tower  entities.TowerType
+		branch string
+		// Share of the projectile's damage the enemy behind the target takes
+		behind float64
+	}{
+		{"piercing hits the enemy behind", entities.TowerArrow, "Piercing", 1},
+		{"splash hits everything around", entities.TowerRefactor, "Extract", 0.5},
+		{"chain jumps to the nearest", entities.TowerRefactor, "Rename", 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, tower := newBranchGame(t, tt.tower, tt.branch)
+			target := enemyAt(g, entities.EnemyDaemon, 6, 5)
+			target.PathIndex = 3
+			behind := enemyAt(g, entities.EnemyDaemon, 6, 6)
+			far := enemyAt(g, entities.EnemyDaemon, 15, 15)
+
+			hitEnemy(g, target, tower.Damage)
+			g.hitSpecial(tower, target, tower.Damage)
+			if lost := behind.MaxHealth - behind.Health; lost != int(float64(tower.Damage)*tt.behind) {
+				t.Errorf("Enemy behind lost %d, want %v of %d", lost, tt.behind, tower.Damage)
+			}
+			if far.Health != far.MaxHealth {
+				t.Errorf("Far enemy lost %d health", far.MaxHealth-far.Health)
+			}
+		})
+	}
+}
+
+func TestSlowAura(t *testing.T) {
+	g, tower := newBranchGame(t, entities.TowerLSP, "Diagnostics")
+	near := enemyAt(g, entities.EnemyBug, 6, 5)
+	away := enemyAt(g, entities.EnemyBug, 19, 19)
+	g.applySlowAuras()
+	if !near.Slowed || away.Slowed {
+		t.Fatalf("Slowed near %v away %v, want only the enemy in range", near.Slowed, away.Slowed)
+	}
+
+	tower.DisabledLeft = 1
+	g.applySlowAuras()
+	if near.Slowed {
+		t.Error("A disabled tower still slows enemies")
+	}
+}
+
+func TestChallengeChargesTower(t *testing.T) {
+	g := NewGame(20, 20)
+	g.WaveFunc = func(int) Wave { return Wave{} }
+	g.Path = nil
+	g.State = StatePlaying
+	g.Gold = 1000
+	g.CursorX, g.CursorY = 5, 5
+	g.PlaceTower()
+	tower := g.Towers[0]
+	var charged []*entities.Tower
+	g.Events.Subscribe(func(e Event) {
+		if c, ok := e.(TowerCharged); ok {
+			charged = append(charged, c.Tower)
+		}
+	})
+
+	// A failed challenge charges nothing
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.Apply(Action{Kind: ActionEndChallenge})
+	if tower.AbilityLeft > 0 {
+		t.Fatal("A failed challenge charged the tower")
+	}
+
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.MoveCursor(1, 0) // Where the cursor ends up doesn't matter
+	g.Apply(Action{Kind: ActionEndChallenge, Success: true, Gold: 10, Efficiency: 1, SpeedBonus: 2})
+	if !tower.Charged(entities.AbilityOverclock) || tower.AbilityStrength != 1.0 ||
+		tower.AbilityLeft != g.Economy.AbilityDuration {
+		t.Errorf("Ability %v strength %v for %vs, want a full overclock", tower.Ability, tower.AbilityStrength, tower.AbilityLeft)
+	}
+	if len(charged) != 1 || charged[0] != tower || g.ChallengeTower != nil {
+		t.Errorf("TowerCharged events %v, challenge tower %v", charged, g.ChallengeTower)
+	}
+
+	// Off a tower a challenge only pays gold
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.Apply(Action{Kind: ActionEndChallenge, Success: true, Gold: 10})
+	if len(charged) != 1 {
+		t.Error("A challenge off any tower charged one")
+	}
+}
+
+func TestPierceAbility(t *testing.T) {
+	g := NewGame(20, 20)
+	g.Path = nil
+	g.Gold = 1000
+	g.SelectedTower = entities.TowerRefactor
+	g.CursorX, g.CursorY = 5, 5
+	g.PlaceTower()
+	tower := g.Towers[0]
+	target := enemyAt(g, entities.EnemyDaemon, 6, 5)
+	target.PathIndex = 3
+	behind := enemyAt(g, entities.EnemyDaemon, 6, 6)
+
+	g.hitSpecial(tower, target, 10)
+	if behind.Health != behind.MaxHealth {
+		t.Fatal("An uncharged tower pierced")
+	}
+	tower.Charge(0.5, 10)
+	g.hitSpecial(tower, target, 10)
+	if lost := behind.MaxHealth - behind.Health; lost != 5 {
+		t.Errorf("Enemy behind lost %d, want 5", lost)
+	}
+}
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// TowerReport is one tower's combat record.
+type TowerReport struct {
+	ID         int    `json:"id"`
+	Type       string `json:"type"`
+	X          int    `json:"x"`
+	Y          int    `json:"y"`
+	Level      int    `json:"level"`            // Upgrades applied, 0 for a new tower
+	Branch     string `json:"branch,omitempty"` // Specialisation taken
+	PlacedWave int    `json:"placed_wave"`
+	Shots      int    `json:"shots"`
+	Damage     int    `json:"damage"`
+	Kills      int    `json:"kills"`
+}
+
+// WaveReport is what one wave cost the player.
+type WaveReport struct {
+	Wave       int `json:"wave"`
+	Kills      int `json:"kills"`
+	Leaks      int `json:"leaks"`
+	HealthLost int `json:"health_lost"`
+}
+
+// ChallengeReport counts the tower defense challenges of a game.
+type ChallengeReport struct {
+	Attempted int     `json:"attempted"`
+	Succeeded int     `json:"succeeded"`
+	Accuracy  float64 `json:"accuracy"` // Succeeded / Attempted, 0 with no attempts
+}
+
+// GameStats collects combat and economy statistics from a game's events.
+type GameStats struct {
+	towers     []*TowerReport
+	towerByID  map[int]*TowerReport
+	waves      []WaveReport
+	goldEarned map[GoldSource]int
+	goldSpent  int
+	challenges ChallengeReport
+}
+
+// NewGameStats creates empty statistics.
+func NewGameStats() *GameStats {
+	return &GameStats{
+		towerByID:  make(map[int]*TowerReport),
+		goldEarned: make(map[GoldSource]int),
+	}
+}
+
+// wave returns the report for wave n, adding any missing waves before it.
+func (s *GameStats) wave(n int) *WaveReport {
+	for len(s.waves) < n {
+		s.waves = append(s.waves, WaveReport{Wave: len(s.waves) + 1})
+	}
+	return &s.waves[n-1]
+}
+
+// tower returns the report for a tower, or a throwaway one for a tower the
+// stats never saw placed.
+func (s *GameStats) tower(t *entities.Tower) *TowerReport {
+	if t == nil {
+		return &TowerReport{}
+	}
+	if r, ok := s.towerByID[t.ID]; ok {
+		return r
+	}
+	return &TowerReport{}
+}
+
+// removeTower keeps the record of a tower taken off the grid at the level it
+// reached, and forgets towers that never fired, such as undone purchases.
+func (s *GameStats) removeTower(t *entities.Tower) {
+	r, ok := s.towerByID[t.ID]
+	if !ok {
+		return
+	}
+	if r.Shots > 0 {
+		r.Level, r.Branch = t.Level, t.Branch
+		return
+	}
+	delete(s.towerByID, t.ID)
+	s.towers = slices.DeleteFunc(s.towers, func(other *TowerReport) bool { return other == r })
+}
+
+// recordStats is subscribed to the game's events by the constructors.
+func (g *Game) recordStats(e Event) {
+	s := g.Stats
+	switch e := e.(type) {
+	case TowerPlaced:
+		if _, ok := s.towerByID[e.Tower.ID]; ok {
+			break // A sale undone
+		}
+		x, y := e.Tower.Pos.IntPos()
+		r := &TowerReport{ID: e.Tower.ID, Type: e.Tower.Info().Name, X: x, Y: y, PlacedWave: g.Wave}
+		s.towers = append(s.towers, r)
+		s.towerByID[r.ID] = r
+	case TowerRemoved:
+		s.removeTower(e.Tower)
+	case TowerFired:
+		s.tower(e.Tower).Shots++
+	case EnemyDamaged:
+		s.tower(e.Tower).Damage += e.Damage
+	case EnemyKilled:
+		s.tower(e.Tower).Kills++
+		s.wave(g.Wave).Kills++
+	case EnemyLeaked:
+		w := s.wave(g.Wave)
+		w.Leaks++
+		w.HealthLost += e.Damage
+	case GoldChanged:
+		if e.Delta < 0 || e.Source == GoldSourceRefund {
+			s.goldSpent -= e.Delta
+		} else {
+			s.goldEarned[e.Source] += e.Delta
+		}
+	case ChallengeEnded:
+		s.challenges.Attempted++
+		if e.Success {
+			s.challenges.Succeeded++
+		}
+		s.challenges.Accuracy = float64(s.challenges.Succeeded) / float64(s.challenges.Attempted)
+	case WaveStarted:
+		s.wave(e.Wave)
+	}
+}
+
+// GameReport is the end-of-game summary shown on the game over and victory
+// screens and exported as JSON.
+type GameReport struct {
+	Level      string  `json:"level,omitempty"`
+	Difficulty string  `json:"difficulty,omitempty"`
+	Seed       uint64  `json:"seed"`
+	Endless    bool    `json:"endless,omitempty"`
+	Score      int     `json:"score,omitempty"`          // Endless runs only
+	Survived   int     `json:"waves_survived,omitempty"` // Endless runs only
+	Outcome    string  `json:"outcome"`
+	Wave       int     `json:"wave"`
+	TotalWaves int     `json:"total_waves"`
+	Health     int     `json:"health"`
+	MaxHealth  int     `json:"max_health"`
+	Gold       int     `json:"gold"`
+	Seconds    float64 `json:"seconds"`
+
+	Towers     []TowerReport      `json:"towers"`
+	Waves      []WaveReport       `json:"waves"`
+	GoldEarned map[GoldSource]int `json:"gold_earned"`
+	GoldSpent  int                `json:"gold_spent"`
+	Challenges ChallengeReport    `json:"challenges"`
+
+	Director []DirectorAdjustment `json:"director,omitempty"` // Changes the director made, in order
+}
+
+// Report summarizes the game so far. Towers are in the order they were built.
+func (g *Game) Report() GameReport {
+	s := g.Stats
+	r := GameReport{
+		Level:      g.LevelID,
+		Difficulty: g.Difficulty,
+		Seed:       g.Seed,
+		Outcome:    g.outcome(),
+		Wave:       min(g.Wave, g.TotalWaves),
+		TotalWaves: g.TotalWaves,
+		Health:     g.Health,
+		MaxHealth:  g.MaxHealth,
+		Gold:       g.Gold,
+		Seconds:    float64(g.Tick) * FixedTimestep,
+		Towers:     make([]TowerReport, 0, len(s.towers)),
+		Waves:      append(make([]WaveReport, 0, len(s.waves)), s.waves...),
+		GoldEarned: maps.Clone(s.goldEarned),
+		GoldSpent:  s.goldSpent,
+		Challenges: s.challenges,
+	}
+	if g.Director != nil {
+		r.Director = slices.Clone(g.Director.Log)
+	}
+	if g.Endless {
+		r.Endless = true
+		r.Wave = g.Wave
+		r.Score = g.EndlessScore()
+		r.Survived = g.WavesSurvived()
+	}
+	for _, t := range s.towers {
+		tower := *t
+		if live := g.towerByID(t.ID); live != nil {
+			tower.Level, tower.Branch = live.Level, live.Branch
+		}
+		r.Towers = append(r.Towers, tower)
+	}
+	return r
+}
+
+// TotalLeaks returns the number of enemies that reached the end of the path.
+func (r *GameReport) TotalLeaks() int {
+	total := 0
+	for _, w := range r.Waves {
+		total += w.Leaks
+	}
+	return total
+}
+
+// Save writes the report as JSON.
+func (r *GameReport) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// outcome names how the game ended, as stored in replays and reports.
+func (g *Game) outcome() string {
+	switch g.State {
+	case StateGameOver:
+		return "game_over"
+	case StateVictory:
+		return "victory"
+	case StateMenu, StateLevelSelect, StateSettings, StatePlaying, StatePaused,
+		StateChallengeActive, StateChallengeWaiting, StateWaveComplete,
+		StateChallengeMode, StateChallengeSelection, StateChallengeModePractice, StateChallengeSelectionPractice:
+		return "abandoned"
+	}
+	return "abandoned"
+}
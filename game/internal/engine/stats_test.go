@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// playLevel1 plays level 1 to the end with two arrow towers and one
+// successful and one failed challenge.
+func playLevel1(t *testing.T) *Game {
+	t.Helper()
+	level := Level1()
+	settings := DefaultGameSettings()
+	settings.Seed = 1
+	g := NewGameFromLevelAndSettings(&level, settings)
+
+	g.Apply(Action{Kind: ActionPlaceTower, X: 3, Y: 6})
+	g.Apply(Action{Kind: ActionPlaceTower, X: 6, Y: 8})
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.Apply(Action{Kind: ActionEndChallenge, Success: true, Gold: 40})
+	g.Apply(Action{Kind: ActionStartChallenge})
+	g.Apply(Action{Kind: ActionEndChallenge})
+
+	for g.Tick < maxSimulationTicks && g.State == StatePlaying {
+		g.Step()
+	}
+	if g.State != StateVictory {
+		t.Fatalf("Expected to win level 1, state %v at wave %d", g.State, g.Wave)
+	}
+	return g
+}
+
+func TestReportCountsCombat(t *testing.T) {
+	g := playLevel1(t)
+	r := g.Report()
+
+	if r.Outcome != "victory" || r.Level != g.LevelID || r.Wave != g.TotalWaves {
+		t.Errorf("Unexpected outcome %q on %q at wave %d", r.Outcome, r.Level, r.Wave)
+	}
+	if len(r.Towers) != 2 {
+		t.Fatalf("Expected 2 towers in the report, got %d", len(r.Towers))
+	}
+	towerKills := 0
+	for _, tower := range r.Towers {
+		if tower.Kills > tower.Shots {
+			t.Errorf("Tower %d has %d kills from %d shots", tower.ID, tower.Kills, tower.Shots)
+		}
+		towerKills += tower.Kills
+	}
+	if towerKills == 0 || r.Towers[0].Damage == 0 {
+		t.Errorf("Expected the first tower to do damage and kill, got %+v", r.Towers)
+	}
+
+	if len(r.Waves) != g.TotalWaves {
+		t.Errorf("Expected %d waves in the report, got %d", g.TotalWaves, len(r.Waves))
+	}
+	waveKills, healthLost := 0, 0
+	for _, w := range r.Waves {
+		waveKills += w.Kills
+		healthLost += w.HealthLost
+	}
+	if waveKills != towerKills {
+		t.Errorf("Waves count %d kills, towers %d", waveKills, towerKills)
+	}
+	if healthLost != r.MaxHealth-r.Health {
+		t.Errorf("Waves lost %d health, game lost %d", healthLost, r.MaxHealth-r.Health)
+	}
+}
+
+func TestReportCountsGoldAndChallenges(t *testing.T) {
+	g := playLevel1(t)
+	r := g.Report()
+
+	earned := 0
+	for _, gold := range r.GoldEarned {
+		earned += gold
+	}
+	if start := DefaultGameSettings().StartingGold; start+earned-r.GoldSpent != r.Gold {
+		t.Errorf("Gold doesn't add up: %d + %d earned - %d spent != %d", start, earned, r.GoldSpent, r.Gold)
+	}
+	if r.GoldEarned[GoldSourceChallenge] != 40 || r.GoldEarned[GoldSourceWaveBonus] == 0 {
+		t.Errorf("Unexpected gold by source %v", r.GoldEarned)
+	}
+	if c := r.Challenges; c.Attempted != 2 || c.Succeeded != 1 || c.Accuracy != 0.5 {
+		t.Errorf("Expected 1 of 2 challenges at 50%%, got %+v", c)
+	}
+}
+
+func TestReportSave(t *testing.T) {
+	r := playLevel1(t).Report()
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	if err := r.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded GameReport
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+	if loaded.Seed != r.Seed || len(loaded.Towers) != len(r.Towers) || loaded.Towers[0].Damage != r.Towers[0].Damage {
+		t.Errorf("Saved report doesn't match: %+v", loaded)
+	}
+}
@@ -156,6 +156,32 @@ func (t *Tutorial) HandleEvent(event string) bool {
 	return false
 }
 
+// HandleGameEvent advances the tutorial when the game does what the current
+// step waits for. Unlike key presses, game events never complete "any_key" steps.
+func (t *Tutorial) HandleGameEvent(e Event) bool {
+	var name string
+	switch e.(type) {
+	case TowerPlaced:
+		name = "place_tower"
+	case EnemyKilled:
+		name = "kill_enemy"
+	default:
+		return false
+	}
+	if step := t.CurrentStepData(); step == nil || step.WaitFor != name {
+		return false
+	}
+	return t.HandleEvent(name)
+}
+
+// Attach subscribes the tutorial to a game's events and returns the
+// function that detaches it.
+func (t *Tutorial) Attach(g *Game) func() {
+	return g.Events.Subscribe(func(e Event) {
+		t.HandleGameEvent(e)
+	})
+}
+
 // IsActive returns whether the tutorial is currently active.
 func (t *Tutorial) IsActive() bool {
 	return t.Active
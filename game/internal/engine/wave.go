@@ -4,8 +4,10 @@ import "github.com/keyforge/keyforge/internal/entities"
 
 // Spawn defines a single enemy spawn in a wave.
 type Spawn struct {
-	Type  entities.EnemyType
-	Delay float64 // seconds after previous spawn
+	Type       entities.EnemyType
+	Delay      float64 // seconds after previous spawn
+	HealthMult float64 // scales the enemy's health; 0 means unscaled
+	Path       string  // named path to follow; "" is the level's main path
 }
 
 // Wave defines a wave of enemies.
@@ -343,7 +345,7 @@ func level7Wave(waveNum int) Wave {
 	return Wave{Number: waveNum, Spawns: spawns, BonusGold: 55 + waveNum*7}
 }
 
-// level8Wave generates waves for Level 8 (Crawler, Specter, Daemon).
+// level8Wave generates waves for Level 8 (Crawler, Specter, Daemon, Moth from wave 6).
 func level8Wave(waveNum int) Wave {
 	// 5-7 enemies per wave, late game mix
 	count := 5 + waveNum/3
@@ -362,12 +364,17 @@ func level8Wave(waveNum int) Wave {
 		crawlerCount = 1
 	}
 
-	for range specterCount {
+	for i := range specterCount {
 		delay := 0.4
 		if len(spawns) == 0 {
 			delay = 0
 		}
-		spawns = append(spawns, Spawn{Type: entities.EnemySpecter, Delay: delay})
+		// Moths lead the fast group from wave 6
+		t := entities.EnemySpecter
+		if waveNum >= 6 && i == 0 {
+			t = entities.EnemyMoth
+		}
+		spawns = append(spawns, Spawn{Type: t, Delay: delay})
 	}
 	for range crawlerCount {
 		spawns = append(spawns, Spawn{Type: entities.EnemyCrawler, Delay: 1.2})
@@ -379,7 +386,8 @@ func level8Wave(waveNum int) Wave {
 	return Wave{Number: waveNum, Spawns: spawns, BonusGold: 65 + waveNum*8}
 }
 
-// level9Wave generates waves for Level 9 (Specter, Daemon).
+// level9Wave generates waves for Level 9 (Specter, Daemon, Moth from wave 5,
+// Dragon from wave 8).
 func level9Wave(waveNum int) Wave {
 	// 5-7 enemies per wave, pre-boss difficulty
 	count := 5 + waveNum/3
@@ -394,15 +402,24 @@ func level9Wave(waveNum int) Wave {
 	}
 	specterCount := count - daemonCount
 
-	for range specterCount {
+	for i := range specterCount {
 		delay := 0.4
 		if len(spawns) == 0 {
 			delay = 0
 		}
-		spawns = append(spawns, Spawn{Type: entities.EnemySpecter, Delay: delay})
+		t := entities.EnemySpecter
+		if waveNum >= 5 && i%2 == 1 {
+			t = entities.EnemyMoth
+		}
+		spawns = append(spawns, Spawn{Type: t, Delay: delay})
 	}
-	for range daemonCount {
-		spawns = append(spawns, Spawn{Type: entities.EnemyDaemon, Delay: 1.3})
+	for i := range daemonCount {
+		// The last heavy of a late wave flies straight across the winding path
+		t := entities.EnemyDaemon
+		if waveNum >= 8 && i == daemonCount-1 {
+			t = entities.EnemyDragon
+		}
+		spawns = append(spawns, Spawn{Type: t, Delay: 1.3})
 	}
 
 	return Wave{Number: waveNum, Spawns: spawns, BonusGold: 75 + waveNum*10}
@@ -429,9 +446,9 @@ func level10Wave(waveNum int) Wave {
 	case waveNum <= 3:
 		pool = []entities.EnemyType{entities.EnemyBug, entities.EnemyGremlin, entities.EnemyCrawler}
 	case waveNum <= 6:
-		pool = []entities.EnemyType{entities.EnemyGremlin, entities.EnemyCrawler, entities.EnemySpecter}
+		pool = []entities.EnemyType{entities.EnemyGremlin, entities.EnemyCrawler, entities.EnemySpecter, entities.EnemyMoth}
 	default:
-		pool = []entities.EnemyType{entities.EnemySpecter, entities.EnemyDaemon}
+		pool = []entities.EnemyType{entities.EnemySpecter, entities.EnemyDaemon, entities.EnemyMoth, entities.EnemyDragon}
 	}
 
 	for i := range count {
@@ -456,8 +473,53 @@ func level10BossWave() Wave {
 		{Type: entities.EnemyDaemon, Delay: 1.0},
 		{Type: entities.EnemyBoss, Delay: 2.0},
 		{Type: entities.EnemySpecter, Delay: 0.5},
-		{Type: entities.EnemyDaemon, Delay: 1.0},
+		{Type: entities.EnemyDragon, Delay: 1.0},
 	}
 
 	return Wave{Number: 10, Spawns: spawns, BonusGold: 250}
 }
+
+// level11Wave generates waves for Level 11, sending enemies down every route
+// of the crossroads in turn.
+func level11Wave(waveNum int) Wave {
+	routes := []string{"", "south", "north-low", "south-high"}
+	count := min(5+waveNum/3, 7)
+	spawns := make([]Spawn, 0, count)
+	for i := range count {
+		t := entities.EnemyGremlin
+		switch {
+		case waveNum >= 6 && i%3 == 2:
+			t = entities.EnemyCrawler
+		case waveNum >= 3 && i%2 == 1:
+			t = entities.EnemySpecter
+		}
+		delay := 0.7
+		if i == 0 {
+			delay = 0
+		}
+		spawns = append(spawns, Spawn{Type: t, Delay: delay, Path: routes[i%len(routes)]})
+	}
+	return Wave{Number: waveNum, Spawns: spawns, BonusGold: 60 + waveNum*10}
+}
+
+// level12Wave generates waves for Level 12. The open field gives towers a
+// long maze to shoot along, so waves are dense.
+func level12Wave(waveNum int) Wave {
+	count := min(4+waveNum/2, 7)
+	spawns := make([]Spawn, 0, count)
+	for i := range count {
+		t := entities.EnemyBug
+		switch {
+		case waveNum >= 5 && i%3 == 0:
+			t = entities.EnemyCrawler
+		case waveNum >= 2 && i%2 == 1:
+			t = entities.EnemyGremlin
+		}
+		delay := 0.6
+		if i == 0 {
+			delay = 0
+		}
+		spawns = append(spawns, Spawn{Type: t, Delay: delay})
+	}
+	return Wave{Number: waveNum, Spawns: spawns, BonusGold: 50 + waveNum*10}
+}
@@ -277,11 +277,12 @@ func TestLevel8Wave(t *testing.T) {
 			}
 		})
 
-		t.Run("only crawlers, specters, daemons", func(t *testing.T) {
+		t.Run("only crawlers, specters, daemons, moths", func(t *testing.T) {
 			for _, spawn := range wave.Spawns {
 				if spawn.Type != entities.EnemyCrawler &&
 					spawn.Type != entities.EnemySpecter &&
-					spawn.Type != entities.EnemyDaemon {
+					spawn.Type != entities.EnemyDaemon &&
+					(spawn.Type != entities.EnemyMoth || waveNum < 6) {
 					t.Errorf("Wave %d spawns invalid enemy: %v", waveNum, spawn.Type)
 				}
 			}
@@ -299,9 +300,11 @@ func TestLevel9Wave(t *testing.T) {
 			}
 		})
 
-		t.Run("only specters and daemons", func(t *testing.T) {
+		t.Run("only specters, daemons and late flyers", func(t *testing.T) {
 			for _, spawn := range wave.Spawns {
-				if spawn.Type != entities.EnemySpecter && spawn.Type != entities.EnemyDaemon {
+				if spawn.Type != entities.EnemySpecter && spawn.Type != entities.EnemyDaemon &&
+					(spawn.Type != entities.EnemyMoth || waveNum < 5) &&
+					(spawn.Type != entities.EnemyDragon || waveNum < 8) {
 					t.Errorf("Wave %d spawns invalid enemy: %v", waveNum, spawn.Type)
 				}
 			}
@@ -394,3 +397,23 @@ func TestWaveSpawnCount(t *testing.T) {
 		})
 	}
 }
+
+func TestFlyersJoinLateWaves(t *testing.T) {
+	for _, level := range []Level{Level8(), Level9(), Level10()} {
+		flyersIn := func(waveNum int) int {
+			n := 0
+			for _, spawn := range level.WaveFunc(waveNum).Spawns {
+				if entities.EnemyTypes[spawn.Type].Movement == entities.MovementFlying {
+					n++
+				}
+			}
+			return n
+		}
+		if flyersIn(1) != 0 {
+			t.Errorf("%s: wave 1 shouldn't have flyers", level.ID)
+		}
+		if flyersIn(level.TotalWaves) == 0 {
+			t.Errorf("%s: final wave should have flyers", level.ID)
+		}
+	}
+}
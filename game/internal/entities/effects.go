@@ -64,14 +64,11 @@ var EffectTypes = map[EffectType]EffectInfo{
 	},
 }
 
-var effectIDCounter = 0
-
 // NewEffect creates a new visual effect.
-func NewEffect(effectType EffectType, pos Position) *Effect {
-	effectIDCounter++
+func NewEffect(id int, effectType EffectType, pos Position) *Effect {
 	info := EffectTypes[effectType]
 	return &Effect{
-		ID:       effectIDCounter,
+		ID:       id,
 		Type:     effectType,
 		Pos:      pos,
 		Duration: info.Duration,
@@ -127,6 +124,7 @@ func (e *Effect) CurrentFrame() string {
 // EffectManager manages all active effects.
 type EffectManager struct {
 	Effects []*Effect
+	ids     IDAllocator
 }
 
 // NewEffectManager creates a new effect manager.
@@ -138,7 +136,7 @@ func NewEffectManager() *EffectManager {
 
 // Add creates and adds a new effect.
 func (em *EffectManager) Add(effectType EffectType, pos Position) *Effect {
-	effect := NewEffect(effectType, pos)
+	effect := NewEffect(em.ids.Next(), effectType, pos)
 	em.Effects = append(em.Effects, effect)
 	return effect
 }
@@ -1,5 +1,7 @@
 package entities
 
+import "math"
+
 // Enemy represents an enemy moving along the path.
 type Enemy struct {
 	ID        int
@@ -8,11 +10,16 @@ type Enemy struct {
 	Health    int
 	MaxHealth int
 	Speed     float64
+	Path      string  // named path it follows; "" is the level's main path
 	PathIndex int     // current waypoint index
 	PathProg  float64 // progress to next waypoint (0-1)
+	Slowed    bool    // in a slowing aura this step
 	Dead      bool
 }
 
+// SlowFactor is the share of its speed a slowed enemy keeps.
+const SlowFactor = 0.6
+
 // NewEnemy creates a new enemy at the start of the path.
 func NewEnemy(id int, enemyType EnemyType, startPos Position) *Enemy {
 	info := EnemyTypes[enemyType]
@@ -50,9 +57,26 @@ func (e *Enemy) HealthPercent() float64 {
 	return float64(e.Health) / float64(e.MaxHealth)
 }
 
-// Update moves the enemy along the path
+// Flying reports whether the enemy flies over the path.
+func (e *Enemy) Flying() bool {
+	return e.Info().Movement == MovementFlying
+}
+
+// speed returns the enemy's current speed in cells per second.
+func (e *Enemy) speed() float64 {
+	if e.Slowed {
+		return e.Speed * SlowFactor
+	}
+	return e.Speed
+}
+
+// Update moves the enemy along the path, or straight from its start to its
+// end for flyers.
 // Returns true if the enemy has reached the end.
 func (e *Enemy) Update(dt float64, path []Position) bool {
+	if e.Flying() && len(path) > 0 {
+		return e.fly(dt, path[0], path[len(path)-1])
+	}
 	if e.Dead || e.PathIndex >= len(path)-1 {
 		return e.PathIndex >= len(path)-1
 	}
@@ -72,7 +96,7 @@ func (e *Enemy) Update(dt float64, path []Position) bool {
 	}
 
 	// Progress along path segment
-	e.PathProg += (e.Speed * dt) / dist
+	e.PathProg += (e.speed() * dt) / dist
 
 	// Move to next waypoint if we've reached current target
 	for e.PathProg >= 1.0 && e.PathIndex < len(path)-1 {
@@ -94,3 +118,23 @@ func (e *Enemy) Update(dt float64, path []Position) bool {
 
 	return false
 }
+
+// fly moves a flyer along the straight line from start to end. PathIndex and
+// PathProg count cells flown, so towers compare its progress with walkers'.
+func (e *Enemy) fly(dt float64, start, end Position) bool {
+	total := math.Hypot(end.X-start.X, end.Y-start.Y)
+	flown := float64(e.PathIndex) + e.PathProg
+	if e.Dead {
+		return flown >= total
+	}
+	flown += e.speed() * dt
+	if flown >= total {
+		e.Pos = end
+		return true
+	}
+	e.PathIndex = int(flown)
+	e.PathProg = flown - float64(e.PathIndex)
+	e.Pos.X = start.X + (end.X-start.X)*flown/total
+	e.Pos.Y = start.Y + (end.Y-start.Y)*flown/total
+	return false
+}
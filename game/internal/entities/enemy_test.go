@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"testing"
 )
 
@@ -106,6 +107,32 @@ func TestEnemyReachesEnd(t *testing.T) {
 	}
 }
 
+func TestFlyingEnemyIgnoresPath(t *testing.T) {
+	// An L-shaped path: walkers go around the corner, flyers cut across
+	path := []Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}}
+	moth := NewEnemy(1, EnemyMoth, path[0])
+	if !moth.Flying() || NewEnemy(2, EnemyBug, path[0]).Flying() {
+		t.Fatal("Only moths should fly")
+	}
+
+	moth.Update(1.0, path)
+	// 1.6 cells along the diagonal from (0,0) to (2,2)
+	want := 1.6 / math.Sqrt2
+	if math.Abs(moth.Pos.X-want) > 1e-9 || math.Abs(moth.Pos.Y-want) > 1e-9 {
+		t.Errorf("Moth at %v, want (%.3f, %.3f) on the straight line", moth.Pos, want, want)
+	}
+	if progress := float64(moth.PathIndex) + moth.PathProg; math.Abs(progress-1.6) > 1e-9 {
+		t.Errorf("Progress = %v, want 1.6 cells flown", progress)
+	}
+
+	if !moth.Update(1.0, path) {
+		t.Error("Moth should have reached the exit")
+	}
+	if moth.Pos != path[len(path)-1] {
+		t.Errorf("Moth ended at %v, want the exit", moth.Pos)
+	}
+}
+
 func TestEnemyInfo(t *testing.T) {
 	enemy := NewEnemy(1, EnemyGremlin, Position{})
 
@@ -116,8 +143,11 @@ func TestEnemyInfo(t *testing.T) {
 }
 
 func TestAllEnemyTypes(t *testing.T) {
-	// All 7 enemy types
-	types := []EnemyType{EnemyMite, EnemyBug, EnemyGremlin, EnemyCrawler, EnemySpecter, EnemyDaemon, EnemyBoss}
+	// All 9 enemy types
+	types := []EnemyType{
+		EnemyMite, EnemyBug, EnemyGremlin, EnemyCrawler, EnemySpecter, EnemyDaemon, EnemyBoss,
+		EnemyMoth, EnemyDragon,
+	}
 
 	for _, etype := range types {
 		info, ok := EnemyTypes[etype]
@@ -141,8 +171,8 @@ func TestAllEnemyTypes(t *testing.T) {
 }
 
 func TestEnemyTypeCount(t *testing.T) {
-	// Verify exactly 7 enemy types exist
-	expectedCount := 7
+	// Verify exactly 9 enemy types exist
+	expectedCount := 9
 	actualCount := len(EnemyTypes)
 	if actualCount != expectedCount {
 		t.Errorf("Expected %d enemy types, got %d", expectedCount, actualCount)
@@ -165,6 +195,8 @@ func TestEnemyTypeStats(t *testing.T) {
 		{EnemySpecter, "Specter", 15, 3.5, 8},
 		{EnemyDaemon, "Daemon", 100, 0.8, 25},
 		{EnemyBoss, "Boss", 500, 0.5, 100},
+		{EnemyMoth, "Moth", 18, 1.6, 9},
+		{EnemyDragon, "Dragon", 90, 0.9, 28},
 	}
 
 	for _, tc := range tests {
@@ -188,8 +220,8 @@ func TestEnemyGoldScalesWithHealth(t *testing.T) {
 	// Verify higher health enemies give more gold (generally)
 	// Gold should scale: low health (2-5), medium (8-15), high (25-100)
 	lowHealthEnemies := []EnemyType{EnemyMite, EnemyBug}
-	medHealthEnemies := []EnemyType{EnemyGremlin, EnemyCrawler, EnemySpecter}
-	highHealthEnemies := []EnemyType{EnemyDaemon, EnemyBoss}
+	medHealthEnemies := []EnemyType{EnemyGremlin, EnemyCrawler, EnemySpecter, EnemyMoth}
+	highHealthEnemies := []EnemyType{EnemyDaemon, EnemyBoss, EnemyDragon}
 
 	var lowGoldMax, medGoldMin, medGoldMax, highGoldMin int
 
@@ -8,11 +8,20 @@ type Tower struct {
 	Type         TowerType
 	Pos          Position
 	Level        int
+	Branch       string // Name of the chosen specialisation, "" before choosing
 	Damage       int
 	Range        float64
 	Cooldown     float64
 	CooldownLeft float64
 	Target       *Enemy
+	Kills        int     // Enemies finished off by this tower's projectiles
+	DisabledLeft float64 // Seconds until a boss's disable wears off
+	Paid         int     // Gold paid for the tower and its upgrades, at the prices charged
+
+	// Ability charged by a successful challenge, while AbilityLeft lasts
+	Ability         Ability
+	AbilityStrength float64
+	AbilityLeft     float64
 }
 
 // NewTower creates a new tower at the specified position.
@@ -36,33 +45,112 @@ func (t *Tower) Info() TowerInfo {
 	return TowerTypes[t.Type]
 }
 
-// CanUpgrade returns true if the tower can be upgraded.
+// CanUpgrade returns true if the tower can be upgraded, by the next tier
+// or by choosing a branch.
 func (t *Tower) CanUpgrade() bool {
 	info := t.Info()
-	return t.Level < len(info.Upgrades)
+	return t.Level < len(info.Upgrades) || t.NeedsBranch()
+}
+
+// NeedsBranch reports whether the next upgrade is the choice of a branch.
+func (t *Tower) NeedsBranch() bool {
+	info := t.Info()
+	return t.Level == len(info.Upgrades) && t.Branch == "" && len(info.Branches) > 0
 }
 
-// UpgradeCost returns the cost of the next upgrade, or 0 if maxed.
+// UpgradeCost returns the cost of the next upgrade, the cheapest branch when
+// a branch is next, or 0 if maxed.
 func (t *Tower) UpgradeCost() int {
 	info := t.Info()
-	if t.Level >= len(info.Upgrades) {
+	if t.Level < len(info.Upgrades) {
+		return info.Upgrades[t.Level].Cost
+	}
+	if !t.NeedsBranch() {
 		return 0
 	}
-	return info.Upgrades[t.Level].Cost
+	cost := info.Branches[0].Upgrade.Cost
+	for _, b := range info.Branches[1:] {
+		cost = min(cost, b.Upgrade.Cost)
+	}
+	return cost
 }
 
-// Upgrade applies the next upgrade level.
+// Upgrade applies the next upgrade tier. It fails when a branch has to be
+// chosen with Specialize instead.
 func (t *Tower) Upgrade() bool {
 	info := t.Info()
 	if t.Level >= len(info.Upgrades) {
 		return false
 	}
-	upgrade := info.Upgrades[t.Level]
+	t.apply(info.Upgrades[t.Level])
+	return true
+}
+
+// Specialize takes the named branch.
+func (t *Tower) Specialize(name string) bool {
+	branch, ok := t.Info().Branch(name)
+	if !ok || !t.NeedsBranch() {
+		return false
+	}
+	t.apply(branch.Upgrade)
+	t.Branch = branch.Name
+	return true
+}
+
+func (t *Tower) apply(upgrade TowerUpgrade) {
 	t.Damage += upgrade.DamageBonus
 	t.Range += upgrade.RangeBonus
 	t.Cooldown *= upgrade.CooldownMult
 	t.Level++
-	return true
+}
+
+// ChosenBranch returns the branch the tower took, if any.
+func (t *Tower) ChosenBranch() (TowerBranch, bool) {
+	if t.Branch == "" {
+		return TowerBranch{}, false
+	}
+	return t.Info().Branch(t.Branch)
+}
+
+// Special returns what the tower's branch does beyond its stats.
+func (t *Tower) Special() Special {
+	branch, _ := t.ChosenBranch()
+	return branch.Special
+}
+
+// Disabled reports whether a boss has switched the tower off.
+func (t *Tower) Disabled() bool {
+	return t.DisabledLeft > 0
+}
+
+// Charge gives the tower its type's ability at strength for duration
+// seconds, replacing any ability it still has.
+func (t *Tower) Charge(strength, duration float64) {
+	t.Ability = t.Info().Ability
+	t.AbilityStrength = strength
+	t.AbilityLeft = duration
+}
+
+// Charged reports whether the tower has the given ability right now.
+func (t *Tower) Charged(a Ability) bool {
+	return t.AbilityLeft > 0 && t.Ability == a
+}
+
+// EffectiveRange returns the tower's range, boosted by its ability.
+func (t *Tower) EffectiveRange() float64 {
+	if t.Charged(AbilityRange) {
+		return t.Range * (1 + t.AbilityStrength)
+	}
+	return t.Range
+}
+
+// EffectiveCooldown returns the seconds between shots, shortened by an
+// overclock.
+func (t *Tower) EffectiveCooldown() float64 {
+	if t.Charged(AbilityOverclock) {
+		return t.Cooldown / (1 + t.AbilityStrength)
+	}
+	return t.Cooldown
 }
 
 // InRange checks if a position is within the tower's range.
@@ -70,23 +158,34 @@ func (t *Tower) InRange(pos Position) bool {
 	dx := t.Pos.X - pos.X
 	dy := t.Pos.Y - pos.Y
 	distSq := dx*dx + dy*dy
-	return distSq <= t.Range*t.Range
+	r := t.EffectiveRange()
+	return distSq <= r*r
 }
 
 // FindTarget finds the best enemy target within range
-// Uses "first" strategy (furthest along path).
+// Uses "first" strategy (furthest along path), or the enemy with the most
+// health for the Strongest special. Towers without anti-air ignore flyers.
 func (t *Tower) FindTarget(enemies []*Enemy) *Enemy {
+	return t.FindTargetExcept(enemies, nil)
+}
+
+// FindTargetExcept finds the best target other than skip, for a second shot.
+func (t *Tower) FindTargetExcept(enemies []*Enemy, skip *Enemy) *Enemy {
 	var bestTarget *Enemy
-	bestProgress := -1.0
+	bestScore := -1.0
+	strongest := t.Special() == SpecialStrongest
 
 	for _, enemy := range enemies {
-		if enemy.Dead || !t.InRange(enemy.Pos) {
+		if enemy == skip || enemy.Dead || !t.InRange(enemy.Pos) || (enemy.Flying() && !t.Info().AntiAir) {
 			continue
 		}
 		// Calculate total progress (waypoint index + fractional progress)
-		progress := float64(enemy.PathIndex) + enemy.PathProg
-		if progress > bestProgress {
-			bestProgress = progress
+		score := float64(enemy.PathIndex) + enemy.PathProg
+		if strongest {
+			score = float64(enemy.Health)
+		}
+		if score > bestScore {
+			bestScore = score
 			bestTarget = enemy
 		}
 	}
@@ -95,12 +194,22 @@ func (t *Tower) FindTarget(enemies []*Enemy) *Enemy {
 }
 
 // Update handles tower cooldown and targeting
-// Returns a projectile if the tower fires, nil otherwise.
-func (t *Tower) Update(dt float64, enemies []*Enemy) *Projectile {
+// Returns a projectile with an ID from ids if the tower fires, nil otherwise.
+func (t *Tower) Update(dt float64, enemies []*Enemy, ids *IDAllocator) *Projectile {
 	// Update cooldown
 	if t.CooldownLeft > 0 {
 		t.CooldownLeft -= dt
 	}
+	if t.AbilityLeft > 0 {
+		t.AbilityLeft = max(0, t.AbilityLeft-dt)
+	}
+
+	// Disabled towers neither aim nor fire
+	if t.DisabledLeft > 0 {
+		t.DisabledLeft = max(0, t.DisabledLeft-dt)
+		t.Target = nil
+		return nil
+	}
 
 	// Find target
 	t.Target = t.FindTarget(enemies)
@@ -110,8 +219,8 @@ func (t *Tower) Update(dt float64, enemies []*Enemy) *Projectile {
 
 	// Fire if ready
 	if t.CooldownLeft <= 0 {
-		t.CooldownLeft = t.Cooldown
-		return NewProjectile(t, t.Target)
+		t.CooldownLeft = t.EffectiveCooldown()
+		return NewProjectile(ids.Next(), t, t.Target)
 	}
 
 	return nil
@@ -125,21 +234,20 @@ type Projectile struct {
 	Damage   int
 	Speed    float64
 	TargetID int
+	TowerID  int // Tower that fired it, credited with the kill
 	Done     bool
 }
 
-var projectileIDCounter = 0
-
 // NewProjectile creates a new projectile aimed at an enemy.
-func NewProjectile(tower *Tower, target *Enemy) *Projectile {
-	projectileIDCounter++
+func NewProjectile(id int, tower *Tower, target *Enemy) *Projectile {
 	return &Projectile{
-		ID:       projectileIDCounter,
+		ID:       id,
 		Pos:      tower.Pos,
 		Target:   target.Pos,
 		Damage:   tower.Damage,
 		Speed:    10.0, // cells per second
 		TargetID: target.ID,
+		TowerID:  tower.ID,
 		Done:     false,
 	}
 }
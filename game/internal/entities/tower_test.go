@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"testing"
 )
 
@@ -96,6 +97,23 @@ func TestTowerFindTargetIgnoresDead(t *testing.T) {
 	}
 }
 
+func TestTowerFindTargetAntiAir(t *testing.T) {
+	moth := NewEnemy(1, EnemyMoth, Position{X: 6, Y: 5})
+	moth.PathIndex = 3 // Further along than the bug
+	bug := NewEnemy(2, EnemyBug, Position{X: 5, Y: 6})
+	enemies := []*Enemy{moth, bug}
+
+	if target := NewTower(1, TowerArrow, Position{X: 5, Y: 5}).FindTarget(enemies); target != moth {
+		t.Error("Anti-air tower should target the flyer ahead")
+	}
+	if target := NewTower(2, TowerRefactor, Position{X: 5, Y: 5}).FindTarget(enemies); target != bug {
+		t.Error("Ground-only tower should skip the flyer")
+	}
+	if target := NewTower(3, TowerRefactor, Position{X: 5, Y: 5}).FindTarget(enemies[:1]); target != nil {
+		t.Error("Ground-only tower shouldn't target a lone flyer")
+	}
+}
+
 func TestTowerCanUpgrade(t *testing.T) {
 	tower := NewTower(1, TowerArrow, Position{X: 5, Y: 5})
 
@@ -103,11 +121,20 @@ func TestTowerCanUpgrade(t *testing.T) {
 		t.Error("New tower should be upgradeable")
 	}
 
-	// Upgrade to max
+	// Upgrade to max: the tiers, then a branch
 	info := TowerTypes[TowerArrow]
 	for range len(info.Upgrades) {
 		tower.Upgrade()
 	}
+	if !tower.CanUpgrade() || !tower.NeedsBranch() {
+		t.Fatal("Tower should offer its branches after the last tier")
+	}
+	if tower.Upgrade() {
+		t.Error("Upgrade should not pick a branch")
+	}
+	if !tower.Specialize(info.Branches[0].Name) {
+		t.Fatal("Specialize failed")
+	}
 
 	if tower.CanUpgrade() {
 		t.Error("Maxed tower should not be upgradeable")
@@ -145,20 +172,21 @@ func TestTowerUpgradeCost(t *testing.T) {
 
 	tower.Upgrade()
 	cost = tower.UpgradeCost()
-	if cost != info.Upgrades[1].Cost {
-		t.Errorf("Expected cost %d, got %d", info.Upgrades[1].Cost, cost)
+	if cost != info.Branches[0].Upgrade.Cost {
+		t.Errorf("Expected branch cost %d, got %d", info.Branches[0].Upgrade.Cost, cost)
 	}
 }
 
 func TestTowerUpdate(t *testing.T) {
 	tower := NewTower(1, TowerArrow, Position{X: 5, Y: 5})
+	var ids IDAllocator
 
 	enemies := []*Enemy{
 		NewEnemy(1, EnemyBug, Position{X: 6, Y: 5}),
 	}
 
 	// First update should fire (cooldown starts at 0)
-	proj := tower.Update(0.1, enemies)
+	proj := tower.Update(0.1, enemies, &ids)
 	if proj == nil {
 		t.Fatal("Tower should fire on first update")
 	}
@@ -167,7 +195,7 @@ func TestTowerUpdate(t *testing.T) {
 	}
 
 	// Immediate update should not fire (on cooldown)
-	proj = tower.Update(0.1, enemies)
+	proj = tower.Update(0.1, enemies, &ids)
 	if proj != nil {
 		t.Error("Tower should be on cooldown")
 	}
@@ -201,7 +229,7 @@ func TestProjectileUpdate(t *testing.T) {
 	tower := NewTower(1, TowerArrow, Position{X: 0, Y: 0})
 	enemy := NewEnemy(1, EnemyBug, Position{X: 5, Y: 0})
 
-	proj := NewProjectile(tower, enemy)
+	proj := NewProjectile(1, tower, enemy)
 
 	// Update projectile
 	reached := proj.Update(0.3)
@@ -217,7 +245,7 @@ func TestProjectileReachesTarget(t *testing.T) {
 	tower := NewTower(1, TowerArrow, Position{X: 0, Y: 0})
 	enemy := NewEnemy(1, EnemyBug, Position{X: 1, Y: 0})
 
-	proj := NewProjectile(tower, enemy)
+	proj := NewProjectile(1, tower, enemy)
 
 	// Update until reached
 	for i := 0; i < 100 && !proj.Done; i++ {
@@ -330,3 +358,104 @@ func TestTowerRoleDistinction(t *testing.T) {
 		t.Error("Refactor tower should be the most expensive")
 	}
 }
+
+func TestTowerTypeByName(t *testing.T) {
+	if got, ok := TowerTypeByName("lsp"); !ok || got != TowerLSP {
+		t.Errorf("TowerTypeByName(lsp) = %v, %v; want TowerLSP", got, ok)
+	}
+	if _, ok := TowerTypeByName("ballista"); ok {
+		t.Error("TowerTypeByName(ballista) found a tower")
+	}
+}
+
+func TestTowerBranches(t *testing.T) {
+	for ttype, info := range TowerTypes {
+		if len(info.Branches) != 2 {
+			t.Errorf("%s: expected 2 branches, got %d", info.Name, len(info.Branches))
+			continue
+		}
+		for _, branch := range info.Branches {
+			tower := NewTower(1, ttype, Position{X: 5, Y: 5})
+			if tower.Specialize(branch.Name) {
+				t.Errorf("%s: took %s before the shared tier", info.Name, branch.Name)
+			}
+			tower.Upgrade()
+			damage := tower.Damage
+			if !tower.Specialize(branch.Name) {
+				t.Fatalf("%s: could not take %s", info.Name, branch.Name)
+			}
+			if tower.Branch != branch.Name || tower.Special() != branch.Special || tower.Special() == SpecialNone {
+				t.Errorf("%s: branch %q special %v, want %s", info.Name, tower.Branch, tower.Special(), branch.Name)
+			}
+			if tower.Damage != damage+branch.Upgrade.DamageBonus {
+				t.Errorf("%s %s: damage %d, want %d", info.Name, branch.Name, tower.Damage, damage+branch.Upgrade.DamageBonus)
+			}
+			other := info.Branches[0].Name
+			if other == branch.Name {
+				other = info.Branches[1].Name
+			}
+			if tower.Specialize(other) {
+				t.Errorf("%s: took a second branch", info.Name)
+			}
+		}
+	}
+}
+
+func TestTowerFindTargetStrongest(t *testing.T) {
+	tower := NewTower(1, TowerLSP, Position{X: 5, Y: 5})
+	tower.Upgrade()
+	tower.Specialize("Sniper")
+
+	ahead := NewEnemy(1, EnemyBug, Position{X: 6, Y: 5})
+	ahead.PathIndex = 5
+	tough := NewEnemy(2, EnemyDaemon, Position{X: 4, Y: 5})
+	if target := tower.FindTarget([]*Enemy{ahead, tough}); target != tough {
+		t.Error("Sniper should target the enemy with the most health")
+	}
+	if target := tower.FindTargetExcept([]*Enemy{ahead, tough}, tough); target != ahead {
+		t.Error("FindTargetExcept should skip the given enemy")
+	}
+}
+
+func TestSlowedEnemyMovesSlower(t *testing.T) {
+	var path []Position
+	for x := range 10 {
+		path = append(path, Position{X: float64(x)})
+	}
+	normal := NewEnemy(1, EnemyBug, path[0])
+	slowed := NewEnemy(2, EnemyBug, path[0])
+	slowed.Slowed = true
+	normal.Update(1, path)
+	slowed.Update(1, path)
+	if want := normal.Pos.X * SlowFactor; math.Abs(slowed.Pos.X-want) > 1e-9 {
+		t.Errorf("Slowed enemy at %v, want %v", slowed.Pos.X, want)
+	}
+}
+
+func TestTowerAbilities(t *testing.T) {
+	arrow := NewTower(1, TowerArrow, Position{X: 5, Y: 5})
+	arrow.Charge(0.5, 2)
+	if !arrow.Charged(AbilityOverclock) || math.Abs(arrow.EffectiveCooldown()-arrow.Cooldown/1.5) > 1e-9 {
+		t.Errorf("Overclocked cooldown = %v, want %v", arrow.EffectiveCooldown(), arrow.Cooldown/1.5)
+	}
+	if arrow.EffectiveRange() != arrow.Range {
+		t.Error("Overclock changed the range")
+	}
+
+	lsp := NewTower(2, TowerLSP, Position{X: 0, Y: 0})
+	far := Position{X: lsp.Range + 1}
+	if lsp.InRange(far) {
+		t.Fatal("Enemy should start out of range")
+	}
+	lsp.Charge(0.5, 2)
+	if !lsp.InRange(far) {
+		t.Error("Range boost should reach the enemy")
+	}
+
+	// Abilities wear off, even on a disabled tower
+	lsp.DisabledLeft = 5
+	lsp.Update(2, nil, &IDAllocator{})
+	if lsp.Charged(AbilityRange) || lsp.InRange(far) {
+		t.Error("Ability should have worn off")
+	}
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "strings"
+
 // Position represents a 2D coordinate on the game grid.
 type Position struct {
 	X, Y float64
@@ -17,6 +19,19 @@ func (p Position) Distance(other Position) float64 {
 	return dx*dx + dy*dy // squared distance for efficiency
 }
 
+// IDAllocator hands out increasing entity IDs starting at 1. The zero value is
+// ready to use. Each game owns its allocators, so games running side by side
+// in one process never share or race on IDs.
+type IDAllocator struct {
+	last int
+}
+
+// Next returns the next unused ID.
+func (a *IDAllocator) Next() int {
+	a.last++
+	return a.last
+}
+
 // EntityType identifies the kind of entity.
 type EntityType int
 
@@ -49,7 +64,10 @@ type TowerInfo struct {
 	Categories []string // all challenge categories this tower can trigger
 	Symbol     string   // display character
 	Color      string   // hex color
+	AntiAir    bool     // can target flying enemies
 	Upgrades   []TowerUpgrade
+	Branches   []TowerBranch // specialisations to choose from after the last upgrade tier
+	Ability    Ability       // what a successful challenge started on the tower charges it with
 }
 
 // TowerUpgrade defines an upgrade tier.
@@ -60,6 +78,76 @@ type TowerUpgrade struct {
 	CooldownMult float64 // multiplier (0.8 = 20% faster)
 }
 
+// TowerBranch is a specialisation a tower can take once its shared upgrade
+// tiers are done. A tower takes one branch for good.
+type TowerBranch struct {
+	Name        string
+	Symbol      string // replaces the tower's symbol on the grid
+	Description string
+	Upgrade     TowerUpgrade
+	Special     Special
+}
+
+// Special is what a tower branch does beyond its stat changes.
+type Special int
+
+const (
+	SpecialNone      Special = iota
+	SpecialMultiShot         // Fires at a second enemy as well
+	SpecialPierce            // Hits the enemies right behind the target too
+	SpecialStrongest         // Targets the enemy with the most health
+	SpecialSlowAura          // Slows every enemy in range
+	SpecialSplash            // Half damage to everything around the target
+	SpecialChain             // Half damage jumps on to the nearest other enemy
+)
+
+// Ability is a temporary boost a successful challenge charges a tower with.
+// Its strength s says how much: an overclocked tower fires 1+s times as
+// fast, a range boost reaches 1+s times as far, and piercing shots pass s
+// of their damage on to the enemies behind the target.
+type Ability int
+
+const (
+	AbilityNone      Ability = iota
+	AbilityOverclock         // Fires faster
+	AbilityRange             // Reaches further
+	AbilityPierce            // Shots pass through to the enemies behind
+)
+
+// String returns the ability's display name.
+func (a Ability) String() string {
+	switch a {
+	case AbilityOverclock:
+		return "Overclock"
+	case AbilityRange:
+		return "Range boost"
+	case AbilityPierce:
+		return "Piercing shots"
+	default:
+		return "None"
+	}
+}
+
+// Branch returns the branch with the given name, ignoring case.
+func (t TowerInfo) Branch(name string) (TowerBranch, bool) {
+	for _, b := range t.Branches {
+		if strings.EqualFold(b.Name, name) {
+			return b, true
+		}
+	}
+	return TowerBranch{}, false
+}
+
+// TowerTypeByName looks up a tower type by its display name, ignoring case.
+func TowerTypeByName(name string) (TowerType, bool) {
+	for t, info := range TowerTypes {
+		if strings.EqualFold(info.Name, name) {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 // EnemyType identifies different enemy variants.
 type EnemyType int
 
@@ -71,6 +159,16 @@ const (
 	EnemySpecter                  // Very fast, fragile
 	EnemyDaemon                   // Late-game tank
 	EnemyBoss                     // Final challenge
+	EnemyMoth                     // Fragile flyer
+	EnemyDragon                   // Armored flyer
+)
+
+// Movement is how an enemy gets from its spawn to the exit.
+type Movement int
+
+const (
+	MovementGround Movement = iota // Walks the path waypoints
+	MovementFlying                 // Flies straight from spawn to exit
 )
 
 // EnemyInfo contains configuration for each enemy type.
@@ -81,6 +179,7 @@ type EnemyInfo struct {
 	Symbol    string
 	Color     string
 	GoldValue int
+	Movement  Movement
 }
 
 // TowerTypes contains all tower configurations.
@@ -100,10 +199,23 @@ var TowerTypes = map[TowerType]TowerInfo{
 		Categories: []string{"movement", "buffer-management", "window-management", "quickfix", "folding"},
 		Symbol:     "🏹",
 		Color:      "#22c55e",
+		AntiAir:    true,
 		Upgrades: []TowerUpgrade{
 			{Cost: 30, DamageBonus: 1, RangeBonus: 0.3, CooldownMult: 0.9}, // +15% dmg (1.2 -> rounds to 1), +0.3 range, -10% cooldown
-			{Cost: 60, DamageBonus: 2, RangeBonus: 0.3, CooldownMult: 0.9}, // Cumulative
 		},
+		Branches: []TowerBranch{
+			{
+				Name: "Rapid", Symbol: "💨", Description: "fires at two enemies at once",
+				Upgrade: TowerUpgrade{Cost: 60, DamageBonus: 1, RangeBonus: 0.3, CooldownMult: 0.7},
+				Special: SpecialMultiShot,
+			},
+			{
+				Name: "Piercing", Symbol: "🔱", Description: "arrows pass through to the enemies behind",
+				Upgrade: TowerUpgrade{Cost: 60, DamageBonus: 4, RangeBonus: 0.3, CooldownMult: 1.0},
+				Special: SpecialPierce,
+			},
+		},
+		Ability: AbilityOverclock,
 	},
 	TowerLSP: {
 		Name:       "LSP",
@@ -115,10 +227,23 @@ var TowerTypes = map[TowerType]TowerInfo{
 		Categories: []string{"lsp-navigation", "telescope", "diagnostics", "formatting", "harpoon"},
 		Symbol:     "🔮",
 		Color:      "#8b5cf6",
+		AntiAir:    true,
 		Upgrades: []TowerUpgrade{
-			{Cost: 60, DamageBonus: 3, RangeBonus: 0.3, CooldownMult: 0.9},  // +15% dmg (3), +0.3 range, -10% cooldown
-			{Cost: 120, DamageBonus: 3, RangeBonus: 0.3, CooldownMult: 0.9}, // Cumulative
+			{Cost: 60, DamageBonus: 3, RangeBonus: 0.3, CooldownMult: 0.9}, // +15% dmg (3), +0.3 range, -10% cooldown
+		},
+		Branches: []TowerBranch{
+			{
+				Name: "Sniper", Symbol: "🔭", Description: "long range, targets the toughest enemy",
+				Upgrade: TowerUpgrade{Cost: 120, DamageBonus: 12, RangeBonus: 2.0, CooldownMult: 1.1},
+				Special: SpecialStrongest,
+			},
+			{
+				Name: "Diagnostics", Symbol: "📡", Description: "slows every enemy in range",
+				Upgrade: TowerUpgrade{Cost: 120, DamageBonus: 0, RangeBonus: 0.5, CooldownMult: 0.9},
+				Special: SpecialSlowAura,
+			},
 		},
+		Ability: AbilityRange,
 	},
 	TowerRefactor: {
 		Name:       "Refactor",
@@ -131,9 +256,21 @@ var TowerTypes = map[TowerType]TowerInfo{
 		Symbol:     "⚡",
 		Color:      "#f59e0b",
 		Upgrades: []TowerUpgrade{
-			{Cost: 90, DamageBonus: 2, RangeBonus: 0.3, CooldownMult: 0.9},  // +15% dmg (1.8 -> 2), +0.3 range, -10% cooldown
-			{Cost: 180, DamageBonus: 2, RangeBonus: 0.3, CooldownMult: 0.9}, // Cumulative
+			{Cost: 90, DamageBonus: 2, RangeBonus: 0.3, CooldownMult: 0.9}, // +15% dmg (1.8 -> 2), +0.3 range, -10% cooldown
 		},
+		Branches: []TowerBranch{
+			{
+				Name: "Extract", Symbol: "💥", Description: "blasts everything around the target",
+				Upgrade: TowerUpgrade{Cost: 180, DamageBonus: 4, RangeBonus: 0.3, CooldownMult: 0.9},
+				Special: SpecialSplash,
+			},
+			{
+				Name: "Rename", Symbol: "🔗", Description: "hits chain to a second enemy",
+				Upgrade: TowerUpgrade{Cost: 180, DamageBonus: 2, RangeBonus: 0.6, CooldownMult: 0.8},
+				Special: SpecialChain,
+			},
+		},
+		Ability: AbilityPierce,
 	},
 }
 
@@ -195,4 +332,22 @@ var EnemyTypes = map[EnemyType]EnemyInfo{
 		Color:     "#7c2d12",
 		GoldValue: 100,
 	},
+	EnemyMoth: {
+		Name:      "Moth",
+		Health:    18,
+		Speed:     1.6,
+		Symbol:    "🦋",
+		Color:     "#e879f9",
+		GoldValue: 9,
+		Movement:  MovementFlying,
+	},
+	EnemyDragon: {
+		Name:      "Dragon",
+		Health:    90,
+		Speed:     0.9,
+		Symbol:    "🐉",
+		Color:     "#0d9488",
+		GoldValue: 28,
+		Movement:  MovementFlying,
+	},
 }
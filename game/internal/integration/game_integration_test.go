@@ -9,6 +9,7 @@ package integration
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/keyforge/keyforge/internal/engine"
 	"github.com/keyforge/keyforge/internal/entities"
+	"github.com/keyforge/keyforge/internal/nvim"
 	"github.com/keyforge/keyforge/internal/ui"
 )
 
@@ -128,13 +130,40 @@ func (h *GameTestHarness) Cleanup() {
 }
 
 // Tick simulates a Bubbletea tick and returns the updated model.
+// Each tick advances the model's clock by exactly one frame, so tests step the
+// fixed-timestep simulation regardless of how fast they run.
 func (h *GameTestHarness) Tick() ui.Model {
-	tickMsg := ui.TickMsg(time.Now())
+	tickMsg := ui.TickMsg(h.Model.LastUpdate.Add(time.Second / ui.TargetFPS))
 	newModel, _ := h.Model.Update(tickMsg)
 	h.Model = newModel.(ui.Model)
 	return h.Model
 }
 
+// PassChallenge has the game's verifier issue a challenge under requestID
+// and judge a correct answer to it, as the plugin does before reporting the
+// result. It returns the gold the game awards.
+func (h *GameTestHarness) PassChallenge(requestID string) int {
+	h.t.Helper()
+	challenge := &engine.Challenge{
+		ID:             "integration_delete_word",
+		InitialBuffer:  "hello cruel world",
+		ExpectedBuffer: "hello world",
+		ValidationType: "exact_match",
+		ParKeystrokes:  4,
+		GoldBase:       20,
+	}
+	h.Model.ChallengeVerifier.Register(requestID, challenge, h.Model.Game.Economy)
+	verdict := h.Model.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID:      requestID,
+		Buffer:         challenge.ExpectedBuffer,
+		KeystrokeCount: challenge.ParKeystrokes,
+	})
+	if verdict == nil || !verdict.Success {
+		h.t.Fatalf("Expected the verifier to pass the answer, got %+v", verdict)
+	}
+	return verdict.GoldEarned
+}
+
 // TickN runs N ticks.
 func (h *GameTestHarness) TickN(n int) {
 	for range n {
@@ -258,8 +287,8 @@ func TestChallengeFlowWithSuccess(t *testing.T) {
 	}
 
 	// Simulate Neovim completing the challenge
-	goldReward := 75
-	err := h.Client.SendChallengeComplete("test_challenge_1", true, goldReward)
+	goldReward := h.PassChallenge("test_challenge_1")
+	err := h.Client.SendChallengeComplete("test_challenge_1", true, 75)
 	if err != nil {
 		t.Fatalf("Failed to send challenge complete: %v", err)
 	}
@@ -317,18 +346,17 @@ func TestMultipleChallengesInSequence(t *testing.T) {
 
 	initialGold := h.Model.Game.Gold
 	totalEarned := 0
+	h.Model.Game.ChallengeCharges = 3 // One for each challenge
 
 	for i := 1; i <= 3; i++ {
 		// Start challenge
 		h.Model.NvimChallengeCount++
-		challengeID := h.Model.NvimChallengeID
-		h.Model.NvimChallengeID = challengeID
+		h.Model.NvimChallengeID = fmt.Sprintf("sequence_challenge_%d", i)
 		h.Model.Game.StartChallengeWaiting()
 
-		// Complete with varying rewards
-		reward := 50 + (i * 10)
-		h.Client.SendChallengeComplete(h.Model.NvimChallengeID, true, reward)
-		totalEarned += reward
+		// Complete with varying reported rewards; the game pays its own
+		totalEarned += h.PassChallenge(h.Model.NvimChallengeID)
+		h.Client.SendChallengeComplete(h.Model.NvimChallengeID, true, 50+(i*10))
 
 		time.Sleep(100 * time.Millisecond)
 		h.Tick()
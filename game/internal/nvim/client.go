@@ -32,6 +32,7 @@ type Client struct {
 // Handler processes incoming RPC messages from Neovim.
 type Handler interface {
 	HandleChallengeComplete(result *ChallengeResult)
+	HandleValidateChallenge(req *ValidateChallengeRequest) *ValidateChallengeResult
 	HandleConfigUpdate(config *ConfigUpdate)
 	HandlePause()
 	HandleResume()
@@ -185,6 +186,8 @@ func (c *Client) handleRequest(req *Request) {
 			}
 			result = map[string]bool{"ok": true}
 		}
+	case MethodValidateChallenge:
+		result, rpcErr = validateChallenge(c.handler, req.Params)
 	case MethodConfigUpdate:
 		if params, ok := req.Params.(map[string]interface{}); ok {
 			cfg := parseConfigUpdate(params)
@@ -314,33 +317,7 @@ func (c *Client) Notify(method string, params interface{}) error {
 
 // RequestChallenge asks Neovim to present a challenge.
 func (c *Client) RequestChallenge(requestID string, challenge *ChallengeData) error {
-	req := &ChallengeRequest{
-		RequestID: requestID,
-	}
-	if challenge != nil {
-		req.ChallengeID = challenge.ID
-		req.ChallengeName = challenge.Name
-		req.Category = challenge.Category
-		req.Difficulty = challenge.Difficulty
-		req.Description = challenge.Description
-		req.InitialBuffer = challenge.InitialBuffer
-		req.ExpectedBuffer = challenge.ExpectedBuffer
-		req.ValidationType = challenge.ValidationType
-		req.ExpectedCursor = challenge.ExpectedCursor
-		req.ExpectedContent = challenge.ExpectedContent
-		req.FunctionName = challenge.FunctionName
-		req.CursorStart = challenge.CursorStart
-		req.ParKeystrokes = challenge.ParKeystrokes
-		req.GoldBase = challenge.GoldBase
-		req.Filetype = challenge.Filetype
-		req.HintAction = challenge.HintAction
-		req.HintFallback = challenge.HintFallback
-		req.Mode = challenge.Mode
-		// Include feedback from previous challenge
-		req.PrevSuccess = challenge.PrevSuccess
-		req.PrevStreak = challenge.PrevStreak
-		req.PrevGold = challenge.PrevGold
-	}
+	req := NewChallengeRequest(requestID, challenge)
 	return c.Notify(MethodRequestChallenge, req)
 }
 
@@ -400,6 +377,17 @@ func (c *Client) SendVictory(wave, gold, towers, health int) error {
 	})
 }
 
+// SendBossPhase notifies Neovim that a boss started a phase.
+func (c *Client) SendBossPhase(kind string, phase, health, maxHealth int, shielded bool) error {
+	return c.Notify(MethodBossPhase, &BossPhaseParams{
+		Kind:      kind,
+		Phase:     phase,
+		Health:    health,
+		MaxHealth: maxHealth,
+		Shielded:  shielded,
+	})
+}
+
 // Helper functions to parse params
 
 func parseChallengeResult(params map[string]interface{}) *ChallengeResult {
@@ -431,6 +419,61 @@ func parseChallengeResult(params map[string]interface{}) *ChallengeResult {
 	return cr
 }
 
+// validateChallenge parses a validate_challenge request and asks the handler for
+// a verdict. Shared by Client and SocketServer.
+func validateChallenge(handler Handler, rawParams interface{}) (interface{}, *RPCError) {
+	params, ok := rawParams.(map[string]interface{})
+	if !ok {
+		return nil, NewError(ErrCodeInvalidParams, "validate_challenge expects an object")
+	}
+	req := parseValidateChallengeRequest(params)
+	if req.RequestID == "" {
+		return nil, NewError(ErrCodeInvalidParams, "validate_challenge requires request_id")
+	}
+	if handler == nil {
+		return nil, NewError(ErrCodeInternalError, "no handler registered")
+	}
+	verdict := handler.HandleValidateChallenge(req)
+	if verdict == nil {
+		return nil, NewError(ErrCodeInvalidParams, "unknown challenge: "+req.RequestID)
+	}
+	return verdict, nil
+}
+
+func parseValidateChallengeRequest(params map[string]interface{}) *ValidateChallengeRequest {
+	req := &ValidateChallengeRequest{}
+	if v, ok := params["request_id"].(string); ok {
+		req.RequestID = v
+	}
+	if v, ok := params["buffer"].(string); ok {
+		req.Buffer = v
+	}
+	if v, ok := params["cursor"].([]interface{}); ok {
+		for _, n := range v {
+			if f, ok := n.(float64); ok {
+				req.Cursor = append(req.Cursor, int(f))
+			}
+		}
+	}
+	if v, ok := params["keystrokes"].([]interface{}); ok {
+		for _, k := range v {
+			if key, ok := k.(string); ok {
+				req.Keystrokes = append(req.Keystrokes, key)
+			}
+		}
+	}
+	if v, ok := params["keystroke_count"].(float64); ok {
+		req.KeystrokeCount = int(v)
+	}
+	if v, ok := params["time_ms"].(float64); ok {
+		req.TimeMs = int(v)
+	}
+	if v, ok := params["insert_entered"].(bool); ok {
+		req.InsertEntered = v
+	}
+	return req
+}
+
 func parseConfigUpdate(params map[string]interface{}) *ConfigUpdate {
 	cfg := &ConfigUpdate{}
 	if v, ok := params["difficulty"].(string); ok {
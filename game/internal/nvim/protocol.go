@@ -17,12 +17,20 @@ type ChallengeData struct {
 	ExpectedCursor  []int
 	ExpectedContent string
 	FunctionName    string
+	Pattern         string
+	Signature       string
+	OldName         string
+	NewName         string
+	Checks          []ChallengeCheck
 	CursorStart     []int
 	ParKeystrokes   int
 	GoldBase        int
 	Filetype        string
 	HintAction      string
 	HintFallback    string
+	ForbiddenKeys   []string
+	MaxKeystrokes   int
+	TimeLimit       int    // Seconds
 	Mode            string // "challenge_mode", "challenge_selection", or empty for tower defense
 	// Feedback from previous challenge (for continuous modes)
 	PrevSuccess *bool // nil if no previous, true/false for success/fail
@@ -38,6 +46,7 @@ type RPCClient interface {
 	SendChallengeAvailable(count, nextReward int, nextCategory string) error
 	SendGameOver(wave, gold, towers, health int) error
 	SendVictory(wave, gold, towers, health int) error
+	SendBossPhase(kind string, phase, health, maxHealth int, shielded bool) error
 }
 
 // Ensure Client and SocketServer implement RPCClient.
@@ -90,29 +99,94 @@ const (
 // ChallengeRequest asks Neovim to present a challenge to the user.
 // Now includes full challenge data so Neovim doesn't need its own challenge list.
 type ChallengeRequest struct {
-	RequestID       string `json:"request_id"`
-	Category        string `json:"category"`
-	Difficulty      int    `json:"difficulty"`
-	ChallengeID     string `json:"challenge_id,omitempty"`
-	ChallengeName   string `json:"challenge_name,omitempty"`
-	Description     string `json:"description,omitempty"`
-	InitialBuffer   string `json:"initial_buffer,omitempty"`
-	ExpectedBuffer  string `json:"expected_buffer,omitempty"`
-	ValidationType  string `json:"validation_type,omitempty"`
-	ExpectedCursor  []int  `json:"expected_cursor,omitempty"`
-	ExpectedContent string `json:"expected_content,omitempty"`
-	FunctionName    string `json:"function_name,omitempty"`
-	CursorStart     []int  `json:"cursor_start,omitempty"`
-	ParKeystrokes   int    `json:"par_keystrokes,omitempty"`
-	GoldBase        int    `json:"gold_base,omitempty"`
-	Filetype        string `json:"filetype,omitempty"`
-	HintAction      string `json:"hint_action,omitempty"`
-	HintFallback    string `json:"hint_fallback,omitempty"`
-	Mode            string `json:"mode,omitempty"` // "challenge_mode", "challenge_selection", or empty for tower defense
+	RequestID       string   `json:"request_id"`
+	Category        string   `json:"category"`
+	Difficulty      int      `json:"difficulty"`
+	ChallengeID     string   `json:"challenge_id,omitempty"`
+	ChallengeName   string   `json:"challenge_name,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	InitialBuffer   string   `json:"initial_buffer,omitempty"`
+	ExpectedBuffer  string   `json:"expected_buffer,omitempty"`
+	ValidationType  string   `json:"validation_type,omitempty"`
+	ExpectedCursor  []int    `json:"expected_cursor,omitempty"`
+	ExpectedContent string   `json:"expected_content,omitempty"`
+	FunctionName    string   `json:"function_name,omitempty"`
+	Pattern         string   `json:"pattern,omitempty"`
+	Signature       string   `json:"signature,omitempty"` // go_function_signature, e.g. "(int, int) int"
+	OldName         string   `json:"old_name,omitempty"`  // go_identifier_renamed
+	NewName         string   `json:"new_name,omitempty"`  // go_identifier_renamed
+	CursorStart     []int    `json:"cursor_start,omitempty"`
+	ParKeystrokes   int      `json:"par_keystrokes,omitempty"`
+	GoldBase        int      `json:"gold_base,omitempty"`
+	Filetype        string   `json:"filetype,omitempty"`
+	HintAction      string   `json:"hint_action,omitempty"`
+	HintFallback    string   `json:"hint_fallback,omitempty"`
+	ForbiddenKeys   []string `json:"forbidden_keys,omitempty"` // Vim notation; "insert_mode" forbids insert mode
+	MaxKeystrokes   int      `json:"max_keystrokes,omitempty"` // Hard limit, 0 = none
+	TimeLimit       int      `json:"time_limit,omitempty"`     // Seconds, 0 = none
+	Mode            string   `json:"mode,omitempty"`           // "challenge_mode", "challenge_selection", or empty for tower defense
 	// Feedback from previous challenge (for continuous modes)
 	PrevSuccess *bool `json:"prev_success,omitempty"` // nil if no previous, true/false for success/fail
 	PrevStreak  int   `json:"prev_streak,omitempty"`  // Current streak count (challenge mode only)
 	PrevGold    int   `json:"prev_gold,omitempty"`    // Gold earned from previous challenge
+	// Sub-checks for all_of/any_of validation
+	Checks []ChallengeCheck `json:"checks,omitempty"`
+}
+
+// ChallengeCheck is a single check within a composite (all_of/any_of) validation.
+type ChallengeCheck struct {
+	ValidationType  string           `json:"validation_type"`
+	ExpectedBuffer  string           `json:"expected_buffer,omitempty"`
+	ExpectedCursor  []int            `json:"expected_cursor,omitempty"`
+	ExpectedContent string           `json:"expected_content,omitempty"`
+	FunctionName    string           `json:"function_name,omitempty"`
+	Pattern         string           `json:"pattern,omitempty"`
+	Signature       string           `json:"signature,omitempty"`
+	OldName         string           `json:"old_name,omitempty"`
+	NewName         string           `json:"new_name,omitempty"`
+	Checks          []ChallengeCheck `json:"checks,omitempty"`
+}
+
+// NewChallengeRequest builds the request_challenge payload from challenge data.
+// A nil challenge produces a bare request that lets Neovim pick its own.
+func NewChallengeRequest(requestID string, challenge *ChallengeData) *ChallengeRequest {
+	req := &ChallengeRequest{
+		RequestID: requestID,
+	}
+	if challenge == nil {
+		return req
+	}
+	req.ChallengeID = challenge.ID
+	req.ChallengeName = challenge.Name
+	req.Category = challenge.Category
+	req.Difficulty = challenge.Difficulty
+	req.Description = challenge.Description
+	req.InitialBuffer = challenge.InitialBuffer
+	req.ExpectedBuffer = challenge.ExpectedBuffer
+	req.ValidationType = challenge.ValidationType
+	req.ExpectedCursor = challenge.ExpectedCursor
+	req.ExpectedContent = challenge.ExpectedContent
+	req.FunctionName = challenge.FunctionName
+	req.Pattern = challenge.Pattern
+	req.Signature = challenge.Signature
+	req.OldName = challenge.OldName
+	req.NewName = challenge.NewName
+	req.Checks = challenge.Checks
+	req.CursorStart = challenge.CursorStart
+	req.ParKeystrokes = challenge.ParKeystrokes
+	req.GoldBase = challenge.GoldBase
+	req.Filetype = challenge.Filetype
+	req.HintAction = challenge.HintAction
+	req.HintFallback = challenge.HintFallback
+	req.ForbiddenKeys = challenge.ForbiddenKeys
+	req.MaxKeystrokes = challenge.MaxKeystrokes
+	req.TimeLimit = challenge.TimeLimit
+	req.Mode = challenge.Mode
+	// Include feedback from previous challenge
+	req.PrevSuccess = challenge.PrevSuccess
+	req.PrevStreak = challenge.PrevStreak
+	req.PrevGold = challenge.PrevGold
+	return req
 }
 
 // GameStateUpdate notifies Neovim of game state changes.
@@ -154,6 +228,30 @@ type ChallengeResult struct {
 	GoldEarned     int     `json:"gold_earned,omitempty"` // Gold earned from challenge
 }
 
+// ValidateChallengeRequest carries the final editor state of a challenge so the
+// game can judge it. The game is the single source of truth for success and gold.
+type ValidateChallengeRequest struct {
+	RequestID      string   `json:"request_id"`
+	Buffer         string   `json:"buffer"`
+	Cursor         []int    `json:"cursor,omitempty"` // [line, col], 0-indexed
+	Keystrokes     []string `json:"keystrokes,omitempty"`
+	KeystrokeCount int      `json:"keystroke_count,omitempty"`
+	TimeMs         int      `json:"time_ms,omitempty"`
+	InsertEntered  bool     `json:"insert_entered,omitempty"` // Insert mode was used
+}
+
+// ValidateChallengeResult is the game's verdict for a submitted challenge.
+type ValidateChallengeResult struct {
+	RequestID  string  `json:"request_id"`
+	Success    bool    `json:"success"`
+	Message    string  `json:"message,omitempty"`
+	Efficiency float64 `json:"efficiency"`
+	SpeedBonus float64 `json:"speed_bonus"`
+	GoldEarned int     `json:"gold_earned"`
+	// Optimal is the shortest known answer in Vim key notation, if solved
+	Optimal string `json:"optimal,omitempty"`
+}
+
 // StartChallengeRequest is sent when user triggers a new challenge.
 type StartChallengeRequest struct {
 	// Empty for now, but can be extended with category preferences
@@ -177,9 +275,11 @@ const (
 	MethodChallengeAvailable = "challenge_available"
 	MethodGameOver           = "game_over"
 	MethodVictory            = "victory"
+	MethodBossPhase          = "boss_phase"
 
 	// Neovim -> Game.
 	MethodChallengeComplete = "challenge_complete"
+	MethodValidateChallenge = "validate_challenge"
 	MethodStartChallenge    = "start_challenge"
 	MethodConfigUpdate      = "config_update"
 	MethodPauseGame         = "pause_game"
@@ -204,6 +304,15 @@ type VictoryParams struct {
 	Health int `json:"health"`
 }
 
+// BossPhaseParams tells Neovim a boss started a phase or lost its shield.
+type BossPhaseParams struct {
+	Kind      string `json:"kind"`  // "minions", "speed_burst", "disable_towers", "shield", "shield_broken"
+	Phase     int    `json:"phase"` // Phases started so far
+	Health    int    `json:"health"`
+	MaxHealth int    `json:"max_health"`
+	Shielded  bool   `json:"shielded"` // A challenge breaks the shield
+}
+
 // NewRequest creates a new JSON-RPC request.
 func NewRequest(id int, method string, params interface{}) *Request {
 	return &Request{
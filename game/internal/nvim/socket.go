@@ -263,6 +263,8 @@ func (s *SocketServer) handleRequest(req *Request) {
 			}
 			result = map[string]bool{"ok": true}
 		}
+	case MethodValidateChallenge:
+		result, rpcErr = validateChallenge(s.handler, req.Params)
 	case MethodConfigUpdate:
 		if params, ok := req.Params.(map[string]interface{}); ok {
 			cfg := parseConfigUpdate(params)
@@ -378,33 +380,7 @@ func (s *SocketServer) Notify(method string, params interface{}) error {
 
 // RequestChallenge asks Neovim to present a challenge.
 func (s *SocketServer) RequestChallenge(requestID string, challenge *ChallengeData) error {
-	req := &ChallengeRequest{
-		RequestID: requestID,
-	}
-	if challenge != nil {
-		req.ChallengeID = challenge.ID
-		req.ChallengeName = challenge.Name
-		req.Category = challenge.Category
-		req.Difficulty = challenge.Difficulty
-		req.Description = challenge.Description
-		req.InitialBuffer = challenge.InitialBuffer
-		req.ExpectedBuffer = challenge.ExpectedBuffer
-		req.ValidationType = challenge.ValidationType
-		req.ExpectedCursor = challenge.ExpectedCursor
-		req.ExpectedContent = challenge.ExpectedContent
-		req.FunctionName = challenge.FunctionName
-		req.CursorStart = challenge.CursorStart
-		req.ParKeystrokes = challenge.ParKeystrokes
-		req.GoldBase = challenge.GoldBase
-		req.Filetype = challenge.Filetype
-		req.HintAction = challenge.HintAction
-		req.HintFallback = challenge.HintFallback
-		req.Mode = challenge.Mode
-		// Include feedback from previous challenge
-		req.PrevSuccess = challenge.PrevSuccess
-		req.PrevStreak = challenge.PrevStreak
-		req.PrevGold = challenge.PrevGold
-	}
+	req := NewChallengeRequest(requestID, challenge)
 	return s.Notify(MethodRequestChallenge, req)
 }
 
@@ -463,3 +439,14 @@ func (s *SocketServer) SendVictory(wave, gold, towers, health int) error {
 		Health: health,
 	})
 }
+
+// SendBossPhase notifies Neovim that a boss started a phase.
+func (s *SocketServer) SendBossPhase(kind string, phase, health, maxHealth int, shielded bool) error {
+	return s.Notify(MethodBossPhase, &BossPhaseParams{
+		Kind:      kind,
+		Phase:     phase,
+		Health:    health,
+		MaxHealth: maxHealth,
+		Shielded:  shielded,
+	})
+}
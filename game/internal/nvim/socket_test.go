@@ -12,6 +12,8 @@ import (
 // MockHandler implements Handler for testing.
 type MockHandler struct {
 	ChallengeResults []*ChallengeResult
+	Validations      []*ValidateChallengeRequest
+	Verdict          *ValidateChallengeResult
 	ConfigUpdates    []*ConfigUpdate
 	PauseCalls       int
 	ResumeCalls      int
@@ -24,6 +26,11 @@ func (h *MockHandler) HandleChallengeComplete(result *ChallengeResult) {
 	h.ChallengeResults = append(h.ChallengeResults, result)
 }
 
+func (h *MockHandler) HandleValidateChallenge(req *ValidateChallengeRequest) *ValidateChallengeResult {
+	h.Validations = append(h.Validations, req)
+	return h.Verdict
+}
+
 func (h *MockHandler) HandleConfigUpdate(config *ConfigUpdate) {
 	h.ConfigUpdates = append(h.ConfigUpdates, config)
 }
@@ -558,3 +565,143 @@ func TestEndToEndLevelSelectFlow(t *testing.T) {
 		t.Errorf("Expected no restart calls, got %d", handler.RestartCalls)
 	}
 }
+
+// TestSocketServerHandlesValidateChallengeRequest tests that validate_challenge
+// is answered with the handler's verdict.
+func TestSocketServerHandlesValidateChallengeRequest(t *testing.T) {
+	tmpDir := os.TempDir()
+	socketPath := filepath.Join(tmpDir, "test_validate_req.sock")
+	defer os.Remove(socketPath)
+
+	handler := &MockHandler{
+		Verdict: &ValidateChallengeResult{RequestID: "ch_1", Success: true, Efficiency: 0.5, GoldEarned: 12},
+	}
+	server := NewSocketServer(socketPath, handler)
+
+	err := server.Start()
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Consume game_ready
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	conn.Read(buf)
+
+	req := Request{
+		JSONRPC: "2.0",
+		ID:      7,
+		Method:  "validate_challenge",
+		Params: map[string]interface{}{
+			"request_id":      "ch_1",
+			"buffer":          "hello\nworld",
+			"cursor":          []int{1, 2},
+			"keystrokes":      []string{"j", "l", "l"},
+			"keystroke_count": 3,
+			"time_ms":         1200,
+		},
+	}
+	data, _ := json.Marshal(req)
+	conn.Write(append(data, '\n'))
+
+	conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+
+	var resp struct {
+		Result *ValidateChallengeResult `json:"result"`
+		Error  *RPCError                `json:"error"`
+		ID     int                      `json:"id"`
+	}
+	if err := json.Unmarshal(buf[:n-1], &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got: %v", resp.Error)
+	}
+	if resp.ID != 7 {
+		t.Errorf("Expected response ID 7, got %d", resp.ID)
+	}
+	if resp.Result == nil || !resp.Result.Success || resp.Result.GoldEarned != 12 {
+		t.Errorf("Expected handler verdict in result, got %+v", resp.Result)
+	}
+
+	if len(handler.Validations) != 1 {
+		t.Fatalf("Expected 1 validation, got %d", len(handler.Validations))
+	}
+	got := handler.Validations[0]
+	if got.Buffer != "hello\nworld" || got.KeystrokeCount != 3 || got.TimeMs != 1200 {
+		t.Errorf("Unexpected parsed request: %+v", got)
+	}
+	if len(got.Cursor) != 2 || got.Cursor[0] != 1 || got.Cursor[1] != 2 {
+		t.Errorf("Expected cursor [1 2], got %v", got.Cursor)
+	}
+	if len(got.Keystrokes) != 3 {
+		t.Errorf("Expected 3 keystrokes, got %v", got.Keystrokes)
+	}
+}
+
+// TestSocketServerValidateChallengeUnknownID tests that an unknown request
+// is rejected with an error instead of a verdict.
+func TestSocketServerValidateChallengeUnknownID(t *testing.T) {
+	tmpDir := os.TempDir()
+	socketPath := filepath.Join(tmpDir, "test_validate_unknown.sock")
+	defer os.Remove(socketPath)
+
+	handler := &MockHandler{}
+	server := NewSocketServer(socketPath, handler)
+
+	err := server.Start()
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer server.Stop()
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	time.Sleep(100 * time.Millisecond)
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	conn.Read(buf)
+
+	req := Request{
+		JSONRPC: "2.0",
+		ID:      8,
+		Method:  "validate_challenge",
+		Params:  map[string]interface{}{"request_id": "missing", "buffer": ""},
+	}
+	data, _ := json.Marshal(req)
+	conn.Write(append(data, '\n'))
+
+	conn.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(buf[:n-1], &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if resp.Error == nil || resp.Error.Code != ErrCodeInvalidParams {
+		t.Errorf("Expected invalid params error, got: %+v", resp.Error)
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestHUDShowsChargedTower(t *testing.T) {
+	m := NewModel()
+	m.Game.State = engine.StatePlaying
+	m.Game.Gold = 1000
+	x, y := buildSpot(t, &m)
+	m.act(engine.Action{Kind: engine.ActionPlaceTower, X: x, Y: y})
+	if hud := renderHUD(&m); !strings.Contains(hud, "charges 🏹 Overclock") || strings.Contains(hud, "Charged:") {
+		t.Fatalf("HUD before the challenge:\n%s", hud)
+	}
+
+	m.act(engine.Action{Kind: engine.ActionStartChallenge})
+	m.act(engine.Action{Kind: engine.ActionEndChallenge, Success: true, Gold: 10, Efficiency: 0.5, SpeedBonus: 1})
+	if hud := renderHUD(&m); !strings.Contains(hud, "Charged: 🏹 Overclock +38% (15s)") {
+		t.Errorf("HUD doesn't show the charged tower:\n%s", hud)
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestBossBarInHUD(t *testing.T) {
+	m := newTestModel()
+	if strings.Contains(renderHUD(&m), "Boss") {
+		t.Fatal("HUD shows a boss bar without a boss")
+	}
+
+	m.Game.SpawnEnemy(entities.EnemyBoss)
+	boss := m.Game.ActiveBoss()
+	boss.Enemy.Health = boss.Enemy.MaxHealth / 5
+	boss.Phase = len(boss.Phases)
+	boss.Shielded = true
+
+	hud := renderHUD(&m)
+	for _, want := range []string{"Boss", "Phase: Shielded", "Complete a challenge"} {
+		if !strings.Contains(hud, want) {
+			t.Errorf("HUD is missing %q:\n%s", want, hud)
+		}
+	}
+
+	boss.Enemy.Dead = true
+	if strings.Contains(renderHUD(&m), "Boss") {
+		t.Error("HUD still shows the boss bar after the boss died")
+	}
+}
+
+func TestBossPhasesForwardedToNvim(t *testing.T) {
+	model := NewModel()
+	rpc := &MockRPCClient{}
+	model.NvimMode = true
+	model.NvimRPC = rpc
+	levels := model.LevelRegistry.GetAll()
+	model.SelectedLevel = &levels[0]
+	model.startGameFromSettings()
+
+	model.Game.SpawnEnemy(entities.EnemyBoss)
+	boss := model.Game.ActiveBoss()
+	boss.Phase, boss.Shielded = 4, true
+	model.Game.Events.Publish(engine.BossPhaseChanged{Boss: boss, Kind: engine.BossPhaseShield})
+
+	if len(rpc.BossPhases) != 1 {
+		t.Fatalf("Expected 1 boss phase notification, got %+v", rpc.BossPhases)
+	}
+	got := rpc.BossPhases[0]
+	if got.Kind != "shield" || got.Phase != 4 || !got.Shielded || got.MaxHealth != boss.Enemy.MaxHealth {
+		t.Errorf("Unexpected boss phase notification %+v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestBranchPrompt(t *testing.T) {
+	m := NewModel()
+	m.Game.State = engine.StatePlaying
+	m.Game.Gold = 1000
+	x, y := buildSpot(t, &m)
+	m.Game.CursorX, m.Game.CursorY = x, y
+	m = press(t, m, " ")
+	m = press(t, m, "u")
+	tower := m.Game.GetTowerAt(x, y)
+	if tower == nil || tower.Level != 1 || m.BranchPrompt {
+		t.Fatal("Expected [u] to buy the first upgrade tier")
+	}
+	if shop := renderShop(&m); !strings.Contains(shop, "[u] Specialise") {
+		t.Errorf("Shop doesn't offer the specialisation:\n%s", shop)
+	}
+
+	// Esc closes the prompt without buying anything
+	m = press(t, m, "u")
+	if !m.BranchPrompt {
+		t.Fatal("Expected [u] to open the branch prompt")
+	}
+	if view := RenderGame(&m); !strings.Contains(view, "Rapid") || !strings.Contains(view, "Piercing") {
+		t.Errorf("Prompt doesn't list the Arrow branches:\n%s", view)
+	}
+	m = press(t, m, "esc")
+	if m.BranchPrompt || tower.Branch != "" {
+		t.Fatalf("Esc left prompt %v and branch %q", m.BranchPrompt, tower.Branch)
+	}
+
+	m = press(t, m, "u")
+	m = press(t, m, "2")
+	if m.BranchPrompt || tower.Branch != "Piercing" {
+		t.Fatalf("[2] left prompt %v and branch %q, want Piercing", m.BranchPrompt, tower.Branch)
+	}
+	if m.Game.SelectedTower != entities.TowerArrow {
+		t.Errorf("Choosing a branch selected tower %v", m.Game.SelectedTower)
+	}
+	if got := renderTower(tower); !strings.Contains(got, "🔱") {
+		t.Errorf("renderTower() = %q, want the Piercing symbol", got)
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestBuildPhaseFromSettings(t *testing.T) {
+	m := NewModel()
+	m.Game.State = engine.StateSettings
+	m.SelectedLevel = m.LevelRegistry.GetByID("level-1")
+	m.SettingsMenuIndex = 4
+	m = press(t, m, "l")
+	if !m.Settings.BuildPhase || !strings.Contains(RenderSettingsScreen(&m), "Build Phase") {
+		t.Fatal("Expected [l] to turn the build phase on")
+	}
+
+	m.SettingsMenuIndex = settingsStartIndex
+	m = press(t, m, "enter")
+	if !m.Game.Building {
+		t.Fatal("Expected the game to start in a build phase")
+	}
+	if hud := renderHUD(&m); !strings.Contains(hud, "BUILD PHASE") {
+		t.Errorf("HUD doesn't show the build phase:\n%s", hud)
+	}
+	if preview := renderWavePreview(&m); !strings.Contains(preview, "Next wave 1") || !strings.Contains(preview, "[n] Start wave") {
+		t.Errorf("Preview = %q, want wave 1 and the start hint", preview)
+	}
+
+	// Place a tower, undo and redo it, then start the wave
+	x, y := buildSpot(t, &m)
+	m.Game.CursorX, m.Game.CursorY = x, y
+	gold := m.Game.Gold
+	m = press(t, m, " ")
+	m = press(t, m, "U")
+	if m.Game.HasTower(x, y) || m.Game.Gold != gold {
+		t.Fatalf("[U] left tower %v and gold %d, want %d", m.Game.HasTower(x, y), m.Game.Gold, gold)
+	}
+	m = press(t, m, "ctrl+r")
+	if !m.Game.HasTower(x, y) {
+		t.Fatal("[Ctrl+R] didn't redo the placement")
+	}
+	m = press(t, m, "n")
+	if m.Game.Building {
+		t.Error("[n] didn't start the wave")
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestCategoryChooser(t *testing.T) {
+	m := NewModel()
+	m.Game.State = engine.StatePlaying
+	m.subscribeGame()
+	x, y := buildSpot(t, &m)
+	m.act(engine.Action{Kind: engine.ActionPlaceTower, X: x, Y: y})
+	arrow := entities.TowerTypes[entities.TowerArrow].Categories
+
+	m = press(t, m, "c")
+	if len(m.CategoryChoices) != len(arrow) || m.Game.ChallengeActive {
+		t.Fatalf("[c] on an Arrow tower: %d choices, challenge %v", len(m.CategoryChoices), m.Game.ChallengeActive)
+	}
+	want := fmt.Sprintf("[2] buffer-management (%d, –)", m.CategoryChoices[1].Count)
+	if view := RenderGame(&m); !strings.Contains(view, want) || m.CategoryChoices[1].Count == 0 {
+		t.Errorf("Chooser doesn't list buffer-management with its count:\n%s", view)
+	}
+
+	// Choose a category, then give up on the challenge
+	m = press(t, m, "2")
+	if m.CurrentChallenge == nil || m.CurrentChallenge.Category != "buffer-management" ||
+		m.Game.ChallengeCategory != "buffer-management" {
+		t.Fatalf("Expected a buffer-management challenge, got %+v", m.CurrentChallenge)
+	}
+	m = press(t, m, "esc")
+	if m.Game.ChallengeActive {
+		t.Fatal("Esc didn't end the challenge")
+	}
+
+	m = press(t, m, "c")
+	want = fmt.Sprintf("buffer-management (%d, 0%%)", m.CategoryChoices[1].Count)
+	if view := RenderGame(&m); !strings.Contains(view, want) {
+		t.Errorf("Chooser doesn't show the failed attempt:\n%s", view)
+	}
+	m = press(t, m, "esc")
+	if m.CategoryChoices != nil || m.Game.ChallengeActive {
+		t.Fatal("Esc should close the chooser without a challenge")
+	}
+
+	// Not choosing rotates through the tower's categories
+	m = press(t, m, "c")
+	m = press(t, m, "c")
+	if m.CurrentChallenge == nil || !slices.Contains(arrow, m.CurrentChallenge.Category) {
+		t.Errorf("Rotation picked %+v, want one of %v", m.CurrentChallenge, arrow)
+	}
+}
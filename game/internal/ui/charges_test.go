@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestChallengeChargesInHUD(t *testing.T) {
+	m := newTestModel()
+	m.Game.ChallengeCharges = 1
+	hud := renderHUD(&m)
+	for _, want := range []string{"Challenges: ●○○ 1/3", "next in 30s", "[c] Challenge: "} {
+		if !strings.Contains(hud, want) {
+			t.Errorf("HUD is missing %q:\n%s", want, hud)
+		}
+	}
+
+	m.Game.ChallengeCharges = 0
+	if hud := renderHUD(&m); strings.Contains(hud, "[c] Challenge") {
+		t.Errorf("HUD offers a challenge without charges:\n%s", hud)
+	}
+	m = press(t, m, "c")
+	if m.Game.ChallengeActive || m.CurrentChallenge != nil {
+		t.Error("[c] started a challenge without charges")
+	}
+}
+
+func TestChallengeAvailableSentToNvim(t *testing.T) {
+	model := NewModel()
+	rpc := &MockRPCClient{}
+	model.NvimMode = true
+	model.NvimRPC = rpc
+	levels := model.LevelRegistry.GetAll()
+	model.SelectedLevel = &levels[0]
+	model.startGameFromSettings()
+
+	if len(rpc.Available) != 1 || rpc.Available[0].Count != model.Game.ChallengeCharges {
+		t.Fatalf("Expected the starting charges, got %+v", rpc.Available)
+	}
+	first := rpc.Available[0]
+	if first.NextCategory == "" || first.NextReward <= 0 {
+		t.Errorf("Expected a preview of the next challenge, got %+v", first)
+	}
+
+	model.startChallenge() // Off a tower the game picks the category
+	if !model.Game.ChallengeActive {
+		t.Fatal("Challenge didn't start")
+	}
+	if len(rpc.Available) != 2 || rpc.Available[1].Count != first.Count-1 {
+		t.Fatalf("Expected a notification for the used charge, got %+v", rpc.Available)
+	}
+	if got := rpc.ChallengeRequests[0].ChallengeData.Category; got != first.NextCategory {
+		t.Errorf("Challenge came from %s, preview said %s", got, first.NextCategory)
+	}
+
+	model.act(engine.Action{Kind: engine.ActionEndChallenge})
+	if len(rpc.Available) != 2 {
+		t.Errorf("Ending a challenge doesn't change the charges, got %+v", rpc.Available)
+	}
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestDirectorFromSettings(t *testing.T) {
+	m := NewModel()
+	m.Game.State = engine.StateSettings
+	m.SelectedLevel = m.LevelRegistry.GetByID("level-1")
+	m.SettingsMenuIndex = 5
+	m = press(t, m, "l")
+	if !m.Settings.Director || !strings.Contains(RenderSettingsScreen(&m), "Adaptive Waves") {
+		t.Fatal("Expected [l] to turn the director on")
+	}
+
+	m.SettingsMenuIndex = settingsStartIndex
+	m = press(t, m, "enter")
+	if m.Game.Director == nil {
+		t.Fatal("Expected the game to start with a director")
+	}
+	if preview := renderWavePreview(&m); strings.Contains(preview, "Director") {
+		t.Errorf("Preview = %q, want no director note before it adjusts anything", preview)
+	}
+
+	m.Game.Director.Pressure = 0.5
+	if preview := renderWavePreview(&m); !strings.Contains(preview, "health +15%, spawn delay -15%") {
+		t.Errorf("Preview = %q, want the director's adjustment", preview)
+	}
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+// EndlessBest is the best endless run on one level.
+type EndlessBest struct {
+	Score      int       `json:"score"`
+	Waves      int       `json:"waves"` // Waves survived
+	Challenges int       `json:"challenges"`
+	Difficulty string    `json:"difficulty"`
+	Date       time.Time `json:"date"`
+}
+
+// EndlessBests holds the personal best endless run per level and saves it
+// to a JSON file. Only the Update loop touches it.
+type EndlessBests struct {
+	path   string
+	Levels map[string]EndlessBest `json:"levels"`
+}
+
+// DefaultEndlessBestsPath returns where personal bests are kept unless
+// overridden, or "" if the platform has no config directory.
+func DefaultEndlessBestsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "keyforge", "endless.json")
+}
+
+// LoadEndlessBests reads personal bests from path. A missing file is an
+// empty record, not an error.
+func LoadEndlessBests(path string) (*EndlessBests, error) {
+	b := &EndlessBests{path: path, Levels: make(map[string]EndlessBest)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return b, err
+	}
+	if err := json.Unmarshal(data, b); err != nil {
+		return b, err
+	}
+	if b.Levels == nil {
+		b.Levels = make(map[string]EndlessBest)
+	}
+	return b, nil
+}
+
+// Best returns the personal best for a level.
+func (b *EndlessBests) Best(levelID string) (EndlessBest, bool) {
+	best, ok := b.Levels[levelID]
+	return best, ok
+}
+
+// Record stores a finished run if it beats the level's best and saves the
+// file. It reports whether the run is a new best.
+func (b *EndlessBests) Record(report *engine.GameReport) (bool, error) {
+	if best, ok := b.Levels[report.Level]; ok && best.Score >= report.Score {
+		return false, nil
+	}
+	b.Levels[report.Level] = EndlessBest{
+		Score:      report.Score,
+		Waves:      report.Survived,
+		Challenges: report.Challenges.Succeeded,
+		Difficulty: report.Difficulty,
+		Date:       time.Now(),
+	}
+	return true, b.save()
+}
+
+func (b *EndlessBests) save() error {
+	if b.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o750); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, data, 0o600)
+}
+
+// recordEndlessBest stores the endless run that just ended as a personal
+// best if it is one.
+func (m *Model) recordEndlessBest() {
+	m.NewEndlessBest = false
+	if m.EndlessBests == nil || !m.Game.Endless || m.Player != nil {
+		return
+	}
+	report := m.Game.Report()
+	isBest, err := m.EndlessBests.Record(&report)
+	if err != nil {
+		m.Game.SetStatusMessage("Failed to save personal best: " + err.Error())
+	}
+	m.NewEndlessBest = isBest
+}
+
+// renderEndlessResult renders the score of a finished endless run.
+func renderEndlessResult(m *Model) string {
+	g := m.Game
+	result := fmt.Sprintf("  Endless score: %d (%d waves survived, %d challenges solved)\n",
+		g.EndlessScore(), g.WavesSurvived(), g.Report().Challenges.Succeeded)
+	switch {
+	case m.NewEndlessBest:
+		result += VictoryStyle.Render("  New personal best!") + "\n"
+	case m.EndlessBests != nil:
+		if best, ok := m.EndlessBests.Best(g.LevelID); ok {
+			result += fmt.Sprintf("  Personal best: %d (%d waves)\n", best.Score, best.Waves)
+		}
+	}
+	return result
+}
+
+// renderEndlessBest returns a level's personal best for the level list.
+func renderEndlessBest(m *Model, levelID string) string {
+	if m.EndlessBests == nil {
+		return ""
+	}
+	if best, ok := m.EndlessBests.Best(levelID); ok {
+		return fmt.Sprintf(" (best: %d waves)", best.Waves)
+	}
+	return ""
+}
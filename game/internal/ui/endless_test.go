@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestEndlessBestsRecordAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endless.json")
+	bests, err := LoadEndlessBests(path)
+	if err != nil {
+		t.Fatalf("LoadEndlessBests() on a missing file: %v", err)
+	}
+
+	run := engine.GameReport{Level: "level-1", Endless: true, Score: 700, Survived: 6}
+	if isBest, err := bests.Record(&run); !isBest || err != nil {
+		t.Fatalf("First run should be a best, got %v, %v", isBest, err)
+	}
+	worse := engine.GameReport{Level: "level-1", Endless: true, Score: 300, Survived: 3}
+	if isBest, _ := bests.Record(&worse); isBest {
+		t.Error("A lower score replaced the personal best")
+	}
+
+	loaded, err := LoadEndlessBests(path)
+	if err != nil {
+		t.Fatalf("LoadEndlessBests() error = %v", err)
+	}
+	if best, ok := loaded.Best("level-1"); !ok || best.Score != 700 || best.Waves != 6 {
+		t.Errorf("Loaded best = %+v, want score 700 over 6 waves", best)
+	}
+}
+
+func TestEndlessModeFromStartScreen(t *testing.T) {
+	m := NewModel()
+	m.EndlessBests, _ = LoadEndlessBests(filepath.Join(t.TempDir(), "endless.json"))
+	m.StartSection = SectionModes
+	m.ModeMenuIndex = 2
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	updated, _ := m.handleLevelSelectKeys(enter)
+	m = updated.(Model)
+	if !m.Settings.Endless || m.StartSection != SectionLevels {
+		t.Fatal("Endless Mode should switch to picking a level for an endless run")
+	}
+	if !strings.Contains(RenderStartScreen(&m), "Select Level (Endless)") {
+		t.Error("Level list doesn't say it is for endless mode")
+	}
+
+	updated, _ = m.handleLevelSelectKeys(enter)
+	m = updated.(Model)
+	m.SettingsMenuIndex = settingsStartIndex
+	updated, _ = m.handleSettingsKeys(enter)
+	m = updated.(Model)
+	if !m.Game.Endless {
+		t.Fatal("Expected an endless game")
+	}
+
+	m.Game.Health = 0
+	for range 3 {
+		m = frame(t, m)
+	}
+	if m.Game.State != engine.StateGameOver {
+		t.Fatalf("Expected game over, got %v", m.Game.State)
+	}
+	if !m.NewEndlessBest {
+		t.Error("The first endless run on a level should be a personal best")
+	}
+	if _, ok := m.EndlessBests.Best(m.SelectedLevel.ID); !ok {
+		t.Error("Personal best was not recorded")
+	}
+	if !strings.Contains(RenderGameOver(&m), "Endless score") {
+		t.Error("Game over screen doesn't show the endless score")
+	}
+}
+
+func TestEscLeavesEndlessLevelSelect(t *testing.T) {
+	m := NewModel()
+	m.Settings.Endless = true
+
+	updated, _ := m.handleLevelSelectKeys(tea.KeyMsg{Type: tea.KeyEscape})
+	if updated.(Model).Settings.Endless {
+		t.Error("Esc should go back to the campaign level list")
+	}
+}
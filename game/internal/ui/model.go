@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,10 @@ const (
 	keyUp     = "up"
 	keyEsc    = "esc"
 	keyEscape = "Escape"
+
+	// settingsStartIndex is the Start Game button, after the settings:
+	// 0 difficulty, 1 speed, 2 gold, 3 health, 4 build phase, 5 director.
+	settingsStartIndex = 6
 )
 
 // TickMsg is sent on each frame update.
@@ -72,6 +77,9 @@ type Model struct {
 	LevelMenuIndex    int // Selected level in level browser
 	SettingsMenuIndex int // Selected setting in settings menu
 
+	// Custom difficulty profile named by --difficulty, offered after the presets
+	CustomDifficulty *engine.DifficultyProfile
+
 	// Start menu section navigation
 	StartSection  StartMenuSection // Which section is active (levels or modes)
 	ModeMenuIndex int              // Selected mode (0 = Challenge Mode, 1 = Challenge Selection)
@@ -101,6 +109,38 @@ type Model struct {
 	PrevGameState      engine.GameState   // Track state changes for notifications
 	// Pending feedback to send with next challenge request
 	PendingFeedback *ChallengeFeedback
+	// Verifier judges Neovim submissions; the game decides success and gold
+	ChallengeVerifier *ChallengeVerifier
+	// OptimalSolutions holds the shortest answers shown after a challenge
+	OptimalSolutions *OptimalSolutions
+
+	// Replays: Recorder saves each tower defense game (nil disables it),
+	// Player drives the game from a recording instead of the keyboard
+	Recorder       *ReplayRecorder
+	Player         *ReplayPlayer
+	LastReplayPath string
+
+	// Reports: the end screen exports the game report into ReportDir
+	ReportDir      string
+	LastReportPath string
+	ReportErr      error
+
+	// Random Level entry: the seed and difficulty to generate from, and
+	// the level last generated from them
+	RandomSeed       uint64
+	RandomDifficulty engine.LevelDifficulty
+	RandomLevel      *engine.Level
+
+	// Personal bests for endless mode (nil disables them)
+	EndlessBests   *EndlessBests
+	NewEndlessBest bool
+
+	// BranchPrompt asks which specialisation the tower at the cursor takes
+	BranchPrompt bool
+
+	// CategoryChoices lists the challenge categories of the tower at the
+	// cursor while the player picks one, nil otherwise
+	CategoryChoices []engine.CategoryChoice
 
 	// Channels for RPC commands (thread-safe communication with Update loop)
 	ChallengeResultChan chan *nvim.ChallengeResult
@@ -118,6 +158,9 @@ func NewModelWithSettings(settings engine.GameSettings) Model {
 	cm, _ := engine.NewChallengeManager()
 	cs := engine.NewChallengeSelector(cm)
 	registry := engine.NewLevelRegistry()
+	optimal := NewOptimalSolutions()
+	verifier := NewChallengeVerifier()
+	verifier.optimal = optimal
 
 	// Get the first level as default selection
 	levels := registry.GetAll()
@@ -126,6 +169,11 @@ func NewModelWithSettings(settings engine.GameSettings) Model {
 		selectedLevel = &levels[0]
 	}
 
+	var custom *engine.DifficultyProfile
+	if settings.Economy != nil {
+		custom = &engine.DifficultyProfile{Name: settings.Difficulty, Economy: *settings.Economy}
+	}
+
 	// Create a placeholder game (will be replaced when starting from settings)
 	// Start in level select state
 	game := engine.NewGame(GridWidth, GridHeight)
@@ -149,6 +197,7 @@ func NewModelWithSettings(settings engine.GameSettings) Model {
 		LevelRegistry:       registry,
 		SelectedLevel:       selectedLevel,
 		Settings:            settings,
+		CustomDifficulty:    custom,
 		LevelMenuIndex:      0,
 		SettingsMenuIndex:   0,
 		StartSection:        SectionLevels,
@@ -160,6 +209,10 @@ func NewModelWithSettings(settings engine.GameSettings) Model {
 		TerminalWidth:       80,
 		TerminalHeight:      24,
 		BufferScroll:        0,
+		ChallengeVerifier:   verifier,
+		OptimalSolutions:    optimal,
+		RandomSeed:          engine.NewLevelSeed(),
+		RandomDifficulty:    engine.LevelDifficultyBeginner,
 		ChallengeResultChan: make(chan *nvim.ChallengeResult, 10),
 		RestartChan:         make(chan struct{}, 1),
 		LevelSelectChan:     make(chan struct{}, 1),
@@ -205,6 +258,15 @@ func (m *Model) HandleChallengeComplete(result *nvim.ChallengeResult) {
 	}
 }
 
+// HandleValidateChallenge validates a submission from Neovim and returns the verdict.
+// Safe to call from the RPC goroutine: the verifier is shared and locked.
+func (m *Model) HandleValidateChallenge(req *nvim.ValidateChallengeRequest) *nvim.ValidateChallengeResult {
+	if m.ChallengeVerifier == nil {
+		return nil
+	}
+	return m.ChallengeVerifier.Verify(req)
+}
+
 // HandleConfigUpdate processes config updates from Neovim.
 func (m *Model) HandleConfigUpdate(config *nvim.ConfigUpdate) {
 	// Could apply difficulty settings etc.
@@ -213,14 +275,14 @@ func (m *Model) HandleConfigUpdate(config *nvim.ConfigUpdate) {
 // HandlePause pauses the game.
 func (m *Model) HandlePause() {
 	if m.Game.State == engine.StatePlaying {
-		m.Game.TogglePause()
+		m.act(engine.Action{Kind: engine.ActionTogglePause})
 	}
 }
 
 // HandleResume resumes the game.
 func (m *Model) HandleResume() {
 	if m.Game.State == engine.StatePaused {
-		m.Game.TogglePause()
+		m.act(engine.Action{Kind: engine.ActionTogglePause})
 	}
 }
 
@@ -332,8 +394,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocritic // h
 		select {
 		case <-m.RestartChan:
 			// Restart with same level and settings
+			m.finishRecording()
 			if m.SelectedLevel != nil {
 				m.Game = engine.NewGameFromLevelAndSettings(m.SelectedLevel, m.Settings)
+				m.subscribeGame()
+				m.startRecording()
 			} else {
 				m.Game = engine.NewGame(GridWidth, GridHeight)
 			}
@@ -342,15 +407,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocritic // h
 			m.VimEditor = nil
 			m.NvimChallengeID = ""
 			m.PrevGameState = engine.StatePlaying
-			if m.ChallengeSelector != nil {
-				m.ChallengeSelector.Reset()
-			}
+			m.resetChallengeSelector()
+			m.announceChallengeCharges()
 		default:
 		}
 
 		// Process level select commands from RPC (non-blocking)
 		select {
 		case <-m.LevelSelectChan:
+			m.finishRecording()
 			m.Game.State = engine.StateLevelSelect
 			m.SettingsMenuIndex = 0
 			m.CurrentChallenge = nil
@@ -364,7 +429,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocritic // h
 
 		// Store previous state before update
 		prevState := m.Game.State
-		m.Game.Update(dt)
+		if m.Player != nil {
+			m.Player.Advance(m.Game, dt)
+		} else {
+			m.Game.Advance(dt)
+		}
+
+		// Save the replay as soon as the game is decided
+		if m.Game.State != prevState && (m.Game.State == engine.StateGameOver || m.Game.State == engine.StateVictory) {
+			m.finishRecording()
+			m.recordEndlessBest()
+		}
 
 		// Check for state changes and send notifications in nvim mode
 		if m.NvimMode && m.NvimRPC != nil && m.Game.State != prevState {
@@ -386,10 +461,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocritic // h
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
 	// Global quit
 	if msg.String() == keyCtrlC {
+		m.finishRecording()
 		m.Quitting = true
 		return m, tea.Quit
 	}
 
+	// Playback ignores game input
+	if m.Player != nil {
+		return m.handleReplayKeys(msg)
+	}
+
 	// Game state specific keys
 	switch m.Game.State {
 	case engine.StateLevelSelect:
@@ -397,6 +478,12 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:go
 	case engine.StateSettings:
 		return m.handleSettingsKeys(msg)
 	case engine.StatePlaying:
+		if m.BranchPrompt {
+			return m.handleBranchKeys(msg)
+		}
+		if m.CategoryChoices != nil {
+			return m.handleCategoryKeys(msg)
+		}
 		return m.handlePlayingKeys(msg)
 	case engine.StatePaused:
 		return m.handlePausedKeys(msg)
@@ -429,12 +516,17 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:go
 
 func (m Model) handleLevelSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
 	levels := m.LevelRegistry.GetAll()
-	numModes := 2 // Challenge Mode and Challenge Selection
+	numModes := 3 // Challenge Mode, Challenge Selection and Endless Mode
+
+	if m.handleRandomLevelKey(msg.String()) {
+		return m, nil
+	}
 
 	switch msg.String() {
 	case "j", keyDown:
 		if m.StartSection == SectionLevels {
-			if m.LevelMenuIndex < len(levels)-1 {
+			// The Random Level entry follows the last level
+			if m.LevelMenuIndex < len(levels) {
 				m.LevelMenuIndex++
 			} else {
 				// Move to modes section
@@ -454,7 +546,7 @@ func (m Model) handleLevelSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //no
 			} else {
 				// Move back to levels section
 				m.StartSection = SectionLevels
-				m.LevelMenuIndex = len(levels) - 1
+				m.LevelMenuIndex = len(levels)
 			}
 		} else {
 			// In levels section
@@ -469,22 +561,34 @@ func (m Model) handleLevelSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //no
 				m.SelectedLevel = &levels[m.LevelMenuIndex]
 				m.Game.State = engine.StateSettings
 				m.SettingsMenuIndex = 0
+			} else if level := m.randomLevel(); level != nil {
+				m.SelectedLevel = level
+				m.Game.State = engine.StateSettings
+				m.SettingsMenuIndex = 0
 			}
 		} else {
 			// Select mode
-			if m.ModeMenuIndex == 0 {
+			switch m.ModeMenuIndex {
+			case 0:
 				// Challenge Mode
 				m.Game.State = engine.StateChallengeMode
 				m.ChallengeModeStreak = 0
 				m.Notification = nil
 				m.startChallengeModeChallenge()
-			} else {
+			case 1:
 				// Challenge Selection
 				m.Game.State = engine.StateChallengeSelection
 				m.ChallengeListIndex = 0
 				m.ChallengeListOffset = 0
+			default:
+				// Endless Mode: pick the level to survive on
+				m.Settings.Endless = true
+				m.StartSection = SectionLevels
 			}
 		}
+	case keyEsc:
+		// Leave endless level selection
+		m.Settings.Endless = false
 	case "q":
 		m.Quitting = true
 		return m, tea.Quit
@@ -494,7 +598,7 @@ func (m Model) handleLevelSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //no
 }
 
 func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
-	maxIndex := 4 // 0: difficulty, 1: speed, 2: gold, 3: health, 4: start button
+	maxIndex := settingsStartIndex
 
 	switch msg.String() {
 	case "j", keyDown:
@@ -510,7 +614,7 @@ func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolin
 	case "l", "right":
 		m.adjustSetting(1)
 	case keyEnter, keySpace:
-		if m.SettingsMenuIndex == 4 {
+		if m.SettingsMenuIndex == settingsStartIndex {
 			// Start game
 			m.startGameFromSettings()
 		}
@@ -528,22 +632,13 @@ func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolin
 func (m *Model) adjustSetting(delta int) {
 	switch m.SettingsMenuIndex {
 	case 0: // Difficulty
-		difficulties := []string{engine.DifficultyEasy, engine.DifficultyNormal, engine.DifficultyHard}
-		idx := 1 // default normal
-		for i, d := range difficulties {
-			if d == m.Settings.Difficulty {
-				idx = i
-				break
-			}
-		}
-		idx += delta
-		if idx < 0 {
-			idx = 0
-		}
-		if idx >= len(difficulties) {
-			idx = len(difficulties) - 1
+		names := m.difficultyNames()
+		idx := min(max(m.difficultySetting()+delta, 0), len(names)-1)
+		if m.CustomDifficulty != nil && idx == len(names)-1 {
+			m.Settings.UseProfile(*m.CustomDifficulty)
+		} else {
+			m.Settings.Difficulty, m.Settings.Economy = names[idx], nil
 		}
-		m.Settings.Difficulty = difficulties[idx]
 	case 1: // Speed
 		speeds := engine.GameSpeedOptions()
 		idx := 1 // default 1x
@@ -577,7 +672,45 @@ func (m *Model) adjustSetting(delta int) {
 		if m.Settings.StartingHealth > 200 {
 			m.Settings.StartingHealth = 200
 		}
+	case 4: // Build Phase
+		m.Settings.BuildPhase = delta > 0
+	case 5: // Adaptive difficulty director
+		m.Settings.Director = delta > 0
+	}
+}
+
+// subscribeGame forwards the new game's events to Neovim. Only gold worth
+// telling the player about is sent: per-kill gold and spending would flood
+// the notifications. Challenge gold outside a tower defense challenge comes
+// from the challenge modes, which report results themselves. Boss phases
+// are always sent, so the player knows when a challenge breaks a shield.
+func (m *Model) subscribeGame() {
+	// The selector learns the player's accuracy per category
+	if cs := m.ChallengeSelector; cs != nil {
+		m.Game.Events.Subscribe(func(e engine.Event) {
+			if e, ok := e.(engine.ChallengeEnded); ok {
+				cs.RecordResult(e.Category, e.Success)
+			}
+		})
+	}
+	if !m.NvimMode || m.NvimRPC == nil {
+		return
 	}
+	rpc, g, cs := m.NvimRPC, m.Game, m.ChallengeSelector
+	g.Events.Subscribe(func(e engine.Event) {
+		switch e := e.(type) {
+		case engine.GoldChanged:
+			if e.Source == engine.GoldSourceWaveBonus || e.Source == engine.GoldSourceInterest ||
+				e.Source == engine.GoldSourceChallenge && g.ChallengeActive {
+				_ = rpc.SendGoldUpdate(e.Gold, e.Delta, string(e.Source), e.SpeedBonus)
+			}
+		case engine.BossPhaseChanged:
+			boss := e.Boss.Enemy
+			_ = rpc.SendBossPhase(string(e.Kind), e.Boss.Phase, boss.Health, boss.MaxHealth, e.Boss.Shielded)
+		case engine.ChallengeChargesChanged:
+			sendChallengeAvailable(rpc, g, cs)
+		}
+	})
 }
 
 func (m *Model) startGameFromSettings() {
@@ -585,19 +718,71 @@ func (m *Model) startGameFromSettings() {
 		return
 	}
 	m.Game = engine.NewGameFromLevelAndSettings(m.SelectedLevel, m.Settings)
+	m.subscribeGame()
 	m.LastUpdate = time.Now()
 	m.CurrentChallenge = nil
 	m.VimEditor = nil
 	m.NvimChallengeID = ""
 	m.PrevGameState = engine.StatePlaying
-	if m.ChallengeSelector != nil {
-		m.ChallengeSelector.Reset()
-	}
+	m.resetChallengeSelector()
+	m.startRecording()
 
 	// Notify Neovim that game is ready (if in nvim mode)
 	if m.NvimMode && m.NvimRPC != nil {
 		_ = m.NvimRPC.SendGameReady()
 	}
+	m.announceChallengeCharges()
+}
+
+// resetChallengeSelector clears selection history and reseeds the selector
+// from the current game, so the seed also fixes the order of challenges.
+func (m *Model) resetChallengeSelector() {
+	if m.ChallengeSelector == nil {
+		return
+	}
+	m.ChallengeSelector.Reset()
+	m.ChallengeSelector.SetRNG(engine.NewChallengeRNG(m.Game.Seed))
+}
+
+// act applies a player action to the game on the current tick and records it
+// for the replay. Every input that changes the simulation goes through here.
+func (m *Model) act(a engine.Action) bool {
+	a.Tick = m.Game.Tick
+	if !m.Game.Apply(a) {
+		return false
+	}
+	if m.Recorder != nil {
+		m.Recorder.Record(a)
+	}
+	return true
+}
+
+// startRecording begins a replay of the game that was just created.
+func (m *Model) startRecording() {
+	m.LastReplayPath = ""
+	m.LastReportPath = ""
+	m.ReportErr = nil
+	if m.Recorder == nil || m.SelectedLevel == nil {
+		return
+	}
+	settings := m.Settings
+	settings.Seed = m.Game.Seed
+	m.Recorder.Start(m.SelectedLevel, settings)
+}
+
+// finishRecording saves the replay of the current game, if one is recording.
+func (m *Model) finishRecording() {
+	if m.Recorder == nil {
+		return
+	}
+	path, err := m.Recorder.Finish(m.Game)
+	if err != nil {
+		m.Game.SetStatusMessage("Failed to save replay: " + err.Error())
+		return
+	}
+	if path != "" {
+		m.LastReplayPath = path
+	}
 }
 
 func (m Model) handleEndGameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
@@ -606,11 +791,14 @@ func (m Model) handleEndGameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint
 		// Restart with same level and settings
 		if m.SelectedLevel != nil {
 			m.Game = engine.NewGameFromLevelAndSettings(m.SelectedLevel, m.Settings)
+			m.subscribeGame()
 			m.LastUpdate = time.Now()
-			if m.ChallengeSelector != nil {
-				m.ChallengeSelector.Reset()
-			}
+			m.resetChallengeSelector()
+			m.announceChallengeCharges()
+			m.startRecording()
 		}
+	case "e":
+		m.exportReport()
 	case "m":
 		// Return to menu
 		m.Game.State = engine.StateLevelSelect
@@ -626,29 +814,45 @@ func (m Model) handlePlayingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint
 	switch msg.String() {
 	// Movement (vim keys)
 	case "h", "left":
-		m.Game.MoveCursor(-1, 0)
+		m.act(engine.Action{Kind: engine.ActionMoveCursor, DX: -1})
 	case "j", "down":
-		m.Game.MoveCursor(0, 1)
+		m.act(engine.Action{Kind: engine.ActionMoveCursor, DY: 1})
 	case "k", "up":
-		m.Game.MoveCursor(0, -1)
+		m.act(engine.Action{Kind: engine.ActionMoveCursor, DY: -1})
 	case "l", "right":
-		m.Game.MoveCursor(1, 0)
+		m.act(engine.Action{Kind: engine.ActionMoveCursor, DX: 1})
 
 	// Tower selection
 	case "1":
-		m.Game.SelectTower(entities.TowerArrow)
+		m.act(engine.Action{Kind: engine.ActionSelectTower, Tower: entities.TowerArrow})
 	case "2":
-		m.Game.SelectTower(entities.TowerLSP)
+		m.act(engine.Action{Kind: engine.ActionSelectTower, Tower: entities.TowerLSP})
 	case "3":
-		m.Game.SelectTower(entities.TowerRefactor)
+		m.act(engine.Action{Kind: engine.ActionSelectTower, Tower: entities.TowerRefactor})
 
 	// Actions
 	case " ", "enter":
-		m.Game.PlaceTower()
+		m.act(engine.Action{Kind: engine.ActionPlaceTower, X: m.Game.CursorX, Y: m.Game.CursorY})
 	case "u":
-		m.Game.UpgradeTower()
+		if tower := m.Game.GetTowerAt(m.Game.CursorX, m.Game.CursorY); tower != nil && tower.NeedsBranch() {
+			m.BranchPrompt = true
+		} else {
+			m.act(engine.Action{Kind: engine.ActionUpgradeTower, X: m.Game.CursorX, Y: m.Game.CursorY})
+		}
 	case "p":
-		m.Game.TogglePause()
+		m.act(engine.Action{Kind: engine.ActionTogglePause})
+	case "n":
+		if m.Game.Building {
+			m.act(engine.Action{Kind: engine.ActionStartWave})
+		} else {
+			m.act(engine.Action{Kind: engine.ActionCallWave})
+		}
+	case "x":
+		m.act(engine.Action{Kind: engine.ActionSellTower, X: m.Game.CursorX, Y: m.Game.CursorY})
+	case "U":
+		m.act(engine.Action{Kind: engine.ActionUndo})
+	case "ctrl+r":
+		m.act(engine.Action{Kind: engine.ActionRedo})
 
 	// Challenge
 	case "c":
@@ -656,6 +860,7 @@ func (m Model) handlePlayingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint
 
 	// Quit to start screen
 	case "q":
+		m.finishRecording()
 		m.Game.State = engine.StateLevelSelect
 		m.SettingsMenuIndex = 0
 	}
@@ -663,84 +868,144 @@ func (m Model) handlePlayingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint
 	return m, nil
 }
 
-// startChallenge starts a new challenge.
+// handleBranchKeys picks the specialisation of the tower at the cursor:
+// the number keys choose a branch, anything else cancels.
+func (m Model) handleBranchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
+	m.BranchPrompt = false
+	tower := m.Game.GetTowerAt(m.Game.CursorX, m.Game.CursorY)
+	if tower == nil {
+		return m, nil
+	}
+	branches := tower.Info().Branches
+	if i, err := strconv.Atoi(msg.String()); err == nil && i >= 1 && i <= len(branches) {
+		m.act(engine.Action{
+			Kind:   engine.ActionUpgradeTower,
+			X:      m.Game.CursorX,
+			Y:      m.Game.CursorY,
+			Branch: branches[i-1].Name,
+		})
+	}
+	return m, nil
+}
+
+// startChallenge starts a new challenge. On a tower with several challenge
+// categories it lets the player choose one first.
 func (m *Model) startChallenge() {
-	if m.Game.ChallengeActive {
+	if !m.Game.CanStartChallenge() || m.ChallengeSelector == nil {
 		return
 	}
 
-	// Get tower category for challenge selection
-	tower := m.Game.GetTowerAt(m.Game.CursorX, m.Game.CursorY)
-	var category string
-	if tower != nil {
-		info := tower.Info()
-		category = info.Category
+	if m.Game.GetTowerAt(m.Game.CursorX, m.Game.CursorY) != nil {
+		m.CategoryChoices = m.ChallengeSelector.Choices(cursorCategories(m.Game, m.ChallengeSelector), m.challengeDifficulty())
+		return
 	}
+	m.beginChallenge(m.ChallengeSelector.NextCategory(cursorCategories(m.Game, m.ChallengeSelector), m.challengeDifficulty()))
+}
 
-	// In Neovim mode, delegate to Neovim via RPC
+// cursorCategories returns the challenge categories of the tower at the
+// cursor, or every category off a tower.
+func cursorCategories(g *engine.Game, cs *engine.ChallengeSelector) []string {
+	if tower := g.GetTowerAt(g.CursorX, g.CursorY); tower != nil {
+		return tower.Info().Categories
+	}
+	return cs.Categories()
+}
+
+// challengePreview returns the category the next challenge at the cursor
+// comes from if the player leaves the choice to the game, with what it pays.
+func challengePreview(g *engine.Game, cs *engine.ChallengeSelector, maxDifficulty int) (engine.CategoryChoice, bool) {
+	if cs == nil {
+		return engine.CategoryChoice{}, false
+	}
+	category := cs.NextCategory(cursorCategories(g, cs), maxDifficulty)
+	if category == "" {
+		return engine.CategoryChoice{}, false
+	}
+	return cs.Choices([]string{category}, maxDifficulty)[0], true
+}
+
+// announceChallengeCharges tells Neovim the challenge charges a new game
+// starts with; changes after that are sent as they happen.
+func (m *Model) announceChallengeCharges() {
 	if m.NvimMode && m.NvimRPC != nil {
-		m.NvimChallengeCount++
-		m.NvimChallengeID = fmt.Sprintf("challenge_%d", m.NvimChallengeCount)
+		sendChallengeAvailable(m.NvimRPC, m.Game, m.ChallengeSelector)
+	}
+}
 
-		// Use selector to pick challenge (with variety and anti-repetition)
-		difficulty := 1
-		if m.Game.Wave >= 4 {
-			difficulty = 2
-		}
-		if m.Game.Wave >= 7 {
-			difficulty = 3
+// sendChallengeAvailable tells Neovim how many challenges the player can
+// start and what the next one would be.
+func sendChallengeAvailable(rpc nvim.RPCClient, g *engine.Game, cs *engine.ChallengeSelector) {
+	preview, _ := challengePreview(g, cs, nvimChallengeDifficulty(g.Wave))
+	_ = rpc.SendChallengeAvailable(g.ChallengeCharges, preview.Reward, preview.Category)
+}
+
+// handleCategoryKeys picks the category for the challenge: the number keys
+// choose one, [c] or Enter leaves it to the rotation, anything else cancels.
+func (m Model) handleCategoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
+	choices := m.CategoryChoices
+	m.CategoryChoices = nil
+	key := msg.String()
+	if i, err := strconv.Atoi(key); err == nil && i >= 1 && i <= len(choices) {
+		m.beginChallenge(choices[i-1].Category)
+	} else if key == "c" || key == keyEnter {
+		categories := make([]string, len(choices))
+		for i, c := range choices {
+			categories[i] = c.Category
 		}
+		m.beginChallenge(m.ChallengeSelector.NextCategory(categories, m.challengeDifficulty()))
+	}
+	return m, nil
+}
 
-		// Get challenge from selector
-		var challengeData *nvim.ChallengeData
-		if m.ChallengeSelector != nil {
-			challenge := m.ChallengeSelector.GetChallenge(category, difficulty)
-			if challenge == nil {
-				challenge = m.ChallengeSelector.GetChallenge("", 0)
-			}
-			if challenge != nil {
-				challengeData = &nvim.ChallengeData{
-					ID:              challenge.ID,
-					Name:            challenge.Name,
-					Category:        challenge.Category,
-					Difficulty:      challenge.Difficulty,
-					Description:     challenge.Description,
-					InitialBuffer:   challenge.InitialBuffer,
-					ExpectedBuffer:  challenge.ExpectedBuffer,
-					ValidationType:  challenge.ValidationType,
-					ExpectedCursor:  challenge.ExpectedCursor,
-					ExpectedContent: challenge.ExpectedContent,
-					FunctionName:    challenge.FunctionName,
-					CursorStart:     challenge.CursorStart,
-					ParKeystrokes:   challenge.ParKeystrokes,
-					GoldBase:        challenge.GoldBase,
-					Filetype:        challenge.Filetype,
-					HintAction:      challenge.HintAction,
-					HintFallback:    challenge.HintFallback,
-				}
-			}
+// challengeDifficulty returns the highest difficulty to draw challenges from,
+// 0 for any.
+func (m *Model) challengeDifficulty() int {
+	if !m.NvimMode || m.NvimRPC == nil {
+		return m.Game.Wave
+	}
+	return nvimChallengeDifficulty(m.Game.Wave)
+}
+
+// nvimChallengeDifficulty returns the highest difficulty of the challenges
+// sent to Neovim in a wave.
+func nvimChallengeDifficulty(wave int) int {
+	switch {
+	case wave >= 7:
+		return 3
+	case wave >= 4:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// beginChallenge starts a challenge from category, or from any category if
+// it is empty or has no challenges.
+func (m *Model) beginChallenge(category string) {
+	// In Neovim mode, delegate to Neovim via RPC
+	if m.NvimMode && m.NvimRPC != nil {
+		// Get challenge from selector (with variety and anti-repetition). The
+		// game can only judge challenges it picked, so without one none starts.
+		challenge := m.selectChallenge(category)
+		if challenge == nil {
+			return
 		}
+		m.NvimChallengeCount++
+		m.NvimChallengeID = fmt.Sprintf("challenge_%d", m.NvimChallengeCount)
+		m.registerNvimChallenge(challenge)
 
-		if err := m.NvimRPC.RequestChallenge(m.NvimChallengeID, challengeData); err != nil {
+		if err := m.NvimRPC.RequestChallenge(m.NvimChallengeID, buildChallengeData(challenge, "")); err != nil {
 			// Failed to request challenge, don't enter waiting state
-			m.NvimChallengeID = ""
+			m.forgetNvimChallenge()
 			return
 		}
-		m.Game.StartChallenge() // Game continues during challenge for time pressure
+		// Game continues during challenge for time pressure
+		m.act(engine.Action{Kind: engine.ActionStartChallenge, ChallengeID: challenge.ID, Category: challenge.Category})
 		return
 	}
 
 	// Standalone mode: use internal vim editor
-	if m.ChallengeSelector == nil {
-		return
-	}
-
-	// Get a challenge using the selector (avoids repetition, ensures variety)
-	challenge := m.ChallengeSelector.GetChallenge(category, m.Game.Wave)
-	if challenge == nil {
-		// Fallback to any challenge
-		challenge = m.ChallengeSelector.GetChallenge("", 0)
-	}
+	challenge := m.selectChallenge(category)
 	if challenge == nil {
 		return
 	}
@@ -749,24 +1014,30 @@ func (m *Model) startChallenge() {
 	m.BufferScroll = 0 // Reset scroll for new challenge
 
 	// Initialize vim editor with challenge buffer
-	m.VimEditor = vim.NewEditor(challenge.InitialBuffer)
+	m.initVimEditor(challenge)
 
-	// Set initial cursor position if specified
-	if len(challenge.CursorStart) == 2 {
-		m.VimEditor.SetCursor(vim.Position{
-			Line: challenge.CursorStart[0],
-			Col:  challenge.CursorStart[1],
-		})
-	}
+	m.act(engine.Action{Kind: engine.ActionStartChallenge, ChallengeID: challenge.ID, Category: challenge.Category})
+}
 
-	m.Game.StartChallenge()
+// selectChallenge picks a challenge from category with the selector, which
+// avoids repetition and ensures variety, falling back to any challenge.
+func (m *Model) selectChallenge(category string) *engine.Challenge {
+	if m.ChallengeSelector == nil {
+		return nil
+	}
+	challenge := m.ChallengeSelector.GetChallenge(category, m.challengeDifficulty())
+	if challenge == nil {
+		challenge = m.ChallengeSelector.GetChallenge("", 0)
+	}
+	return challenge
 }
 
 func (m Model) handlePausedKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
 	switch msg.String() {
 	case "p", " ", "enter":
-		m.Game.TogglePause()
+		m.act(engine.Action{Kind: engine.ActionTogglePause})
 	case "q":
+		m.finishRecording()
 		m.Game.State = engine.StateLevelSelect
 		m.SettingsMenuIndex = 0
 	}
@@ -779,7 +1050,7 @@ func (m Model) handleChallengeWaitingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Only allow cancel via Escape (user canceled in Neovim or wants to cancel here)
 	if msg.String() == "esc" || msg.Type == tea.KeyEscape {
 		m.NvimChallengeID = ""
-		m.Game.EndChallenge()
+		m.act(engine.Action{Kind: engine.ActionEndChallenge})
 	}
 	return m, nil
 }
@@ -865,29 +1136,19 @@ func (m *Model) submitChallenge() {
 		return
 	}
 
-	spec := &vim.ChallengeSpec{
-		ValidationType:  m.CurrentChallenge.ValidationType,
-		ExpectedBuffer:  m.CurrentChallenge.ExpectedBuffer,
-		ExpectedContent: m.CurrentChallenge.ExpectedContent,
-		ExpectedCursor:  m.CurrentChallenge.ExpectedCursor,
-		InitialBuffer:   m.CurrentChallenge.InitialBuffer,
-		ParKeystrokes:   m.CurrentChallenge.ParKeystrokes,
-	}
-
-	result := vim.Validate(m.VimEditor, spec)
+	result := vim.Validate(m.VimEditor, buildChallengeSpec(m.CurrentChallenge))
 
+	end := m.challengeEnd(result.Success)
 	if result.Success {
 		// Calculate gold based on efficiency
-		gold := int(float64(m.CurrentChallenge.GoldBase) * result.Efficiency)
-		if gold < 1 {
-			gold = 1
-		}
-		m.Game.AddChallengeGold(gold)
+		end.Gold = max(int(float64(m.CurrentChallenge.GoldBase)*result.Efficiency), 1)
+		end.Efficiency = result.Efficiency
+		m.ShowNotification(withOptimal(fmt.Sprintf("Success! +%dg", end.Gold), m.optimalAnswer(m.CurrentChallenge)), true)
 	}
 
 	m.VimEditor = nil
 	m.CurrentChallenge = nil
-	m.Game.EndChallenge()
+	m.act(end)
 }
 
 // completeChallenge ends the current challenge.
@@ -896,14 +1157,28 @@ func (m *Model) completeChallenge(success bool) {
 		return
 	}
 
+	end := m.challengeEnd(success)
 	if success {
 		// Award gold based on the challenge
-		m.Game.AddChallengeGold(m.CurrentChallenge.GoldBase)
+		end.Gold = m.CurrentChallenge.GoldBase
 	}
 
 	m.VimEditor = nil
 	m.CurrentChallenge = nil
-	m.Game.EndChallenge()
+	m.act(end)
+}
+
+// challengeEnd builds the action ending the standalone challenge in progress.
+func (m *Model) challengeEnd(success bool) engine.Action {
+	end := engine.Action{
+		Kind:        engine.ActionEndChallenge,
+		ChallengeID: m.CurrentChallenge.ID,
+		Success:     success,
+	}
+	if m.VimEditor != nil && !m.VimEditor.StartedAt.IsZero() {
+		end.Elapsed = time.Since(m.VimEditor.StartedAt).Seconds()
+	}
+	return end
 }
 
 // handleChallengeResult processes a challenge result from Neovim RPC.
@@ -913,13 +1188,18 @@ func (m *Model) handleChallengeResult(result *nvim.ChallengeResult) {
 		return // Stale result, ignore
 	}
 
-	// Award gold if successful
+	result, optimal := m.resolveVerdict(result)
+
+	// Award gold if successful; tower defense awards it through act so the
+	// replay records it
+	towerDefense := !strings.HasPrefix(result.RequestID, "challenge_mode_") &&
+		!strings.HasPrefix(result.RequestID, "challenge_selection_")
+	gold := 0
 	if result.Success {
-		gold := result.GoldEarned
-		if gold < 1 {
-			gold = 1
+		gold = max(result.GoldEarned, 1)
+		if !towerDefense {
+			m.Game.AddChallengeGold(gold)
 		}
-		m.Game.AddChallengeGold(gold)
 	}
 
 	// Handle based on mode (determined by challenge ID prefix)
@@ -939,7 +1219,7 @@ func (m *Model) handleChallengeResult(result *nvim.ChallengeResult) {
 		// Challenge Mode: update streak and start next challenge
 		if result.Success {
 			m.ChallengeModeStreak++
-			m.ShowNotification("Success!", true)
+			m.ShowNotification(withOptimal("Success!", optimal), true)
 		} else {
 			m.ChallengeModeStreak = 0
 			m.ShowNotification("Try again!", false)
@@ -964,7 +1244,7 @@ func (m *Model) handleChallengeResult(result *nvim.ChallengeResult) {
 		}
 		// Challenge Selection: show result and start next challenge
 		if result.Success {
-			m.ShowNotification("Success!", true)
+			m.ShowNotification(withOptimal("Success!", optimal), true)
 		} else {
 			m.ShowNotification("Try again!", false)
 		}
@@ -984,8 +1264,69 @@ func (m *Model) handleChallengeResult(result *nvim.ChallengeResult) {
 		m.startChallengeSelectionChallenge()
 	} else {
 		// Tower defense mode: return to playing
-		m.Game.EndChallenge()
+		if result.Success {
+			m.ShowNotification(withOptimal(fmt.Sprintf("Success! +%dg", gold), optimal), true)
+		}
+		m.act(engine.Action{
+			Kind:       engine.ActionEndChallenge,
+			Success:    result.Success,
+			Gold:       gold,
+			Efficiency: result.Efficiency,
+			SpeedBonus: result.SpeedBonus,
+			Elapsed:    float64(result.TimeMs) / 1000,
+		})
+	}
+}
+
+// resolveVerdict judges a result by the verifier's verdict: challenges are
+// judged by the game, not the client, so one the verifier never issued fails.
+// It also returns the optimal answer for a success, if known.
+func (m *Model) resolveVerdict(result *nvim.ChallengeResult) (*nvim.ChallengeResult, string) {
+	var verdict *nvim.ValidateChallengeResult
+	if m.ChallengeVerifier != nil {
+		verdict, _ = m.ChallengeVerifier.Resolve(result.RequestID)
+	}
+	result = applyVerdict(result, verdict)
+	if !result.Success {
+		return result, ""
 	}
+	return result, verdict.Optimal
+}
+
+// applyVerdict overrides the client-reported outcome with the game's verdict.
+// A challenge that was never validated earns nothing.
+func applyVerdict(result *nvim.ChallengeResult, verdict *nvim.ValidateChallengeResult) *nvim.ChallengeResult {
+	judged := *result
+	judged.Success = false
+	judged.Efficiency = 0
+	judged.SpeedBonus = 0
+	judged.GoldEarned = 0
+	if verdict != nil && verdict.Success && !result.Skipped {
+		judged.Success = true
+		judged.Efficiency = verdict.Efficiency
+		judged.SpeedBonus = verdict.SpeedBonus
+		judged.GoldEarned = verdict.GoldEarned
+	}
+	return &judged
+}
+
+// registerNvimChallenge records the challenge sent under the current request ID
+// so its submission can be validated on the game side.
+func (m *Model) registerNvimChallenge(challenge *engine.Challenge) {
+	if m.ChallengeVerifier != nil {
+		m.ChallengeVerifier.Register(m.NvimChallengeID, challenge, m.Game.Economy)
+	}
+	if m.OptimalSolutions != nil {
+		m.OptimalSolutions.Prepare(challenge)
+	}
+}
+
+// forgetNvimChallenge clears the current request ID and its registration.
+func (m *Model) forgetNvimChallenge() {
+	if m.ChallengeVerifier != nil {
+		m.ChallengeVerifier.Forget(m.NvimChallengeID)
+	}
+	m.NvimChallengeID = ""
 }
 
 // buildChallengeData creates a ChallengeData struct from a Challenge.
@@ -1002,25 +1343,136 @@ func buildChallengeData(challenge *engine.Challenge, mode string) *nvim.Challeng
 		ExpectedCursor:  challenge.ExpectedCursor,
 		ExpectedContent: challenge.ExpectedContent,
 		FunctionName:    challenge.FunctionName,
+		Pattern:         challenge.Pattern,
+		Signature:       challenge.Signature,
+		OldName:         challenge.OldName,
+		NewName:         challenge.NewName,
+		Checks:          buildChallengeChecks(challenge.Checks),
 		CursorStart:     challenge.CursorStart,
 		ParKeystrokes:   challenge.ParKeystrokes,
 		GoldBase:        challenge.GoldBase,
 		Filetype:        challenge.Filetype,
 		HintAction:      challenge.HintAction,
 		HintFallback:    challenge.HintFallback,
+		ForbiddenKeys:   challenge.ForbiddenKeys,
+		MaxKeystrokes:   challenge.MaxKeystrokes,
+		TimeLimit:       challenge.TimeLimit,
 		Mode:            mode,
 	}
 }
 
+// buildChallengeChecks converts composite sub-checks for the RPC payload.
+func buildChallengeChecks(checks []engine.ChallengeCheck) []nvim.ChallengeCheck {
+	if len(checks) == 0 {
+		return nil
+	}
+	out := make([]nvim.ChallengeCheck, len(checks))
+	for i, c := range checks {
+		out[i] = nvim.ChallengeCheck{
+			ValidationType:  c.ValidationType,
+			ExpectedBuffer:  c.ExpectedBuffer,
+			ExpectedCursor:  c.ExpectedCursor,
+			ExpectedContent: c.ExpectedContent,
+			FunctionName:    c.FunctionName,
+			Pattern:         c.Pattern,
+			Signature:       c.Signature,
+			OldName:         c.OldName,
+			NewName:         c.NewName,
+			Checks:          buildChallengeChecks(c.Checks),
+		}
+	}
+	return out
+}
+
+// buildChallengeSpec creates the validation spec for a challenge.
+// All validation paths (standalone and Neovim) go through this conversion.
+func buildChallengeSpec(challenge *engine.Challenge) *vim.ChallengeSpec {
+	return &vim.ChallengeSpec{
+		ValidationType:  challenge.ValidationType,
+		ExpectedBuffer:  challenge.ExpectedBuffer,
+		ExpectedContent: challenge.ExpectedContent,
+		ExpectedCursor:  challenge.ExpectedCursor,
+		Pattern:         challenge.Pattern,
+		FunctionName:    challenge.FunctionName,
+		InitialBuffer:   challenge.InitialBuffer,
+		ParKeystrokes:   challenge.ParKeystrokes,
+		Filetype:        challenge.Filetype,
+		Signature:       challenge.Signature,
+		OldName:         challenge.OldName,
+		NewName:         challenge.NewName,
+		Restrictions:    buildRestrictions(challenge),
+		Checks:          buildCheckSpecs(challenge.Checks),
+	}
+}
+
+// buildRestrictions converts a challenge's golf rules for the vim editor.
+func buildRestrictions(challenge *engine.Challenge) vim.Restrictions {
+	return vim.Restrictions{
+		ForbiddenKeys: challenge.ForbiddenKeys,
+		MaxKeystrokes: challenge.MaxKeystrokes,
+		TimeLimit:     time.Duration(challenge.TimeLimit) * time.Second,
+	}
+}
+
+// buildCheckSpecs converts composite sub-checks into validation specs.
+func buildCheckSpecs(checks []engine.ChallengeCheck) []vim.ChallengeSpec {
+	if len(checks) == 0 {
+		return nil
+	}
+	specs := make([]vim.ChallengeSpec, len(checks))
+	for i, c := range checks {
+		specs[i] = vim.ChallengeSpec{
+			ValidationType:  c.ValidationType,
+			ExpectedBuffer:  c.ExpectedBuffer,
+			ExpectedContent: c.ExpectedContent,
+			ExpectedCursor:  c.ExpectedCursor,
+			Pattern:         c.Pattern,
+			FunctionName:    c.FunctionName,
+			Signature:       c.Signature,
+			OldName:         c.OldName,
+			NewName:         c.NewName,
+			Checks:          buildCheckSpecs(c.Checks),
+		}
+	}
+	return specs
+}
+
 // initVimEditor initializes the vim editor with a challenge buffer.
 func (m *Model) initVimEditor(challenge *engine.Challenge) {
-	m.VimEditor = vim.NewEditor(challenge.InitialBuffer)
+	m.VimEditor = newChallengeEditor(challenge)
+	m.VimEditor.Restrict(buildRestrictions(challenge))
+	if m.OptimalSolutions != nil {
+		m.OptimalSolutions.Prepare(challenge)
+	}
+}
+
+// optimalAnswer returns the solved optimal keys for a challenge, or "" if unknown.
+func (m *Model) optimalAnswer(challenge *engine.Challenge) string {
+	if m.OptimalSolutions == nil || challenge == nil {
+		return ""
+	}
+	keys, _ := m.OptimalSolutions.Lookup(challenge.ID)
+	return keys
+}
+
+// withOptimal appends the optimal answer to a result message.
+func withOptimal(message, optimal string) string {
+	if optimal == "" {
+		return message
+	}
+	return message + " Optimal: " + optimal
+}
+
+// newChallengeEditor creates an editor with the challenge's initial buffer and cursor.
+func newChallengeEditor(challenge *engine.Challenge) *vim.Editor {
+	e := vim.NewEditor(challenge.InitialBuffer)
 	if len(challenge.CursorStart) == 2 {
-		m.VimEditor.SetCursor(vim.Position{
+		e.SetCursor(vim.Position{
 			Line: challenge.CursorStart[0],
 			Col:  challenge.CursorStart[1],
 		})
 	}
+	return e
 }
 
 // startChallengeModeChallenge starts a random challenge for challenge mode.
@@ -1045,6 +1497,7 @@ func (m *Model) startChallengeModeChallenge() {
 		m.NvimChallengeID = fmt.Sprintf("challenge_mode_%d", m.NvimChallengeCount)
 
 		challengeData := buildChallengeData(challenge, "challenge_mode")
+		m.registerNvimChallenge(challenge)
 		// Include feedback from previous challenge if available
 		if m.PendingFeedback != nil {
 			challengeData.PrevSuccess = &m.PendingFeedback.Success
@@ -1053,7 +1506,7 @@ func (m *Model) startChallengeModeChallenge() {
 			m.PendingFeedback = nil // Clear after using
 		}
 		if err := m.NvimRPC.RequestChallenge(m.NvimChallengeID, challengeData); err != nil {
-			m.NvimChallengeID = ""
+			m.forgetNvimChallenge()
 			m.CurrentChallenge = nil
 			m.Game.State = engine.StateChallengeMode
 			return
@@ -1083,6 +1536,7 @@ func (m *Model) startChallengeSelectionChallenge() {
 		m.NvimChallengeID = fmt.Sprintf("challenge_selection_%d", m.NvimChallengeCount)
 
 		challengeData := buildChallengeData(challenge, "challenge_selection")
+		m.registerNvimChallenge(challenge)
 		// Include feedback from previous challenge if available
 		if m.PendingFeedback != nil {
 			challengeData.PrevSuccess = &m.PendingFeedback.Success
@@ -1090,7 +1544,7 @@ func (m *Model) startChallengeSelectionChallenge() {
 			m.PendingFeedback = nil // Clear after using
 		}
 		if err := m.NvimRPC.RequestChallenge(m.NvimChallengeID, challengeData); err != nil {
-			m.NvimChallengeID = ""
+			m.forgetNvimChallenge()
 			m.CurrentChallenge = nil
 			m.Game.State = engine.StateChallengeSelection
 			return
@@ -1162,20 +1616,11 @@ func (m *Model) submitChallengeModeChallenge() {
 		return
 	}
 
-	spec := &vim.ChallengeSpec{
-		ValidationType:  m.CurrentChallenge.ValidationType,
-		ExpectedBuffer:  m.CurrentChallenge.ExpectedBuffer,
-		ExpectedContent: m.CurrentChallenge.ExpectedContent,
-		ExpectedCursor:  m.CurrentChallenge.ExpectedCursor,
-		InitialBuffer:   m.CurrentChallenge.InitialBuffer,
-		ParKeystrokes:   m.CurrentChallenge.ParKeystrokes,
-	}
-
-	result := vim.Validate(m.VimEditor, spec)
+	result := vim.Validate(m.VimEditor, buildChallengeSpec(m.CurrentChallenge))
 
 	if result.Success {
 		m.ChallengeModeStreak++
-		m.ShowNotification("Success!", true)
+		m.ShowNotification(withOptimal("Success!", m.optimalAnswer(m.CurrentChallenge)), true)
 	} else {
 		m.ChallengeModeStreak = 0
 		m.ShowNotification("Try again!", false)
@@ -1273,19 +1718,10 @@ func (m *Model) submitChallengeSelectionChallenge() {
 		return
 	}
 
-	spec := &vim.ChallengeSpec{
-		ValidationType:  m.CurrentChallenge.ValidationType,
-		ExpectedBuffer:  m.CurrentChallenge.ExpectedBuffer,
-		ExpectedContent: m.CurrentChallenge.ExpectedContent,
-		ExpectedCursor:  m.CurrentChallenge.ExpectedCursor,
-		InitialBuffer:   m.CurrentChallenge.InitialBuffer,
-		ParKeystrokes:   m.CurrentChallenge.ParKeystrokes,
-	}
-
-	result := vim.Validate(m.VimEditor, spec)
+	result := vim.Validate(m.VimEditor, buildChallengeSpec(m.CurrentChallenge))
 
 	if result.Success {
-		m.ShowNotification("Success!", true)
+		m.ShowNotification(withOptimal("Success!", m.optimalAnswer(m.CurrentChallenge)), true)
 	} else {
 		m.ShowNotification("Try again!", false)
 	}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/keyforge/keyforge/internal/engine"
 	"github.com/keyforge/keyforge/internal/nvim"
+	"github.com/keyforge/keyforge/internal/vim"
 )
 
 // newTestModel creates a model ready for testing with game in playing state.
@@ -22,9 +24,64 @@ func newTestModel() Model {
 	return model
 }
 
+// passNvimChallenge has the verifier issue a challenge under requestID and
+// judge a correct answer to it, as the plugin does before reporting the
+// result. It returns the gold the verdict awards.
+func passNvimChallenge(t *testing.T, m *Model, requestID string) int {
+	t.Helper()
+	m.ChallengeVerifier.Register(requestID, testVerifierChallenge(), m.Game.Economy)
+	verdict := m.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID:      requestID,
+		Buffer:         "hello world",
+		KeystrokeCount: 4,
+	})
+	if verdict == nil || !verdict.Success {
+		t.Fatalf("Expected the verifier to pass the answer, got %+v", verdict)
+	}
+	return verdict.GoldEarned
+}
+
+// buildSpot returns the first cell of the model's level a tower can go on.
+func buildSpot(t *testing.T, m *Model) (int, int) {
+	t.Helper()
+	for y := range m.Game.Height {
+		for x := range m.Game.Width {
+			if m.Game.CanPlaceTower(x, y) {
+				return x, y
+			}
+		}
+	}
+	t.Fatal("No free cell on the level")
+	return 0, 0
+}
+
+// pressKeys are the special keys press sends by name.
+var pressKeys = map[string]tea.KeyType{
+	"enter":  tea.KeyEnter,
+	"esc":    tea.KeyEsc,
+	"ctrl+r": tea.KeyCtrlR,
+}
+
+// press sends one key to the model: a rune, " " or a special key name such
+// as "enter".
+func press(t *testing.T, m Model, key string) Model {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == " " {
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+	} else if keyType, ok := pressKeys[key]; ok {
+		msg = tea.KeyMsg{Type: keyType}
+	}
+	updated, _ := m.Update(msg)
+	return updated.(Model)
+}
+
 // MockRPCClient implements nvim.RPCClient for testing.
 type MockRPCClient struct {
 	ChallengeRequests []ChallengeRequestRecord
+	GoldUpdates       []nvim.GoldUpdate
+	BossPhases        []nvim.BossPhaseParams
+	Available         []nvim.ChallengeAvailable
 }
 
 type ChallengeRequestRecord struct {
@@ -49,10 +106,21 @@ func (m *MockRPCClient) SendGameReady() error {
 }
 
 func (m *MockRPCClient) SendGoldUpdate(gold, earned int, source string, speedBonus float64) error {
+	m.GoldUpdates = append(m.GoldUpdates, nvim.GoldUpdate{
+		Gold:       gold,
+		Earned:     earned,
+		Source:     source,
+		SpeedBonus: speedBonus,
+	})
 	return nil
 }
 
 func (m *MockRPCClient) SendChallengeAvailable(count, nextReward int, nextCategory string) error {
+	m.Available = append(m.Available, nvim.ChallengeAvailable{
+		Count:        count,
+		NextReward:   nextReward,
+		NextCategory: nextCategory,
+	})
 	return nil
 }
 
@@ -64,6 +132,17 @@ func (m *MockRPCClient) SendVictory(wave, gold, towers, health int) error {
 	return nil
 }
 
+func (m *MockRPCClient) SendBossPhase(kind string, phase, health, maxHealth int, shielded bool) error {
+	m.BossPhases = append(m.BossPhases, nvim.BossPhaseParams{
+		Kind:      kind,
+		Phase:     phase,
+		Health:    health,
+		MaxHealth: maxHealth,
+		Shielded:  shielded,
+	})
+	return nil
+}
+
 // TestChallengeResultChannel tests that challenge results are properly
 // communicated via channel even when Model is copied (as Bubbletea does).
 func TestChallengeResultChannel(t *testing.T) {
@@ -209,6 +288,7 @@ func TestChallengeResultGoldAwarded(t *testing.T) {
 
 	model.NvimChallengeID = "challenge_1"
 	model.Game.StartChallengeWaiting()
+	gold := passNvimChallenge(t, &model, "challenge_1")
 
 	initialGold := model.Game.Gold
 
@@ -224,7 +304,7 @@ func TestChallengeResultGoldAwarded(t *testing.T) {
 	newModel, _ := model.Update(tickMsg)
 	updatedModel := newModel.(Model)
 
-	expectedGold := initialGold + 42
+	expectedGold := initialGold + gold
 	if updatedModel.Game.Gold != expectedGold {
 		t.Errorf("Expected gold %d, got %d", expectedGold, updatedModel.Game.Gold)
 	}
@@ -264,6 +344,38 @@ func TestChallengeResultNoGoldOnFailure(t *testing.T) {
 	}
 }
 
+// TestUnverifiedChallengeResultFails tests that the game doesn't trust a
+// client's success for a challenge its verifier never issued.
+func TestUnverifiedChallengeResultFails(t *testing.T) {
+	model := newTestModel()
+	model.NvimMode = true
+	model.NvimRPC = &MockRPCClient{}
+	model.NvimChallengeID = "challenge_1"
+	model.Game.StartChallengeWaiting()
+	initialGold := model.Game.Gold
+
+	model.handleChallengeResult(&nvim.ChallengeResult{RequestID: "challenge_1", Success: true, GoldEarned: 500})
+	if model.Game.Gold != initialGold || model.Game.State != engine.StatePlaying {
+		t.Errorf("Expected no gold and play to resume, gold %d -> %d, state %v", initialGold, model.Game.Gold, model.Game.State)
+	}
+}
+
+// TestNvimChallengeNeedsAChallenge tests that no Neovim challenge starts when
+// the game has none to issue, since the client's verdict would go unchecked.
+func TestNvimChallengeNeedsAChallenge(t *testing.T) {
+	model := newTestModel()
+	rpc := &MockRPCClient{}
+	model.NvimMode = true
+	model.NvimRPC = rpc
+	model.ChallengeSelector = nil
+
+	model.beginChallenge("")
+	if len(rpc.ChallengeRequests) != 0 || model.NvimChallengeID != "" || model.Game.State != engine.StatePlaying {
+		t.Errorf("Expected no challenge, got %d requests, ID %q, state %v",
+			len(rpc.ChallengeRequests), model.NvimChallengeID, model.Game.State)
+	}
+}
+
 // TestHandleChallengeCompleteViaPointer simulates the real scenario:
 // - Original model is passed to socket server as pointer
 // - Bubbletea works with value copies
@@ -325,6 +437,7 @@ func TestMultipleTicksDoNotDuplicateProcessing(t *testing.T) {
 
 	model.NvimChallengeID = "challenge_1"
 	model.Game.StartChallengeWaiting()
+	gold := passNvimChallenge(t, &model, "challenge_1")
 
 	initialGold := model.Game.Gold
 
@@ -341,8 +454,8 @@ func TestMultipleTicksDoNotDuplicateProcessing(t *testing.T) {
 	newModel, _ := model.Update(tickMsg)
 	updatedModel := newModel.(Model)
 
-	if updatedModel.Game.Gold != initialGold+25 {
-		t.Fatalf("First tick should add gold, expected %d, got %d", initialGold+25, updatedModel.Game.Gold)
+	if updatedModel.Game.Gold != initialGold+gold {
+		t.Fatalf("First tick should add gold, expected %d, got %d", initialGold+gold, updatedModel.Game.Gold)
 	}
 
 	// Second tick should not add more gold (channel is empty)
@@ -350,8 +463,8 @@ func TestMultipleTicksDoNotDuplicateProcessing(t *testing.T) {
 	newModel2, _ := updatedModel.Update(tickMsg2)
 	finalModel := newModel2.(Model)
 
-	if finalModel.Game.Gold != initialGold+25 {
-		t.Errorf("Second tick should not add more gold, expected %d, got %d", initialGold+25, finalModel.Game.Gold)
+	if finalModel.Game.Gold != initialGold+gold {
+		t.Errorf("Second tick should not add more gold, expected %d, got %d", initialGold+gold, finalModel.Game.Gold)
 	}
 }
 
@@ -866,6 +979,7 @@ func TestIntegrationWithRealSocketServer(t *testing.T) {
 	btModel.NvimChallengeCount++
 	btModel.NvimChallengeID = "int_test_1"
 	btModel.Game.StartChallenge() // Game continues during challenge for time pressure
+	gold := passNvimChallenge(t, &btModel, "int_test_1")
 
 	t.Logf("Started challenge, state=%v, id=%s", btModel.Game.State, btModel.NvimChallengeID)
 
@@ -905,7 +1019,7 @@ func TestIntegrationWithRealSocketServer(t *testing.T) {
 		t.Errorf("Expected StatePlaying after challenge complete, got %v", finalModel.Game.State)
 	}
 
-	expectedGold := initialGold + 88
+	expectedGold := initialGold + gold
 	if finalModel.Game.Gold != expectedGold {
 		t.Errorf("Expected gold %d, got %d", expectedGold, finalModel.Game.Gold)
 	}
@@ -1202,8 +1316,8 @@ func TestStartMenuSectionNavigation(t *testing.T) {
 
 	levels := model.LevelRegistry.GetAll()
 
-	// Navigate to last level
-	model.LevelMenuIndex = len(levels) - 1
+	// Navigate to the Random Level entry after the last level
+	model.LevelMenuIndex = len(levels)
 
 	// Navigate down should move to modes section
 	msgDown := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
@@ -1225,8 +1339,8 @@ func TestStartMenuSectionNavigation(t *testing.T) {
 	if finalModel.StartSection != SectionLevels {
 		t.Errorf("Expected SectionLevels after navigating up from modes, got %v", finalModel.StartSection)
 	}
-	if finalModel.LevelMenuIndex != len(levels)-1 {
-		t.Errorf("Expected LevelMenuIndex %d, got %d", len(levels)-1, finalModel.LevelMenuIndex)
+	if finalModel.LevelMenuIndex != len(levels) {
+		t.Errorf("Expected LevelMenuIndex %d, got %d", len(levels), finalModel.LevelMenuIndex)
 	}
 }
 
@@ -1295,6 +1409,149 @@ func TestBuildChallengeData(t *testing.T) {
 	}
 }
 
+func TestBuildChallengeSpec(t *testing.T) {
+	challenge := &engine.Challenge{
+		ValidationType: "all_of",
+		InitialBuffer:  "before",
+		FunctionName:   "main",
+		Pattern:        `^after`,
+		ParKeystrokes:  3,
+		Checks: []engine.ChallengeCheck{
+			{ValidationType: "pattern", Pattern: `^after`},
+			{ValidationType: "any_of", Checks: []engine.ChallengeCheck{
+				{ValidationType: "cursor_position", ExpectedCursor: []int{0, 0}},
+			}},
+		},
+	}
+
+	spec := buildChallengeSpec(challenge)
+
+	if spec.Pattern != `^after` || spec.FunctionName != "main" {
+		t.Errorf("Expected pattern and function name to be copied, got %+v", spec)
+	}
+	if len(spec.Checks) != 2 {
+		t.Fatalf("Expected 2 checks, got %d", len(spec.Checks))
+	}
+	if spec.Checks[0].Pattern != `^after` {
+		t.Errorf("Expected check pattern to be copied, got '%s'", spec.Checks[0].Pattern)
+	}
+	if len(spec.Checks[1].Checks) != 1 || spec.Checks[1].Checks[0].ExpectedCursor[1] != 0 {
+		t.Errorf("Expected nested checks to be copied, got %+v", spec.Checks[1])
+	}
+
+	data := buildChallengeData(challenge, "")
+	if data.Pattern != `^after` || len(data.Checks) != 2 || len(data.Checks[1].Checks) != 1 {
+		t.Errorf("Expected pattern and checks in challenge data, got %+v", data)
+	}
+}
+
+// TestChallengeFileValidationTypes tests that every challenge in the challenge
+// file, sub-checks included, uses a validation type the validator knows and
+// only patterns that compile.
+func TestChallengeFileValidationTypes(t *testing.T) {
+	cm, err := engine.NewChallengeManager()
+	if err != nil {
+		t.Fatalf("Failed to load challenges: %v", err)
+	}
+	challenges := cm.GetAllChallenges()
+	if len(challenges) == 0 {
+		t.Fatal("Expected challenges to be loaded")
+	}
+
+	var checkSpec func(id string, spec vim.ChallengeSpec)
+	checkSpec = func(id string, spec vim.ChallengeSpec) {
+		if spec.ValidationType == "all_of" || spec.ValidationType == "any_of" {
+			for _, sub := range spec.Checks {
+				checkSpec(id, sub)
+			}
+			return
+		}
+		// Validate each leaf on its own so a composite can't skip over it
+		spec.Restrictions = vim.Restrictions{}
+		result := vim.Validate(vim.NewEditor(spec.InitialBuffer), &spec)
+		if strings.HasPrefix(result.Message, "Unknown validation type") || strings.HasPrefix(result.Message, "Invalid pattern") {
+			t.Errorf("%s: %s", id, result.Message)
+		}
+	}
+	for i := range challenges {
+		checkSpec(challenges[i].ID, *buildChallengeSpec(&challenges[i]))
+	}
+}
+
+// TestStandaloneCompositeChallenge tests that a composite challenge from the
+// challenge file can be solved in the standalone editor.
+func TestStandaloneCompositeChallenge(t *testing.T) {
+	model := newTestModel()
+	challenge := model.ChallengeManager.GetChallenge("refactor_remove_debug_line")
+	if challenge == nil {
+		t.Fatal("Expected refactor_remove_debug_line challenge to exist")
+	}
+
+	model.CurrentChallenge = challenge
+	model.initVimEditor(challenge)
+	model.Game.StartChallenge()
+	model.VimEditor.HandleKey("d")
+	model.VimEditor.HandleKey("d")
+
+	initialGold := model.Game.Gold
+	model.submitChallenge()
+
+	if model.Game.Gold <= initialGold {
+		t.Errorf("Expected gold for solving composite challenge, gold %d -> %d", initialGold, model.Game.Gold)
+	}
+}
+
+// TestGoChallengeData tests that the fields Go validation needs reach the
+// Neovim request, including in composite checks.
+func TestGoChallengeData(t *testing.T) {
+	model := newTestModel()
+	rename := model.ChallengeManager.GetChallenge("refactor_go_rename_identifier")
+	unused := model.ChallengeManager.GetChallenge("refactor_go_remove_unused")
+	if rename == nil || unused == nil {
+		t.Fatal("Expected the Go refactoring challenges to exist")
+	}
+
+	data, err := json.Marshal(nvim.NewChallengeRequest("challenge_1", buildChallengeData(rename, "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"old_name":"n"`) || !strings.Contains(string(data), `"new_name":"count"`) {
+		t.Errorf("Expected old and new names in the request, got %s", data)
+	}
+	checks := buildChallengeData(unused, "").Checks
+	if len(checks) != 3 || checks[2].Signature != "(int) int" {
+		t.Errorf("Expected the signature in the composite checks, got %+v", checks)
+	}
+}
+
+// TestVerifierGoChallenge tests that syntax-aware Go validation runs on the
+// RPC validation path.
+func TestVerifierGoChallenge(t *testing.T) {
+	model := newTestModel()
+	challenge := model.ChallengeManager.GetChallenge("refactor_go_remove_unused")
+	if challenge == nil {
+		t.Fatal("Expected refactor_go_remove_unused challenge to exist")
+	}
+	model.ChallengeVerifier.Register("challenge_1", challenge, model.Game.Economy)
+
+	unchanged := model.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID: "challenge_1",
+		Buffer:    challenge.InitialBuffer,
+	})
+	if unchanged == nil || unchanged.Success {
+		t.Errorf("Expected unused variable to fail validation, got %+v", unchanged)
+	}
+
+	fixed := model.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID:      "challenge_1",
+		Buffer:         "package main\n\nfunc double(x int) int {\n\treturn x * 2\n}\n",
+		KeystrokeCount: 2,
+	})
+	if fixed == nil || !fixed.Success {
+		t.Errorf("Expected fixed code to pass validation, got %+v", fixed)
+	}
+}
+
 // =============================================================================
 // Integration Tests for Full Flows (Task 6.3)
 // =============================================================================
@@ -1403,3 +1660,69 @@ func TestChallengeSelectionFullFlow(t *testing.T) {
 		t.Errorf("Expected StateLevelSelect after exit, got %v", model.Game.State)
 	}
 }
+
+// TestStandaloneGolfChallenge tests that golf restrictions are enforced by the
+// standalone editor and forwarded to Neovim.
+func TestStandaloneGolfChallenge(t *testing.T) {
+	model := newTestModel()
+	challenge := model.ChallengeManager.GetChallenge("movement_golf_no_hjkl")
+	if challenge == nil {
+		t.Fatal("Expected movement_golf_no_hjkl challenge to exist")
+	}
+
+	tests := []struct {
+		name    string
+		keys    []string
+		success bool
+	}{
+		{"allowed keys", []string{"4", "G", "w"}, true},
+		{"forbidden key", []string{"4", "G", "l"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model.CurrentChallenge = challenge
+			model.initVimEditor(challenge)
+			for _, key := range tt.keys {
+				model.VimEditor.HandleKey(key)
+			}
+
+			result := vim.Validate(model.VimEditor, buildChallengeSpec(challenge))
+			if result.Success != tt.success {
+				t.Errorf("Expected success=%v, got %v (%s)", tt.success, result.Success, result.Message)
+			}
+		})
+	}
+
+	data := buildChallengeData(challenge, "")
+	req := nvim.NewChallengeRequest("challenge_1", data)
+	if len(req.ForbiddenKeys) != 8 || req.MaxKeystrokes != 4 || req.TimeLimit != 20 {
+		t.Errorf("Expected golf restrictions in request, got %v/%d/%d", req.ForbiddenKeys, req.MaxKeystrokes, req.TimeLimit)
+	}
+}
+
+// TestGoldUpdatesForwardedToNvim tests that the game's gold events reach
+// Neovim, skipping per-kill gold, spending and the standalone challenge modes.
+func TestGoldUpdatesForwardedToNvim(t *testing.T) {
+	model := NewModel()
+	rpc := &MockRPCClient{}
+	model.NvimMode = true
+	model.NvimRPC = rpc
+	levels := model.LevelRegistry.GetAll()
+	model.SelectedLevel = &levels[0]
+	model.startGameFromSettings()
+
+	model.Game.CursorX, model.Game.CursorY = 0, 0
+	model.Game.PlaceTower()
+	model.Game.AddChallengeGold(10) // As challenge mode awards it
+	model.Game.StartChallenge()
+	model.act(engine.Action{Kind: engine.ActionEndChallenge, Success: true, Gold: 25, SpeedBonus: 1.5})
+
+	if len(rpc.GoldUpdates) != 1 {
+		t.Fatalf("Expected 1 gold update, got %+v", rpc.GoldUpdates)
+	}
+	update := rpc.GoldUpdates[0]
+	if update.Source != "challenge" || update.Earned != 25 || update.SpeedBonus != 1.5 || update.Gold != model.Game.Gold {
+		t.Errorf("Unexpected gold update %+v (gold %d)", update, model.Game.Gold)
+	}
+}
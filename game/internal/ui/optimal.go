@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"sync"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/vim"
+)
+
+// hintSolverStates bounds the background search behind in-game hints, so a
+// hard challenge costs a fraction of a second before it gives up.
+const hintSolverStates = 20000
+
+// Solvable reports whether the solver can model a challenge. Plugin challenges
+// are solved with commands the editor doesn't emulate, and "different" accepts
+// any edit, so neither has a meaningful optimum.
+func Solvable(challenge *engine.Challenge) bool {
+	return challenge.RequiredPlugin == "" && challenge.ValidationType != "different"
+}
+
+// SolveChallenge finds the shortest key sequence for a challenge from its
+// starting buffer and cursor.
+func SolveChallenge(challenge *engine.Challenge, cfg vim.SolverConfig) (*vim.Solution, error) {
+	return vim.Solve(newChallengeEditor(challenge), buildChallengeSpec(challenge), cfg)
+}
+
+// OptimalSolutions solves challenges in the background so the optimal answer
+// can be shown once the player finishes. Like the verifier, it is shared by
+// pointer with the RPC handler and guarded by a mutex.
+type OptimalSolutions struct {
+	mu      sync.Mutex
+	answers map[string]string // Challenge ID -> keys in Vim notation, "" if unsolved
+	pending map[string]bool
+	cfg     vim.SolverConfig
+	solve   func(*engine.Challenge, vim.SolverConfig) (*vim.Solution, error)
+}
+
+// NewOptimalSolutions creates an empty solution cache.
+func NewOptimalSolutions() *OptimalSolutions {
+	cfg := vim.DefaultSolverConfig()
+	cfg.MaxStates = hintSolverStates
+	return &OptimalSolutions{
+		answers: make(map[string]string),
+		pending: make(map[string]bool),
+		cfg:     cfg,
+		solve:   SolveChallenge,
+	}
+}
+
+// Prepare starts solving a challenge unless it is already solved or in progress.
+func (o *OptimalSolutions) Prepare(challenge *engine.Challenge) {
+	if challenge == nil || !Solvable(challenge) {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.answers[challenge.ID]; ok || o.pending[challenge.ID] {
+		return
+	}
+	o.pending[challenge.ID] = true
+	go o.run(challenge)
+}
+
+func (o *OptimalSolutions) run(challenge *engine.Challenge) {
+	answer := ""
+	if solution, err := o.solve(challenge, o.cfg); err == nil {
+		answer = solution.String()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.pending, challenge.ID)
+	o.answers[challenge.ID] = answer
+}
+
+// Lookup returns the optimal keys for a challenge. ok is false while the
+// search is still running or if it found nothing within its bounds.
+func (o *OptimalSolutions) Lookup(challengeID string) (string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	answer := o.answers[challengeID]
+	return answer, answer != ""
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/nvim"
+	"github.com/keyforge/keyforge/internal/vim"
+)
+
+// waitForOptimal polls until the challenge is solved or the deadline passes.
+func waitForOptimal(t *testing.T, o *OptimalSolutions, challengeID string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if keys, ok := o.Lookup(challengeID); ok {
+			return keys
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Challenge %s was not solved in time", challengeID)
+	return ""
+}
+
+func TestSolveChallenge(t *testing.T) {
+	challenge := testVerifierChallenge()
+	solution, err := SolveChallenge(challenge, vim.DefaultSolverConfig())
+	if err != nil {
+		t.Fatalf("SolveChallenge failed: %v", err)
+	}
+	// wdw: to "cruel", then delete the word
+	if solution.Keystrokes != 3 {
+		t.Errorf("Expected 3 keystrokes, got %d (%s)", solution.Keystrokes, solution)
+	}
+	if solution.Keystrokes > challenge.ParKeystrokes {
+		t.Errorf("Optimal %d exceeds par %d", solution.Keystrokes, challenge.ParKeystrokes)
+	}
+}
+
+func TestOptimalSolutionsSolvesOnce(t *testing.T) {
+	var calls atomic.Int32
+	o := NewOptimalSolutions()
+	o.solve = func(*engine.Challenge, vim.SolverConfig) (*vim.Solution, error) {
+		calls.Add(1)
+		return &vim.Solution{Keys: []string{"c", "i", "\"", "Escape"}, Keystrokes: 4}, nil
+	}
+
+	challenge := testVerifierChallenge()
+	o.Prepare(challenge)
+	if keys := waitForOptimal(t, o, challenge.ID); keys != `ci"<Esc>` {
+		t.Errorf("Expected optimal %q, got %q", `ci"<Esc>`, keys)
+	}
+
+	o.Prepare(challenge)
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected one solve, got %d", n)
+	}
+}
+
+func TestOptimalSolutionsUnsolved(t *testing.T) {
+	o := NewOptimalSolutions()
+	o.solve = func(*engine.Challenge, vim.SolverConfig) (*vim.Solution, error) {
+		return nil, vim.ErrNoSolution
+	}
+
+	o.Prepare(testVerifierChallenge())
+	time.Sleep(20 * time.Millisecond)
+	if keys, ok := o.Lookup("test_delete_word"); ok {
+		t.Errorf("Expected no optimal answer, got %q", keys)
+	}
+}
+
+// TestVerdictIncludesOptimal tests that a successful Neovim verdict carries
+// the optimal answer once it is known.
+func TestVerdictIncludesOptimal(t *testing.T) {
+	model := newTestModel()
+	challenge := testVerifierChallenge()
+	model.NvimChallengeID = "challenge_1"
+	model.registerNvimChallenge(challenge)
+	keys := waitForOptimal(t, model.OptimalSolutions, challenge.ID)
+
+	verdict := model.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID:      "challenge_1",
+		Buffer:         "hello world",
+		KeystrokeCount: 4,
+	})
+	if verdict == nil || !verdict.Success {
+		t.Fatalf("Expected successful verdict, got %+v", verdict)
+	}
+	if verdict.Optimal != keys {
+		t.Errorf("Expected optimal %q, got %q", keys, verdict.Optimal)
+	}
+}
+
+// TestTowerDefenseResultShowsOptimal tests that solving a tower defense
+// challenge shows the optimal answer, in Neovim and in the built-in editor.
+func TestTowerDefenseResultShowsOptimal(t *testing.T) {
+	model := newTestModel()
+	challenge := testVerifierChallenge()
+	model.NvimChallengeID = "challenge_1"
+	model.registerNvimChallenge(challenge)
+	keys := waitForOptimal(t, model.OptimalSolutions, challenge.ID)
+	model.Game.StartChallenge()
+
+	model.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID:      "challenge_1",
+		Buffer:         "hello world",
+		KeystrokeCount: 4,
+	})
+	model.handleChallengeResult(&nvim.ChallengeResult{RequestID: "challenge_1", Success: true})
+	if model.Notification == nil || !strings.HasSuffix(model.Notification.Message, "Optimal: "+keys) {
+		t.Errorf("Expected the optimal answer after a Neovim challenge, got %+v", model.Notification)
+	}
+
+	model.Notification = nil
+	model.CurrentChallenge = challenge
+	model.initVimEditor(challenge)
+	model.Game.StartChallenge()
+	for _, key := range []string{"w", "d", "w"} {
+		model.VimEditor.HandleKey(key)
+	}
+	model.submitChallenge()
+	if model.Notification == nil || !strings.HasSuffix(model.Notification.Message, "Optimal: "+keys) {
+		t.Errorf("Expected the optimal answer after a standalone challenge, got %+v", model.Notification)
+	}
+	if view := RenderGame(&model); !strings.Contains(view, keys) {
+		t.Error("Expected the notification in the game view")
+	}
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// randomLevelDifficulties are the difficulties the Random Level entry cycles through.
+var randomLevelDifficulties = []engine.LevelDifficulty{
+	engine.LevelDifficultyBeginner,
+	engine.LevelDifficultyIntermediate,
+	engine.LevelDifficultyAdvanced,
+}
+
+// SetRandomLevel picks the seed and difficulty of the Random Level entry,
+// e.g. from an ID a teammate shared.
+func (m *Model) SetRandomLevel(difficulty engine.LevelDifficulty, seed uint64) {
+	m.RandomDifficulty = difficulty
+	m.RandomSeed = seed
+	m.RandomLevel = nil
+}
+
+// randomLevel returns the level the Random Level entry would start,
+// generating it when the seed or difficulty changed.
+func (m *Model) randomLevel() *engine.Level {
+	id := engine.RandomLevelID(m.RandomDifficulty, m.RandomSeed)
+	if m.RandomLevel != nil && m.RandomLevel.ID == id {
+		return m.RandomLevel
+	}
+	level, err := engine.GenerateLevel(m.RandomDifficulty, m.RandomSeed)
+	if err != nil {
+		return nil
+	}
+	m.RandomLevel = &level
+	return m.RandomLevel
+}
+
+// onRandomLevel reports whether the Random Level entry, after the last
+// hand-made level, is selected.
+func (m *Model) onRandomLevel() bool {
+	return m.StartSection == SectionLevels && m.LevelMenuIndex == len(m.LevelRegistry.GetAll())
+}
+
+// handleRandomLevelKey handles the keys only the Random Level entry has:
+// a new seed and cycling the difficulty. It reports whether it used the key.
+func (m *Model) handleRandomLevelKey(key string) bool {
+	if !m.onRandomLevel() {
+		return false
+	}
+	switch key {
+	case "r":
+		m.SetRandomLevel(m.RandomDifficulty, engine.NewLevelSeed())
+	case "h", "left":
+		m.cycleRandomDifficulty(-1)
+	case "l", "right":
+		m.cycleRandomDifficulty(1)
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *Model) cycleRandomDifficulty(delta int) {
+	i := slices.Index(randomLevelDifficulties, m.RandomDifficulty)
+	n := len(randomLevelDifficulties)
+	m.SetRandomLevel(randomLevelDifficulties[((i+delta)%n+n)%n], m.RandomSeed)
+}
+
+// renderRandomLevelEntry returns the Random Level line of the level list.
+func renderRandomLevelEntry(m *Model) string {
+	return fmt.Sprintf("🎲  Random Level #%d", m.RandomSeed)
+}
+
+// renderRandomLevelPreview previews the level the Random Level entry would
+// start, with the ID teammates can use to play the same level.
+func renderRandomLevelPreview(m *Model) string {
+	level := m.randomLevel()
+	if level == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(MenuTitleStyle.Render("Random Level"))
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render(fmt.Sprintf("Seed %d  ID %s", m.RandomSeed, level.ID)))
+	b.WriteString("\n\n")
+
+	b.WriteString(renderMiniGrid(level))
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("Waves: %d  Difficulty: %s %s\n",
+		level.TotalWaves, difficultyIcon(level.Difficulty), string(level.Difficulty)))
+	b.WriteString(fmt.Sprintf("Path: %d cells\n", len(level.Path)))
+	b.WriteString("\n")
+
+	b.WriteString(HelpStyle.Render("Enemies: "))
+	var enemies []string
+	for _, et := range level.EnemyTypes {
+		info := entities.EnemyTypes[et]
+		enemies = append(enemies, info.Symbol+" "+info.Name)
+	}
+	b.WriteString(strings.Join(enemies, ", "))
+	b.WriteString("\n\n")
+
+	b.WriteString(HelpStyle.Render("[r] New seed  [h/l] Difficulty"))
+
+	return PreviewBoxStyle.Render(b.String())
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestRandomLevelFromStartScreen(t *testing.T) {
+	m := NewModel()
+	m.SetRandomLevel(engine.LevelDifficultyBeginner, 4242)
+	m.LevelMenuIndex = len(m.LevelRegistry.GetAll())
+
+	screen := RenderStartScreen(&m)
+	if !strings.Contains(screen, "Random Level #4242") || !strings.Contains(screen, "random-beginner-4242") {
+		t.Error("Start screen doesn't show the random level's seed and ID")
+	}
+
+	updated, _ := m.handleLevelSelectKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = updated.(Model)
+	if m.RandomDifficulty != engine.LevelDifficultyIntermediate || m.RandomSeed != 4242 {
+		t.Errorf("l should raise the difficulty and keep the seed, got %s #%d", m.RandomDifficulty, m.RandomSeed)
+	}
+	updated, _ = m.handleLevelSelectKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = updated.(Model)
+	updated, _ = m.handleLevelSelectKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = updated.(Model)
+	if m.RandomDifficulty != engine.LevelDifficultyAdvanced {
+		t.Errorf("h should wrap around to advanced, got %s", m.RandomDifficulty)
+	}
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	updated, _ = m.handleLevelSelectKeys(enter)
+	m = updated.(Model)
+	if m.SelectedLevel == nil || m.SelectedLevel.ID != "random-advanced-4242" {
+		t.Fatalf("Expected the random level to be selected, got %v", m.SelectedLevel)
+	}
+	m.SettingsMenuIndex = settingsStartIndex
+	updated, _ = m.handleSettingsKeys(enter)
+	m = updated.(Model)
+	if m.Game.LevelID != "random-advanced-4242" || m.Game.TotalWaves != m.SelectedLevel.TotalWaves {
+		t.Errorf("Game started on %q, want the random level", m.Game.LevelID)
+	}
+}
+
+func TestRandomLevelNewSeed(t *testing.T) {
+	m := NewModel()
+	m.SetRandomLevel(engine.LevelDifficultyBeginner, 1)
+	m.LevelMenuIndex = len(m.LevelRegistry.GetAll())
+
+	before := m.randomLevel()
+	updated, _ := m.handleLevelSelectKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = updated.(Model)
+	if m.RandomSeed == 1 {
+		t.Error("r should pick a new seed")
+	}
+	if after := m.randomLevel(); after == before || after.ID == before.ID {
+		t.Error("New seed didn't generate a new level")
+	}
+
+	// r only rerolls on the Random Level entry
+	m.LevelMenuIndex = 0
+	seed := m.RandomSeed
+	updated, _ = m.handleLevelSelectKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = updated.(Model)
+	if m.RandomSeed != seed {
+		t.Error("r changed the seed while a hand-made level was selected")
+	}
+}
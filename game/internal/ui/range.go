@@ -61,7 +61,8 @@ func renderRangeOverlay(grid [][]string, g *engine.Game, centerX, centerY int, r
 
 		// Only overlay empty cells or path cells
 		currentCell := grid[cell.Y][cell.X]
-		if currentCell == EmptyCellStyle.Render(EmptyCell) || currentCell == PathCellStyle.Render(PathChar) {
+		if currentCell == EmptyCellStyle.Render(EmptyCell) || currentCell == PathCellStyle.Render(PathChar) ||
+			currentCell == RouteCellStyle.Render(RouteChar) {
 			grid[cell.Y][cell.X] = RangeOverlayStyle.Render(RangeOverlayChar)
 		}
 	}
@@ -91,5 +92,5 @@ func RenderRangeForHover(grid [][]string, g *engine.Game) {
 	}
 
 	// Use the tower's current range (including upgrades)
-	renderRangeOverlay(grid, g, g.CursorX, g.CursorY, tower.Range)
+	renderRangeOverlay(grid, g, g.CursorX, g.CursorY, tower.EffectiveRange())
 }
@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
 )
 
 func TestCellsInRange(t *testing.T) {
@@ -174,3 +177,25 @@ func TestCellsInRangeTowerRanges(t *testing.T) {
 		}
 	}
 }
+
+func TestMazeRouteOverlay(t *testing.T) {
+	m := NewModel()
+	level := engine.Level12()
+	m.Game = engine.NewGameFromLevelAndSettings(&level, engine.DefaultGameSettings())
+	m.Game.CursorX, m.Game.CursorY = 0, 0
+
+	before := renderGrid(&m)
+	if strings.Count(before, RouteChar) != len(m.Game.Path)-2 {
+		t.Errorf("Expected the route between spawn and exit drawn with %q", RouteChar)
+	}
+
+	m.Game.CursorX, m.Game.CursorY = 10, 7
+	if !m.Game.PlaceTower() {
+		t.Fatal("PlaceTower() on the open route failed")
+	}
+	m.Game.CursorX, m.Game.CursorY = 0, 0
+	after := renderGrid(&m)
+	if strings.Count(after, RouteChar) != len(m.Game.Path)-2 || after == before {
+		t.Error("Route overlay should follow the new route around the tower")
+	}
+}
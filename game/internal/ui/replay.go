@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+// replayMaxSteps caps catch-up steps per frame during playback. It is higher
+// than the live cap so fast-forward keeps up at the top speed.
+const replayMaxSteps = 64
+
+// replaySpeeds are the playback speeds cycled with + and -.
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4, 8}
+
+// DefaultReplayDir returns where replays are saved unless overridden, or ""
+// if the platform has no cache directory.
+func DefaultReplayDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "keyforge", "replays")
+}
+
+// ReplayRecorder records the actions of the tower defense game in progress
+// and saves them when the game ends. Neovim can pause the game from the RPC
+// goroutine, so like the verifier it is shared by pointer and locked.
+type ReplayRecorder struct {
+	mu     sync.Mutex
+	dir    string
+	replay *engine.Replay
+}
+
+// NewReplayRecorder creates a recorder that saves replays into dir.
+func NewReplayRecorder(dir string) *ReplayRecorder {
+	return &ReplayRecorder{dir: dir}
+}
+
+// Start begins recording a new game, discarding any unsaved one.
+func (r *ReplayRecorder) Start(level *engine.Level, settings engine.GameSettings) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replay = engine.NewReplay(level, settings)
+}
+
+// Record appends an action to the game being recorded.
+func (r *ReplayRecorder) Record(a engine.Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replay != nil {
+		r.replay.Actions = append(r.replay.Actions, a)
+	}
+}
+
+// Finish saves the game being recorded and returns the file path. It returns
+// "" when nothing is being recorded, so calling it twice saves once.
+func (r *ReplayRecorder) Finish(g *engine.Game) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replay == nil {
+		return "", nil
+	}
+	replay := r.replay
+	r.replay = nil
+	replay.Finish(g)
+
+	if err := os.MkdirAll(r.dir, 0o750); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s-wave%d.json", time.Now().Format("20060102-150405"), replay.LevelID, replay.FinalWave)
+	path := filepath.Join(r.dir, name)
+	if err := replay.Save(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ReplayPlayer feeds a recorded game back into the simulation: before every
+// fixed step it applies the actions recorded for that tick.
+type ReplayPlayer struct {
+	Replay *engine.Replay
+	Paused bool
+
+	speed       int // Index into replaySpeeds
+	next        int // Next action to apply
+	accumulator float64
+}
+
+// NewReplayPlayer creates a player at normal speed.
+func NewReplayPlayer(replay *engine.Replay) *ReplayPlayer {
+	p := &ReplayPlayer{Replay: replay}
+	p.SetSpeed(1)
+	return p
+}
+
+// Speed returns the playback speed multiplier.
+func (p *ReplayPlayer) Speed() float64 {
+	return replaySpeeds[p.speed]
+}
+
+// SetSpeed picks the slowest available speed at or above multiplier.
+func (p *ReplayPlayer) SetSpeed(multiplier float64) {
+	p.speed = len(replaySpeeds) - 1
+	for i, s := range replaySpeeds {
+		if s >= multiplier {
+			p.speed = i
+			return
+		}
+	}
+}
+
+// Faster steps up to the next playback speed.
+func (p *ReplayPlayer) Faster() {
+	p.speed = min(p.speed+1, len(replaySpeeds)-1)
+}
+
+// Slower steps down to the previous playback speed.
+func (p *ReplayPlayer) Slower() {
+	p.speed = max(p.speed-1, 0)
+}
+
+// Done reports whether playback has reached the end of the recording.
+func (p *ReplayPlayer) Done(g *engine.Game) bool {
+	return p.next >= len(p.Replay.Actions) && g.Tick >= p.Replay.FinalTick
+}
+
+// Advance plays real elapsed time, scaled by the speed, and returns the
+// number of steps taken. Nothing happens while paused.
+func (p *ReplayPlayer) Advance(g *engine.Game, realDt float64) int {
+	if p.Paused {
+		return 0
+	}
+	p.accumulator += realDt * p.Speed()
+	if limit := engine.FixedTimestep * replayMaxSteps; p.accumulator > limit {
+		p.accumulator = limit
+	}
+
+	steps := 0
+	for p.accumulator >= engine.FixedTimestep && !p.Done(g) {
+		p.accumulator -= engine.FixedTimestep
+		p.Step(g)
+		steps++
+	}
+	return steps
+}
+
+// Step applies the actions due on the current tick and runs one fixed step.
+func (p *ReplayPlayer) Step(g *engine.Game) {
+	if p.Done(g) {
+		return
+	}
+	for p.next < len(p.Replay.Actions) && p.Replay.Actions[p.next].Tick <= g.Tick {
+		g.Apply(p.Replay.Actions[p.next])
+		p.next++
+	}
+	g.Step()
+}
+
+// NewReplayModel creates a model that plays back a replay of level.
+func NewReplayModel(replay *engine.Replay, level *engine.Level) Model {
+	m := NewModelWithSettings(replay.Settings)
+	m.SelectedLevel = level
+	m.Game = engine.NewGameFromLevelAndSettings(level, replay.Settings)
+	m.resetChallengeSelector()
+	m.Player = NewReplayPlayer(replay)
+	return m
+}
+
+// handleReplayKeys controls playback; the game itself takes no input.
+func (m Model) handleReplayKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) { //nolint:gocritic // hugeParam: returns modified model
+	switch msg.String() {
+	case " ", "p":
+		m.Player.Paused = !m.Player.Paused
+	case ".", "n":
+		// Step one tick, pausing first so the step is visible
+		m.Player.Paused = true
+		m.Player.Step(m.Game)
+	case "+", "=", "l", "right":
+		m.Player.Faster()
+	case "-", "h", "left":
+		m.Player.Slower()
+	case "q", keyEsc:
+		m.Quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+// frame advances a model by one frame of real time.
+func frame(t *testing.T, m Model) Model {
+	t.Helper()
+	updated, _ := m.Update(TickMsg(m.LastUpdate.Add(time.Second / TargetFPS)))
+	return updated.(Model)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	m := NewModel()
+	m.Recorder = NewReplayRecorder(dir)
+	m.startGameFromSettings()
+
+	for _, key := range []string{"h", "h", "k", " ", "l", "l", "2", " "} {
+		m = press(t, m, key)
+		m = frame(t, m)
+	}
+	for range 300 {
+		m = frame(t, m)
+	}
+	m = press(t, m, "u")
+	for range 300 {
+		m = frame(t, m)
+	}
+	m = press(t, m, "q")
+
+	if len(m.Game.Towers) != 2 {
+		t.Fatalf("Expected 2 towers placed, got %d", len(m.Game.Towers))
+	}
+	if m.LastReplayPath == "" {
+		t.Fatal("Expected the replay to be saved when quitting")
+	}
+	replay, err := engine.LoadReplay(m.LastReplayPath)
+	if err != nil {
+		t.Fatalf("LoadReplay() error = %v", err)
+	}
+	if replay.Settings.Seed != m.Game.Seed {
+		t.Errorf("Replay seed = %d, want %d", replay.Settings.Seed, m.Game.Seed)
+	}
+
+	p := NewReplayModel(replay, m.SelectedLevel)
+	p.Player.SetSpeed(8)
+	for !p.Player.Done(p.Game) {
+		p = frame(t, p)
+	}
+
+	if p.Game.Tick != m.Game.Tick {
+		t.Errorf("Replay ended on tick %d, want %d", p.Game.Tick, m.Game.Tick)
+	}
+	if p.Game.Gold != m.Game.Gold || p.Game.Health != m.Game.Health || len(p.Game.Towers) != len(m.Game.Towers) {
+		t.Errorf("Replay gold/health/towers = %d/%d/%d, want %d/%d/%d",
+			p.Game.Gold, p.Game.Health, len(p.Game.Towers), m.Game.Gold, m.Game.Health, len(m.Game.Towers))
+	}
+}
+
+func TestReplayPauseAndStep(t *testing.T) {
+	level := engine.ClassicLevel()
+	settings := engine.DefaultGameSettings()
+	settings.Seed = 5
+	replay := engine.NewReplay(&level, settings)
+	replay.FinalTick = 100
+
+	m := NewReplayModel(replay, &level)
+	m = press(t, m, " ")
+	m = frame(t, m)
+	if m.Game.Tick != 0 {
+		t.Errorf("Paused replay advanced to tick %d", m.Game.Tick)
+	}
+
+	m = press(t, m, ".")
+	if m.Game.Tick != 1 {
+		t.Errorf("Step advanced to tick %d, want 1", m.Game.Tick)
+	}
+
+	m = press(t, m, "+")
+	if m.Player.Speed() != 2 {
+		t.Errorf("Speed() = %g after +, want 2", m.Player.Speed())
+	}
+}
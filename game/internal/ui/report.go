@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+// reportMaxTowers caps the per-tower rows on the end screen; the exported
+// report always has every tower.
+const reportMaxTowers = 8
+
+// DefaultReportDir returns where game reports are exported unless
+// overridden, or "" if the platform has no cache directory.
+func DefaultReportDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "keyforge", "reports")
+}
+
+// exportReport writes the current game's report as JSON into ReportDir.
+func (m *Model) exportReport() {
+	m.LastReportPath, m.ReportErr = "", nil
+	if m.ReportDir == "" {
+		m.ReportErr = errors.New("no report directory")
+		return
+	}
+	report := m.Game.Report()
+	if err := os.MkdirAll(m.ReportDir, 0o750); err != nil {
+		m.ReportErr = err
+		return
+	}
+	level := cmp.Or(report.Level, "game")
+	name := fmt.Sprintf("%s-%s-report.json", time.Now().Format("20060102-150405"), level)
+	path := filepath.Join(m.ReportDir, name)
+	if err := report.Save(path); err != nil {
+		m.ReportErr = err
+		return
+	}
+	m.LastReportPath = path
+}
+
+// renderReport renders the combat report for the end screens.
+func renderReport(m *Model) string {
+	r := m.Game.Report()
+	var b strings.Builder
+
+	health := 0
+	for _, w := range r.Waves {
+		health += w.HealthLost
+	}
+	b.WriteString(fmt.Sprintf("  Time: %s   Leaks: %d   Health lost: %d\n",
+		time.Duration(r.Seconds*float64(time.Second)).Round(time.Second), r.TotalLeaks(), health))
+	b.WriteString(fmt.Sprintf("  Gold earned: %d mobs, %d wave bonus, %d challenges   Spent: %d\n",
+		r.GoldEarned[engine.GoldSourceMob], r.GoldEarned[engine.GoldSourceWaveBonus],
+		r.GoldEarned[engine.GoldSourceChallenge], r.GoldSpent))
+	if early := r.GoldEarned[engine.GoldSourceEarlyCall]; early > 0 {
+		b.WriteString(fmt.Sprintf("  Early wave calls: +%d gold\n", early))
+	}
+	if interest := r.GoldEarned[engine.GoldSourceInterest]; interest > 0 {
+		b.WriteString(fmt.Sprintf("  Interest: +%d gold\n", interest))
+	}
+	if c := r.Challenges; c.Attempted > 0 {
+		b.WriteString(fmt.Sprintf("  Challenges: %d/%d solved (%.0f%%)\n", c.Succeeded, c.Attempted, c.Accuracy*100))
+	}
+	if n := len(r.Director); n > 0 {
+		b.WriteString(fmt.Sprintf("  Director: %d adjustments, last %s\n", n, r.Director[n-1]))
+	}
+	if worst := worstWave(r.Waves); worst != nil {
+		b.WriteString(fmt.Sprintf("  Worst wave: %d (%d leaked, %d health)\n", worst.Wave, worst.Leaks, worst.HealthLost))
+	}
+
+	if len(r.Towers) == 0 {
+		return b.String()
+	}
+	towers := slices.Clone(r.Towers)
+	slices.SortStableFunc(towers, func(a, b engine.TowerReport) int {
+		return cmp.Compare(b.Damage, a.Damage)
+	})
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render(fmt.Sprintf("  %-10s %-8s %3s %6s %7s %6s", "Tower", "At", "Lvl", "Shots", "Damage", "Kills")))
+	b.WriteString("\n")
+	for _, t := range towers[:min(len(towers), reportMaxTowers)] {
+		b.WriteString(fmt.Sprintf("  %-10s %-8s %3d %6d %7d %6d\n",
+			t.Type, fmt.Sprintf("%d,%d", t.X, t.Y), t.Level+1, t.Shots, t.Damage, t.Kills))
+	}
+	if more := len(towers) - reportMaxTowers; more > 0 {
+		b.WriteString(fmt.Sprintf("  ... and %d more (export for the full report)\n", more))
+	}
+	return b.String()
+}
+
+// worstWave returns the wave that cost the most health, or nil if none did.
+func worstWave(waves []engine.WaveReport) *engine.WaveReport {
+	var worst *engine.WaveReport
+	for i := range waves {
+		if waves[i].HealthLost > 0 && (worst == nil || waves[i].HealthLost > worst.HealthLost) {
+			worst = &waves[i]
+		}
+	}
+	return worst
+}
+
+// renderReportSaved tells the player where the exported report went.
+func renderReportSaved(m *Model) string {
+	switch {
+	case m.ReportErr != nil:
+		return fmt.Sprintf("  Failed to export report: %v\n", m.ReportErr)
+	case m.LastReportPath != "":
+		return fmt.Sprintf("  Report exported: %s\n", m.LastReportPath)
+	}
+	return ""
+}
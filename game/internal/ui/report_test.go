@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keyforge/keyforge/internal/engine"
+)
+
+func TestEndScreenExportsReport(t *testing.T) {
+	m := NewModel()
+	m.ReportDir = t.TempDir()
+	m.startGameFromSettings()
+	m.Game.CursorX, m.Game.CursorY = 0, 0
+	m = press(t, m, " ")
+	for range 600 {
+		m = frame(t, m)
+	}
+	m.Game.State = engine.StateGameOver
+
+	view := RenderGameOver(&m)
+	for _, want := range []string{"Gold earned:", "Tower", "Shots", "Damage"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Game over screen is missing %q", want)
+		}
+	}
+
+	m = press(t, m, "e")
+	if m.ReportErr != nil || m.LastReportPath == "" {
+		t.Fatalf("Export failed: path %q, error %v", m.LastReportPath, m.ReportErr)
+	}
+	if !strings.HasPrefix(m.LastReportPath, m.ReportDir) {
+		t.Errorf("Report saved to %s, outside %s", m.LastReportPath, m.ReportDir)
+	}
+	if !strings.Contains(RenderGameOver(&m), "Report exported:") {
+		t.Error("Game over screen doesn't show where the report went")
+	}
+}
+
+func TestExportReportWithoutDir(t *testing.T) {
+	m := NewModel()
+	m.exportReport()
+
+	if m.ReportErr == nil || m.LastReportPath != "" {
+		t.Errorf("Expected an error without a report directory, got path %q", m.LastReportPath)
+	}
+}
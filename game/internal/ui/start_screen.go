@@ -110,7 +110,11 @@ func RenderStartScreen(m *Model) string {
 
 	// Help text
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("[j/k] Select level  [Enter] Configure settings  [q] Quit"))
+	if m.Settings.Endless {
+		b.WriteString(HelpStyle.Render("[j/k] Select level  [Enter] Configure endless run  [Esc] Back to campaign  [q] Quit"))
+	} else {
+		b.WriteString(HelpStyle.Render("[j/k] Select level  [Enter] Configure settings  [q] Quit"))
+	}
 
 	return b.String()
 }
@@ -126,6 +130,9 @@ func RenderSettingsScreen(m *Model) string {
 	// Level info
 	if m.SelectedLevel != nil {
 		levelInfo := "Level: " + m.SelectedLevel.Name
+		if m.Settings.Endless {
+			levelInfo += " (Endless)"
+		}
 		b.WriteString(HelpStyle.Render(levelInfo))
 		b.WriteString("\n\n")
 	}
@@ -136,33 +143,12 @@ func RenderSettingsScreen(m *Model) string {
 		options []string
 		current int
 	}{
-		{"Difficulty", []string{"Easy", "Normal", "Hard"}, difficultyIndex(m.Settings.Difficulty)},
+		{"Difficulty", m.difficultyLabels(), m.difficultySetting()},
 		{"Game Speed", []string{"0.5x", "1x", "1.5x", "2x"}, speedIndex(m.Settings.GameSpeed)},
 	}
 
 	for i, setting := range settings {
-		isSelected := m.SettingsMenuIndex == i
-
-		// Label
-		label := SettingLabelStyle.Render(setting.label + ":")
-
-		// Options
-		var opts []string
-		for j, opt := range setting.options {
-			if j == setting.current {
-				if isSelected {
-					opts = append(opts, SettingSelectedStyle.Render(opt))
-				} else {
-					opts = append(opts, SettingValueStyle.Render("["+opt+"]"))
-				}
-			} else {
-				opts = append(opts, HelpStyle.Render(opt))
-			}
-		}
-
-		b.WriteString(label)
-		b.WriteString(strings.Join(opts, "  "))
-		b.WriteString("\n")
+		b.WriteString(renderSettingOptions(setting.label, setting.options, setting.current, m.SettingsMenuIndex == i))
 	}
 
 	// Sliders for gold and health
@@ -192,9 +178,22 @@ func RenderSettingsScreen(m *Model) string {
 	healthSlider := renderSlider(m.Settings.StartingHealth, 50, 200)
 	b.WriteString(healthLabel + healthValue + "  " + healthSlider + "\n")
 
+	// Build phase toggle
+	b.WriteString("\n")
+	buildPhase := 0
+	if m.Settings.BuildPhase {
+		buildPhase = 1
+	}
+	b.WriteString(renderSettingOptions("Build Phase", []string{"Off", "On"}, buildPhase, m.SettingsMenuIndex == 4))
+	director := 0
+	if m.Settings.Director {
+		director = 1
+	}
+	b.WriteString(renderSettingOptions("Adaptive Waves", []string{"Off", "On"}, director, m.SettingsMenuIndex == 5))
+
 	// Start Game button
 	b.WriteString("\n")
-	startSelected := m.SettingsMenuIndex == 4
+	startSelected := m.SettingsMenuIndex == settingsStartIndex
 	if startSelected {
 		b.WriteString(MenuItemSelectedStyle.Render("[ Start Game ]"))
 	} else {
@@ -208,6 +207,24 @@ func RenderSettingsScreen(m *Model) string {
 	return b.String()
 }
 
+// renderSettingOptions renders one settings line with its options, the
+// current one highlighted.
+func renderSettingOptions(label string, options []string, current int, selected bool) string {
+	var opts []string
+	for j, opt := range options {
+		if j == current {
+			if selected {
+				opts = append(opts, SettingSelectedStyle.Render(opt))
+			} else {
+				opts = append(opts, SettingValueStyle.Render("["+opt+"]"))
+			}
+		} else {
+			opts = append(opts, HelpStyle.Render(opt))
+		}
+	}
+	return SettingLabelStyle.Render(label+":") + strings.Join(opts, "  ") + "\n"
+}
+
 func renderLogo() string {
 	logo := `
  ██╗  ██╗███████╗██╗   ██╗███████╗ ██████╗ ██████╗  ██████╗ ███████╗
@@ -222,7 +239,11 @@ func renderLogo() string {
 func renderLevelList(m *Model) string {
 	var b strings.Builder
 
-	b.WriteString(MenuTitleStyle.Render("Select Level"))
+	if m.Settings.Endless {
+		b.WriteString(MenuTitleStyle.Render("Select Level (Endless)"))
+	} else {
+		b.WriteString(MenuTitleStyle.Render("Select Level"))
+	}
 	b.WriteString("\n\n")
 
 	levels := m.LevelRegistry.GetAll()
@@ -233,6 +254,9 @@ func renderLevelList(m *Model) string {
 		// Level name and difficulty
 		diffIcon := difficultyIcon(level.Difficulty)
 		text := fmt.Sprintf("%s %s", diffIcon, level.Name)
+		if m.Settings.Endless {
+			text += renderEndlessBest(m, level.ID)
+		}
 
 		if isSelected {
 			b.WriteString(MenuItemSelectedStyle.Render(text))
@@ -242,6 +266,15 @@ func renderLevelList(m *Model) string {
 		b.WriteString("\n")
 	}
 
+	// Random Level entry
+	randomText := renderRandomLevelEntry(m)
+	if m.onRandomLevel() {
+		b.WriteString(MenuItemSelectedStyle.Render(randomText))
+	} else {
+		b.WriteString(MenuItemStyle.Render(randomText))
+	}
+	b.WriteString("\n")
+
 	// Separator and mode options
 	b.WriteString("\n")
 	b.WriteString(HelpStyle.Render("─────────────────"))
@@ -267,6 +300,16 @@ func renderLevelList(m *Model) string {
 	}
 	b.WriteString("\n")
 
+	// Endless Mode option
+	endlessSelected := m.StartSection == SectionModes && m.ModeMenuIndex == 2
+	endlessText := "∞  Endless Mode"
+	if endlessSelected {
+		b.WriteString(MenuItemSelectedStyle.Render(endlessText))
+	} else {
+		b.WriteString(MenuItemStyle.Render(endlessText))
+	}
+	b.WriteString("\n")
+
 	return b.String()
 }
 
@@ -275,6 +318,9 @@ func renderLevelPreview(m *Model) string {
 	if m.StartSection == SectionModes {
 		return renderModePreview(m)
 	}
+	if m.onRandomLevel() {
+		return renderRandomLevelPreview(m)
+	}
 
 	levels := m.LevelRegistry.GetAll()
 	if m.LevelMenuIndex >= len(levels) {
@@ -299,6 +345,12 @@ func renderLevelPreview(m *Model) string {
 	b.WriteString(fmt.Sprintf("Waves: %d  Difficulty: %s\n",
 		level.TotalWaves,
 		string(level.Difficulty)))
+	if len(level.Paths) > 0 {
+		b.WriteString(fmt.Sprintf("Paths: %d\n", len(level.Paths)+1))
+	}
+	if level.Maze != nil {
+		b.WriteString("Open field: towers are walls, enemies find their way\n")
+	}
 	b.WriteString("\n")
 
 	// Enemies
@@ -327,7 +379,8 @@ func renderLevelPreview(m *Model) string {
 func renderModePreview(m *Model) string {
 	var b strings.Builder
 
-	if m.ModeMenuIndex == 0 {
+	switch m.ModeMenuIndex {
+	case 0:
 		// Challenge Mode preview
 		b.WriteString(MenuTitleStyle.Render("Challenge Mode"))
 		b.WriteString("\n")
@@ -344,7 +397,7 @@ func renderModePreview(m *Model) string {
 		b.WriteString("• Streak counter for consecutive wins\n")
 		b.WriteString("• Instant feedback on success/failure\n")
 		b.WriteString("• Press Esc to return to menu\n")
-	} else {
+	case 1:
 		// Challenge Selection preview
 		b.WriteString(MenuTitleStyle.Render("Challenge Selection"))
 		b.WriteString("\n")
@@ -360,6 +413,23 @@ func renderModePreview(m *Model) string {
 		b.WriteString("• Preview challenges before starting\n")
 		b.WriteString("• Auto-advance to next challenge\n")
 		b.WriteString("• Return to selection anytime\n")
+	default:
+		// Endless Mode preview
+		b.WriteString(MenuTitleStyle.Render("Endless Mode"))
+		b.WriteString("\n")
+		b.WriteString(HelpStyle.Render("Survive as long as you can"))
+		b.WriteString("\n\n")
+
+		b.WriteString("Waves never stop. Each one brings\n")
+		b.WriteString("more, tougher and new enemies.\n\n")
+
+		b.WriteString(HelpStyle.Render("Features:"))
+		b.WriteString("\n")
+		b.WriteString("• Procedural waves on any level\n")
+		b.WriteString("• Boss every 10 waves\n")
+		b.WriteString(fmt.Sprintf("• Score: %d per wave, %d per challenge\n",
+			engine.EndlessScore(1, 0), engine.EndlessScore(0, 1)))
+		b.WriteString("• Personal best per level\n")
 	}
 
 	return PreviewBoxStyle.Render(b.String())
@@ -382,12 +452,14 @@ func renderMiniGrid(level *engine.Level) string {
 		}
 	}
 
-	// Plot path (scaled)
-	for _, pos := range level.Path {
-		x := int(pos.X / scaleX)
-		y := int(pos.Y / scaleY)
-		if x >= 0 && x < previewWidth && y >= 0 && y < previewHeight {
-			grid[y][x] = '░'
+	// Plot paths (scaled)
+	for _, path := range level.AllPaths() {
+		for _, pos := range path {
+			x := int(pos.X / scaleX)
+			y := int(pos.Y / scaleY)
+			if x >= 0 && x < previewWidth && y >= 0 && y < previewHeight {
+				grid[y][x] = '░'
+			}
 		}
 	}
 
@@ -454,6 +526,34 @@ func difficultyIndex(d string) int {
 	}
 }
 
+// difficultyNames returns the difficulties the settings screen cycles
+// through: the presets, then the custom profile if there is one.
+func (m *Model) difficultyNames() []string {
+	names := []string{engine.DifficultyEasy, engine.DifficultyNormal, engine.DifficultyHard}
+	if m.CustomDifficulty != nil {
+		names = append(names, m.CustomDifficulty.Name)
+	}
+	return names
+}
+
+// difficultyLabels returns how the settings screen shows difficultyNames.
+func (m *Model) difficultyLabels() []string {
+	labels := []string{"Easy", "Normal", "Hard"}
+	if m.CustomDifficulty != nil {
+		labels = append(labels, m.CustomDifficulty.Name)
+	}
+	return labels
+}
+
+// difficultySetting returns the index of the selected difficulty in
+// difficultyNames.
+func (m *Model) difficultySetting() int {
+	if m.Settings.Economy != nil && m.CustomDifficulty != nil {
+		return len(m.difficultyNames()) - 1
+	}
+	return difficultyIndex(m.Settings.Difficulty)
+}
+
 func speedIndex(s engine.GameSpeed) int {
 	switch s {
 	case engine.SpeedHalf:
@@ -508,12 +608,7 @@ func renderChallengeModeHeader(m *Model) string {
 
 	// Notification
 	if m.Notification != nil {
-		b.WriteString("  ")
-		if m.Notification.IsSuccess {
-			b.WriteString(lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Render("✓ " + m.Notification.Message))
-		} else {
-			b.WriteString(lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).Render("✗ " + m.Notification.Message))
-		}
+		b.WriteString("  " + renderNotification(m.Notification))
 	}
 
 	return b.String()
@@ -569,12 +664,7 @@ func RenderChallengeSelection(m *Model) string {
 	// Header
 	b.WriteString(MenuTitleStyle.Render("CHALLENGE SELECTION"))
 	if m.Notification != nil {
-		b.WriteString("  ")
-		if m.Notification.IsSuccess {
-			b.WriteString(lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Render("✓ " + m.Notification.Message))
-		} else {
-			b.WriteString(lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).Render("✗ " + m.Notification.Message))
-		}
+		b.WriteString("  " + renderNotification(m.Notification))
 	}
 	b.WriteString("\n\n")
 
@@ -724,12 +814,7 @@ func RenderChallengeSelectionPractice(m *Model) string {
 	// Header with notification
 	b.WriteString(MenuTitleStyle.Render("CHALLENGE PRACTICE"))
 	if m.Notification != nil {
-		b.WriteString("  ")
-		if m.Notification.IsSuccess {
-			b.WriteString(lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Render("✓ " + m.Notification.Message))
-		} else {
-			b.WriteString(lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).Render("✗ " + m.Notification.Message))
-		}
+		b.WriteString("  " + renderNotification(m.Notification))
 	}
 	b.WriteString("\n\n")
 
@@ -266,13 +266,13 @@ func TestSettingsNavigation(t *testing.T) {
 		model := NewModel()
 		model.Game.State = engine.StateSettings
 		model.SelectedLevel = model.LevelRegistry.GetByID("level-5")
-		model.SettingsMenuIndex = 4 // Start Game button is last
+		model.SettingsMenuIndex = settingsStartIndex // Start Game button is last
 
 		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
 		m := newModel.(Model)
 
-		if m.SettingsMenuIndex != 4 {
-			t.Errorf("Expected SettingsMenuIndex 4, got %d", m.SettingsMenuIndex)
+		if m.SettingsMenuIndex != settingsStartIndex {
+			t.Errorf("Expected SettingsMenuIndex %d, got %d", settingsStartIndex, m.SettingsMenuIndex)
 		}
 	})
 
@@ -351,6 +351,28 @@ func TestDifficultyAdjustment(t *testing.T) {
 			t.Errorf("Expected difficulty Hard, got %s", m.Settings.Difficulty)
 		}
 	})
+
+	t.Run("custom profile comes after hard", func(t *testing.T) {
+		settings := engine.DefaultGameSettings()
+		settings.UseProfile(engine.DifficultyProfile{Name: "brutal", Economy: engine.EconomyConfig{TowerCostMult: 2}})
+		model := NewModelWithSettings(settings)
+		model.Game.State = engine.StateSettings
+		model.SettingsMenuIndex = 0
+
+		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+		m := newModel.(Model)
+		if m.Settings.Difficulty != engine.DifficultyHard || m.Settings.Economy != nil {
+			t.Fatalf("Expected difficulty Hard without the profile, got %s", m.Settings.Difficulty)
+		}
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+		m = newModel.(Model)
+		if m.Settings.Difficulty != "brutal" || m.Settings.GetEconomyConfig().TowerCostMult != 2 {
+			t.Errorf("Expected the brutal profile back, got %s", m.Settings.Difficulty)
+		}
+		if !strings.Contains(m.View(), "brutal") {
+			t.Error("Settings screen doesn't show the custom profile")
+		}
+	})
 }
 
 // TestGameSpeedAdjustment tests changing game speed setting.
@@ -548,7 +570,7 @@ func TestStartGameFromSettings(t *testing.T) {
 		model := NewModel()
 		model.Game.State = engine.StateSettings
 		model.SelectedLevel = model.LevelRegistry.GetByID("level-5")
-		model.SettingsMenuIndex = 4 // Start Game button
+		model.SettingsMenuIndex = settingsStartIndex // Start Game button
 		model.Settings = engine.GameSettings{
 			Difficulty:     engine.DifficultyEasy,
 			GameSpeed:      engine.SpeedDouble,
@@ -568,7 +590,7 @@ func TestStartGameFromSettings(t *testing.T) {
 		model := NewModel()
 		model.Game.State = engine.StateSettings
 		model.SelectedLevel = model.LevelRegistry.GetByID("level-5")
-		model.SettingsMenuIndex = 4
+		model.SettingsMenuIndex = settingsStartIndex
 		model.Settings = engine.GameSettings{
 			Difficulty:     engine.DifficultyEasy,
 			GameSpeed:      engine.SpeedDouble,
@@ -594,7 +616,7 @@ func TestStartGameFromSettings(t *testing.T) {
 		model := NewModel()
 		model.Game.State = engine.StateSettings
 		model.SelectedLevel = model.LevelRegistry.GetByID("level-5")
-		model.SettingsMenuIndex = 4
+		model.SettingsMenuIndex = settingsStartIndex
 		model.Settings = engine.DefaultGameSettings()
 
 		newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
@@ -619,8 +641,8 @@ func TestQuickStartPath(t *testing.T) {
 		t.Fatalf("Expected StateSettings after first Enter, got %v", m.Game.State)
 	}
 
-	// Move to Start Game button (index 4)
-	m.SettingsMenuIndex = 4
+	// Move to Start Game button
+	m.SettingsMenuIndex = settingsStartIndex
 
 	// Second Enter: start game
 	newModel2, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
@@ -816,7 +838,7 @@ func TestGameRestartKeepsLevelAndSettings(t *testing.T) {
 	}
 
 	// Start the game
-	model.SettingsMenuIndex = 4
+	model.SettingsMenuIndex = settingsStartIndex
 	newModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
 	m := newModel.(Model)
 
@@ -57,6 +57,9 @@ var (
 	PathCellStyle = lipgloss.NewStyle().
 			Foreground(ColorPath)
 
+	RouteCellStyle = lipgloss.NewStyle().
+			Foreground(ColorSecondary)
+
 	CursorStyle = lipgloss.NewStyle().
 			Background(ColorCursor).
 			Foreground(lipgloss.Color("#000000"))
@@ -94,6 +97,20 @@ var (
 			Foreground(lipgloss.Color("#7c2d12")).
 			Bold(true)
 
+	EnemyMothStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#e879f9")) // Orchid
+
+	EnemyDragonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#0d9488")) // Teal
+
+		// Boss bar and towers a boss disabled.
+	BossStyle = lipgloss.NewStyle().
+			Foreground(ColorDanger).
+			Bold(true)
+
+	TowerDisabledStyle = lipgloss.NewStyle().
+				Foreground(ColorMuted)
+
 		// Projectile style.
 	ProjectileStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#fef08a"))
@@ -179,6 +196,8 @@ const (
 	EnemySpecterChar = "👻"
 	EnemyDaemonChar  = "👿"
 	EnemyBossChar    = "💀"
+	EnemyMothChar    = "🦋"
+	EnemyDragonChar  = "🐉"
 
 	ProjectileChar = "•"
 
@@ -191,6 +210,7 @@ const (
 	EmptyCell  = "·"
 	CursorChar = "█"
 	PathChar   = "░"
+	RouteChar  = "◦" // Live enemy route on an open-field level
 
 	// Health bar.
 	HealthFull  = "█"
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/nvim"
+	"github.com/keyforge/keyforge/internal/vim"
+)
+
+// ChallengeVerifier judges challenge submissions from Neovim.
+// The RPC handler runs on the original model pointer in a separate goroutine,
+// so the verifier is shared by pointer and guarded by a mutex.
+type ChallengeVerifier struct {
+	mu     sync.Mutex
+	issued map[string]*issuedChallenge
+	now    func() time.Time
+	// optimal, if set, supplies the optimal answer for successful verdicts
+	optimal *OptimalSolutions
+}
+
+// issuedChallenge is a challenge sent to Neovim and awaiting a verdict.
+type issuedChallenge struct {
+	challenge *engine.Challenge
+	economy   engine.EconomyConfig
+	issuedAt  time.Time
+	verdict   *nvim.ValidateChallengeResult
+}
+
+// NewChallengeVerifier creates an empty verifier.
+func NewChallengeVerifier() *ChallengeVerifier {
+	return &ChallengeVerifier{
+		issued: make(map[string]*issuedChallenge),
+		now:    time.Now,
+	}
+}
+
+// Register records a challenge issued to Neovim under its request ID.
+// Only one challenge is active at a time, so older registrations are dropped.
+func (v *ChallengeVerifier) Register(requestID string, challenge *engine.Challenge, economy engine.EconomyConfig) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.issued = map[string]*issuedChallenge{
+		requestID: {
+			challenge: challenge,
+			economy:   economy,
+			issuedAt:  v.now(),
+		},
+	}
+}
+
+// Verify validates a submission against the registered challenge and stores
+// the verdict. Returns nil if the request ID was never issued.
+func (v *ChallengeVerifier) Verify(req *nvim.ValidateChallengeRequest) *nvim.ValidateChallengeResult {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	issued, ok := v.issued[req.RequestID]
+	if !ok {
+		return nil
+	}
+	c := issued.challenge
+
+	// Trust the longer of the reported count and the key log
+	keystrokes := req.KeystrokeCount
+	if len(req.Keystrokes) > keystrokes {
+		keystrokes = len(req.Keystrokes)
+	}
+
+	// Time is measured from when the challenge was issued, not reported by the client
+	elapsed := v.now().Sub(issued.issuedAt)
+	result := vim.ValidateSubmission(vim.Submission{
+		Buffer:         req.Buffer,
+		Cursor:         req.Cursor,
+		Keystrokes:     req.Keystrokes,
+		KeystrokeCount: keystrokes,
+		EnteredInsert:  req.InsertEntered,
+		Elapsed:        elapsed,
+	}, buildChallengeSpec(c))
+
+	// Nothing was typed: a changed buffer can't be honest, and an unchanged
+	// one earns nothing for efficiency
+	if keystrokes == 0 && result.Success {
+		if req.Buffer != c.InitialBuffer {
+			result = vim.ValidationResult{Message: "No keystrokes recorded"}
+		}
+		result.Efficiency = 0
+	}
+
+	verdict := &nvim.ValidateChallengeResult{
+		RequestID: req.RequestID,
+		Success:   result.Success,
+		Message:   result.Message,
+	}
+	if result.Success {
+		verdict.Efficiency = result.Efficiency
+		verdict.SpeedBonus = issued.economy.CalculateSpeedBonus(int(elapsed.Milliseconds()), c.ParTime()*1000)
+		verdict.GoldEarned = issued.economy.CalculateChallengeGold(
+			c.GoldBase, c.Difficulty, verdict.Efficiency, verdict.SpeedBonus)
+		if v.optimal != nil {
+			verdict.Optimal, _ = v.optimal.Lookup(c.ID)
+		}
+	}
+
+	issued.verdict = verdict
+	return verdict
+}
+
+// Resolve returns the stored verdict for a request and forgets it.
+// registered reports whether the request was issued through the verifier;
+// a registered request with no verdict has not been validated.
+func (v *ChallengeVerifier) Resolve(requestID string) (*nvim.ValidateChallengeResult, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	issued, ok := v.issued[requestID]
+	if !ok {
+		return nil, false
+	}
+	delete(v.issued, requestID)
+	return issued.verdict, true
+}
+
+// Forget drops a registered request without resolving it.
+func (v *ChallengeVerifier) Forget(requestID string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.issued, requestID)
+}
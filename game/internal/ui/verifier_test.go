@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/nvim"
+)
+
+// testVerifierChallenge returns a small exact_match challenge.
+func testVerifierChallenge() *engine.Challenge {
+	return &engine.Challenge{
+		ID:             "test_delete_word",
+		Name:           "Delete word",
+		Difficulty:     1,
+		InitialBuffer:  "hello cruel world",
+		ExpectedBuffer: "hello world",
+		ValidationType: "exact_match",
+		ParKeystrokes:  4,
+		GoldBase:       20,
+	}
+}
+
+// newTestVerifier creates a verifier with a controllable clock.
+func newTestVerifier(clock *time.Time) *ChallengeVerifier {
+	v := NewChallengeVerifier()
+	v.now = func() time.Time { return *clock }
+	return v
+}
+
+func TestVerifierUnknownRequest(t *testing.T) {
+	v := NewChallengeVerifier()
+	if verdict := v.Verify(&nvim.ValidateChallengeRequest{RequestID: "missing"}); verdict != nil {
+		t.Errorf("Expected nil verdict for unknown request, got %+v", verdict)
+	}
+}
+
+func TestVerifierVerdict(t *testing.T) {
+	economy := engine.DefaultEconomyConfig()
+	challenge := testVerifierChallenge()
+
+	tests := []struct {
+		name       string
+		buffer     string
+		keystrokes []string
+		count      int
+		elapsed    time.Duration
+		success    bool
+		efficiency float64
+	}{
+		{"at par, slow", "hello world", []string{"w", "d", "a", "w"}, 4, 10 * time.Second, true, 1.0},
+		{"count lower than key log", "hello world", []string{"w", "d", "a", "w", "u", "x", "x", "x"}, 1, 10 * time.Second, true, 0.5},
+		{"fast finish", "hello world", nil, 4, 2500 * time.Millisecond, true, 1.0},
+		{"wrong buffer", "hello cruel world", nil, 4, time.Second, false, 0},
+		{"no keystrokes", "hello world", nil, 0, 10 * time.Second, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := time.Now()
+			v := newTestVerifier(&clock)
+			v.Register("challenge_1", challenge, economy)
+			clock = clock.Add(tt.elapsed)
+
+			verdict := v.Verify(&nvim.ValidateChallengeRequest{
+				RequestID:      "challenge_1",
+				Buffer:         tt.buffer,
+				Keystrokes:     tt.keystrokes,
+				KeystrokeCount: tt.count,
+				TimeMs:         1, // Client-reported time is ignored
+			})
+			if verdict == nil {
+				t.Fatal("Expected verdict, got nil")
+			}
+			if verdict.Success != tt.success {
+				t.Fatalf("Expected success=%v, got %v (%s)", tt.success, verdict.Success, verdict.Message)
+			}
+			if !tt.success {
+				if verdict.GoldEarned != 0 {
+					t.Errorf("Expected no gold on failure, got %d", verdict.GoldEarned)
+				}
+				return
+			}
+			if verdict.Efficiency != tt.efficiency {
+				t.Errorf("Expected efficiency %.2f, got %.2f", tt.efficiency, verdict.Efficiency)
+			}
+			wantBonus := economy.CalculateSpeedBonus(int(tt.elapsed.Milliseconds()), challenge.ParTime()*1000)
+			if verdict.SpeedBonus != wantBonus {
+				t.Errorf("Expected speed bonus %.2f, got %.2f", wantBonus, verdict.SpeedBonus)
+			}
+			wantGold := economy.CalculateChallengeGold(challenge.GoldBase, challenge.Difficulty, tt.efficiency, wantBonus)
+			if verdict.GoldEarned != wantGold {
+				t.Errorf("Expected gold %d, got %d", wantGold, verdict.GoldEarned)
+			}
+		})
+	}
+}
+
+func TestVerifierZeroKeystrokesEarnNoEfficiency(t *testing.T) {
+	v := NewChallengeVerifier()
+	v.Register("challenge_1", &engine.Challenge{
+		ID:             "test_stay",
+		InitialBuffer:  "hello world",
+		ExpectedCursor: []int{0, 0},
+		ValidationType: "cursor_position",
+		ParKeystrokes:  2,
+		GoldBase:       20,
+	}, engine.DefaultEconomyConfig())
+
+	verdict := v.Verify(&nvim.ValidateChallengeRequest{RequestID: "challenge_1", Buffer: "hello world", Cursor: []int{0, 0}})
+	if verdict == nil || !verdict.Success || verdict.Efficiency != 0 {
+		t.Fatalf("Expected a success with efficiency 0, got %+v", verdict)
+	}
+}
+
+func TestVerifierRegisterReplacesPrevious(t *testing.T) {
+	v := NewChallengeVerifier()
+	v.Register("challenge_1", testVerifierChallenge(), engine.DefaultEconomyConfig())
+	v.Register("challenge_2", testVerifierChallenge(), engine.DefaultEconomyConfig())
+
+	if _, registered := v.Resolve("challenge_1"); registered {
+		t.Error("Expected older registration to be dropped")
+	}
+	if _, registered := v.Resolve("challenge_2"); !registered {
+		t.Error("Expected latest registration to be kept")
+	}
+	if _, registered := v.Resolve("challenge_2"); registered {
+		t.Error("Expected Resolve to forget the request")
+	}
+}
+
+// TestChallengeResultUsesVerdict tests that the game's verdict overrides the
+// success and gold reported by Neovim.
+func TestChallengeResultUsesVerdict(t *testing.T) {
+	model := newTestModel()
+	model.NvimMode = true
+	model.NvimRPC = &MockRPCClient{}
+	model.NvimChallengeID = "challenge_1"
+	model.ChallengeVerifier.Register("challenge_1", testVerifierChallenge(), model.Game.Economy)
+	model.Game.StartChallengeWaiting()
+
+	// Validation goes through the original pointer, as the RPC handler does
+	verdict := model.HandleValidateChallenge(&nvim.ValidateChallengeRequest{
+		RequestID:      "challenge_1",
+		Buffer:         "hello world",
+		KeystrokeCount: 4,
+	})
+	if verdict == nil || !verdict.Success {
+		t.Fatalf("Expected successful verdict, got %+v", verdict)
+	}
+
+	initialGold := model.Game.Gold
+	model.ChallengeResultChan <- &nvim.ChallengeResult{
+		RequestID:  "challenge_1",
+		Success:    true,
+		GoldEarned: 9999,
+	}
+
+	newModel, _ := model.Update(TickMsg(time.Now()))
+	updated := newModel.(Model)
+
+	if updated.Game.Gold != initialGold+verdict.GoldEarned {
+		t.Errorf("Expected gold %d, got %d", initialGold+verdict.GoldEarned, updated.Game.Gold)
+	}
+}
+
+// TestChallengeResultWithoutVerdict tests that a registered challenge reported
+// as successful but never validated earns no gold.
+func TestChallengeResultWithoutVerdict(t *testing.T) {
+	model := newTestModel()
+	model.NvimMode = true
+	model.NvimRPC = &MockRPCClient{}
+	model.NvimChallengeID = "challenge_1"
+	model.ChallengeVerifier.Register("challenge_1", testVerifierChallenge(), model.Game.Economy)
+	model.Game.StartChallengeWaiting()
+
+	initialGold := model.Game.Gold
+	model.ChallengeResultChan <- &nvim.ChallengeResult{
+		RequestID:  "challenge_1",
+		Success:    true,
+		GoldEarned: 50,
+	}
+
+	newModel, _ := model.Update(TickMsg(time.Now()))
+	updated := newModel.(Model)
+
+	if updated.Game.Gold != initialGold {
+		t.Errorf("Expected no gold without a verdict, got %d (was %d)", updated.Game.Gold, initialGold)
+	}
+	if updated.Game.ChallengeActive {
+		t.Error("Expected challenge to end")
+	}
+}
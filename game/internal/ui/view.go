@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -22,6 +23,10 @@ func RenderGame(m *Model) string {
 	// HUD
 	b.WriteString(renderHUD(m))
 	b.WriteString("\n")
+	if m.Notification != nil {
+		b.WriteString(renderNotification(m.Notification))
+		b.WriteString("\n")
+	}
 
 	// Game grid
 	b.WriteString(renderGrid(m))
@@ -32,8 +37,17 @@ func RenderGame(m *Model) string {
 		b.WriteString(renderChallenge(m))
 		b.WriteString("\n")
 	} else {
-		// Shop
-		b.WriteString(renderShop(m))
+		// Next wave preview and shop
+		b.WriteString(renderWavePreview(m))
+		b.WriteString("\n")
+		switch {
+		case m.CategoryChoices != nil:
+			b.WriteString(renderCategoryPrompt(m))
+		case m.BranchPrompt:
+			b.WriteString(renderBranchPrompt(m))
+		default:
+			b.WriteString(renderShop(m))
+		}
 		b.WriteString("\n")
 	}
 
@@ -43,6 +57,14 @@ func RenderGame(m *Model) string {
 	return b.String()
 }
 
+// renderNotification renders a success or failure message.
+func renderNotification(n *Notification) string {
+	if n.IsSuccess {
+		return lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Render("✓ " + n.Message)
+	}
+	return lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).Render("✗ " + n.Message)
+}
+
 func renderTitle(_ *Model) string {
 	title := "═══════════════════════ KEYFORGE ═══════════════════════"
 	return TitleStyle.Render(title)
@@ -53,6 +75,9 @@ func renderHUD(m *Model) string {
 
 	// Wave info
 	waveInfo := WaveStyle.Render(fmt.Sprintf("Wave: %d/%d", g.Wave, g.TotalWaves))
+	if g.Endless {
+		waveInfo = WaveStyle.Render(fmt.Sprintf("Wave: %d/∞", g.Wave))
+	}
 
 	// Gold
 	goldInfo := GoldStyle.Render(fmt.Sprintf("💰 Gold: %d", g.Gold))
@@ -79,16 +104,102 @@ func renderHUD(m *Model) string {
 		// No special status display for these states
 	}
 
-	// Challenge hint when not in challenge
-	var challengeHint string
-	if g.State == engine.StatePlaying && !g.ChallengeActive {
-		challengeHint = HelpStyle.Render("  [Press c for challenge]")
+	if g.Building && g.State == engine.StatePlaying {
+		status = PausedStyle.Render("  [BUILD PHASE]")
+	}
+
+	// Playback position in a replay
+	var replayStatus string
+	if m.Player != nil {
+		replayStatus = PausedStyle.Render(renderReplayStatus(m))
 	}
 
-	hud := fmt.Sprintf("%s    %s    %s%s%s", waveInfo, goldInfo, healthInfo, status, challengeHint)
+	hud := fmt.Sprintf("%s    %s    %s%s%s", waveInfo, goldInfo, healthInfo, status, replayStatus)
+	if charges := renderChallengeCharges(m); charges != "" {
+		hud += "\n" + charges
+	}
+	if boss := g.ActiveBoss(); boss != nil {
+		hud += "\n" + renderBossBar(boss)
+	}
+	if abilities := renderAbilities(g); abilities != "" {
+		hud += "\n" + abilities
+	}
 	return HUDStyle.Render(hud)
 }
 
+// renderChallengeCharges shows the challenge charges and when the next one
+// comes, then the challenge [c] would start: its category, what it pays and
+// the ability it charges the tower at the cursor with.
+func renderChallengeCharges(m *Model) string {
+	g := m.Game
+	if m.Player != nil || g.State != engine.StatePlaying && !g.ChallengeActive {
+		return ""
+	}
+
+	var parts []string
+	if limit := g.ChallengeChargeCap(); limit > 0 {
+		charges := fmt.Sprintf("🎯 Challenges: %s%s %d/%d",
+			strings.Repeat("●", g.ChallengeCharges), strings.Repeat("○", limit-g.ChallengeCharges), g.ChallengeCharges, limit)
+		if next := g.NextChargeIn(); next > 0 {
+			charges += fmt.Sprintf(" (next in %.0fs)", math.Ceil(next))
+		}
+		parts = append(parts, ChallengeStyle.Render(charges))
+	}
+
+	if g.CanStartChallenge() {
+		hint := "[c] Challenge"
+		if preview, ok := challengePreview(g, m.ChallengeSelector, m.challengeDifficulty()); ok {
+			hint += fmt.Sprintf(": %s ~%dg", preview.Category, preview.Reward)
+		}
+		if tower := g.GetTowerAt(g.CursorX, g.CursorY); tower != nil {
+			hint += fmt.Sprintf(", charges %s %s", tower.Info().Symbol, tower.Info().Ability)
+		}
+		parts = append(parts, HelpStyle.Render(hint))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// renderAbilities lists the towers charged by challenges, with what their
+// ability does and how long it has left.
+func renderAbilities(g *engine.Game) string {
+	var items []string
+	for _, t := range g.Towers {
+		if t.AbilityLeft <= 0 {
+			continue
+		}
+		items = append(items, fmt.Sprintf("%s %s +%.0f%% (%.0fs)",
+			t.Info().Symbol, t.Ability, t.AbilityStrength*100, math.Ceil(t.AbilityLeft)))
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return ChallengeStyle.Render("⚡ Charged: " + strings.Join(items, "  "))
+}
+
+// bossPhaseLabels name boss phases in the HUD.
+var bossPhaseLabels = map[engine.BossPhaseKind]string{
+	engine.BossPhaseMinions:       "Minions",
+	engine.BossPhaseSpeedBurst:    "Speed burst",
+	engine.BossPhaseDisableTowers: "Towers disabled",
+	engine.BossPhaseShield:        "Shielded",
+	engine.BossPhaseShieldBroken:  "Shield broken",
+}
+
+// renderBossBar shows the health and phase of the boss on the field, and how
+// to break its shield.
+func renderBossBar(boss *engine.Boss) string {
+	e := boss.Enemy
+	bar := BossStyle.Render(fmt.Sprintf("%s %s: %d/%d ", e.Info().Symbol, e.Info().Name, e.Health, e.MaxHealth)) +
+		RenderHealthBar(e.Health, e.MaxHealth, 20)
+	if label, ok := bossPhaseLabels[boss.CurrentPhase()]; ok {
+		bar += BossStyle.Render("  Phase: " + label)
+	}
+	if boss.Shielded {
+		bar += ChallengeStyle.Render("  🛡 Complete a challenge to break the shield!")
+	}
+	return bar
+}
+
 func renderGrid(m *Model) string {
 	g := m.Game
 	var b strings.Builder
@@ -159,11 +270,19 @@ func populateGridEntities(grid [][]string, g *engine.Game) {
 	width := g.Width
 	height := g.Height
 
-	// Render path
-	for _, p := range g.Path {
-		x, y := p.IntPos()
-		if isInBounds(x, y, width, height) {
-			grid[y][x] = PathCellStyle.Render(PathChar)
+	// Render paths
+	for _, path := range g.AllPaths() {
+		for _, p := range path {
+			x, y := p.IntPos()
+			if !isInBounds(x, y, width, height) {
+				continue
+			}
+			if g.Maze != nil && !g.IsOnPath(x, y) {
+				// Open field: the route moves with the towers
+				grid[y][x] = RouteCellStyle.Render(RouteChar)
+			} else {
+				grid[y][x] = PathCellStyle.Render(PathChar)
+			}
 		}
 	}
 
@@ -258,6 +377,13 @@ func renderTower(tower *entities.Tower) string {
 		char = info.Symbol
 	}
 
+	if branch, ok := tower.ChosenBranch(); ok {
+		char = branch.Symbol
+	}
+	if tower.Disabled() {
+		style = TowerDisabledStyle
+	}
+
 	// TODO: Add level indicator when upgrade system is implemented
 	_ = tower.Level // Reserved for future use
 
@@ -290,6 +416,12 @@ func renderEnemy(enemy *entities.Enemy) string {
 	case entities.EnemyBoss:
 		style = EnemyBossStyle
 		char = EnemyBossChar
+	case entities.EnemyMoth:
+		style = EnemyMothStyle
+		char = EnemyMothChar
+	case entities.EnemyDragon:
+		style = EnemyDragonStyle
+		char = EnemyDragonChar
 	default:
 		style = EnemyBugStyle
 		char = EnemyCharASCII
@@ -310,10 +442,14 @@ func renderShop(m *Model) string {
 
 	for i, towerType := range towers {
 		info := entities.TowerTypes[towerType]
-		canAfford := g.Gold >= info.Cost
+		cost := g.TowerCost(towerType)
+		canAfford := g.Gold >= cost
 		isSelected := g.SelectedTower == towerType
 
-		text := fmt.Sprintf("[%d] %s %s (%dg)", i+1, info.Symbol, info.Name, info.Cost)
+		text := fmt.Sprintf("[%d] %s %s (%dg)", i+1, info.Symbol, info.Name, cost)
+		if !info.AntiAir {
+			text += " ground only"
+		}
 
 		var style lipgloss.Style
 		if isSelected {
@@ -330,19 +466,68 @@ func renderShop(m *Model) string {
 	// Upgrade option if on tower
 	tower := g.GetTowerAt(g.CursorX, g.CursorY)
 	if tower != nil && tower.CanUpgrade() {
-		cost := tower.UpgradeCost()
+		cost := g.UpgradeCost(tower)
 		canAfford := g.Gold >= cost
 		text := fmt.Sprintf("[u] Upgrade (%dg)", cost)
+		if tower.NeedsBranch() {
+			text = fmt.Sprintf("[u] Specialise (from %dg)", cost)
+		}
 		if canAfford {
 			items = append(items, ShopItemStyle.Render(text))
 		} else {
 			items = append(items, ShopItemDisabledStyle.Render(text))
 		}
 	}
+	if tower != nil {
+		items = append(items, ShopItemStyle.Render(fmt.Sprintf("[x] Sell (+%dg)", g.SellValue(tower))))
+	}
+
+	return strings.Join(items, "  ")
+}
 
+// renderBranchPrompt lists the specialisations the tower at the cursor can
+// take, in place of the shop.
+func renderBranchPrompt(m *Model) string {
+	g := m.Game
+	tower := g.GetTowerAt(g.CursorX, g.CursorY)
+	if tower == nil {
+		return ""
+	}
+	info := tower.Info()
+	items := []string{ChallengeStyle.Render("Specialise " + info.Name + ":")}
+	for i, branch := range info.Branches {
+		cost := g.BranchCost(branch)
+		text := fmt.Sprintf("[%d] %s %s (%dg) – %s", i+1, branch.Symbol, branch.Name, cost, branch.Description)
+		style := ShopItemStyle
+		if g.Gold < cost {
+			style = ShopItemDisabledStyle
+		}
+		items = append(items, style.Render(text))
+	}
+	items = append(items, HelpStyle.Render("[Esc] Cancel"))
 	return strings.Join(items, "  ")
 }
 
+// renderCategoryPrompt lists the challenge categories of the tower at the
+// cursor, one per line, with how many challenges each has and the player's
+// accuracy in it.
+func renderCategoryPrompt(m *Model) string {
+	items := []string{ChallengeStyle.Render("Challenge category:")}
+	for i, c := range m.CategoryChoices {
+		accuracy := "–"
+		if c.Attempts > 0 {
+			accuracy = fmt.Sprintf("%.0f%%", c.Accuracy()*100)
+		}
+		style := ShopItemStyle
+		if c.Count == 0 {
+			style = ShopItemDisabledStyle
+		}
+		items = append(items, style.Render(fmt.Sprintf("[%d] %s (%d, %s)", i+1, c.Category, c.Count, accuracy)))
+	}
+	items = append(items, HelpStyle.Render("[c] Any  [Esc] Cancel"))
+	return strings.Join(items, "\n")
+}
+
 func renderChallenge(m *Model) string {
 	c := m.CurrentChallenge
 	if c == nil {
@@ -578,20 +763,66 @@ func renderModeLine(e *vim.Editor, c *engine.Challenge) string {
 		parts = append(parts, HelpStyle.Render(state.Count+state.PendingCmd))
 	}
 
-	// Keystroke count and reward/par
+	// Keystroke count (against the hard limit, if any) and reward/par
+	keys := fmt.Sprintf("Keys: %d", e.KeystrokeCount)
+	if c.MaxKeystrokes > 0 {
+		keys = fmt.Sprintf("Keys: %d/%d", e.KeystrokeCount, c.MaxKeystrokes)
+	}
 	parts = append(parts,
-		HelpStyle.Render(fmt.Sprintf("Keys: %d", e.KeystrokeCount)),
+		HelpStyle.Render(keys),
 		GoldStyle.Render(fmt.Sprintf("Reward: %dg | Par: %d", c.GoldBase, c.ParKeystrokes)),
 	)
 
-	return strings.Join(parts, "  ")
+	line := strings.Join(parts, "  ")
+	if rules := renderRestrictions(c); rules != "" {
+		line += "\n" + rules
+	}
+	if e.Violation != "" {
+		line += "\n" + lipgloss.NewStyle().Foreground(ColorDanger).Bold(true).
+			Render("✗ "+e.Violation+" — [Ctrl+S] to submit")
+	}
+	return line
+}
+
+// renderRestrictions describes a challenge's golf rules, or "" if there are none.
+func renderRestrictions(c *engine.Challenge) string {
+	var rules []string
+	if len(c.ForbiddenKeys) > 0 {
+		rules = append(rules, "Forbidden: "+strings.Join(c.ForbiddenKeys, " "))
+	}
+	if c.TimeLimit > 0 {
+		rules = append(rules, fmt.Sprintf("Time limit: %ds", c.TimeLimit))
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return HelpStyle.Render(strings.Join(rules, "  |  "))
+}
+
+// renderReplayStatus shows playback speed and position.
+func renderReplayStatus(m *Model) string {
+	p := m.Player
+	status := fmt.Sprintf("  [REPLAY %gx  tick %d/%d", p.Speed(), m.Game.Tick, p.Replay.FinalTick)
+	switch {
+	case p.Done(m.Game):
+		status += "  END"
+	case p.Paused:
+		status += "  PAUSED"
+	}
+	return status + "]"
 }
 
 func renderHelp(m *Model) string {
+	if m.Player != nil {
+		return HelpStyle.Render("[space] Pause  [.] Step  [+/-] Speed  [q] Quit replay")
+	}
 	if m.Game.State == engine.StateChallengeActive {
 		return HelpStyle.Render("[Ctrl+S] Submit  [Esc] Cancel  |  Use vim commands to edit")
 	}
-	help := "[hjkl/arrows] Move  [space] Place tower  [c] Challenge  [p] Pause  [q] Quit"
+	if m.Game.Building {
+		return HelpStyle.Render("[hjkl/arrows] Move  [space] Place tower  [U] Undo  [Ctrl+R] Redo  [n] Start wave  [c] Challenge  [q] Quit")
+	}
+	help := "[hjkl/arrows] Move  [space] Place tower  [c] Challenge  [n] Next wave  [p] Pause  [q] Quit"
 	return HelpStyle.Render(help)
 }
 
@@ -608,10 +839,17 @@ func RenderGameOver(m *Model) string {
 	b.WriteString(GameOverStyle.Render("  ╚═════╝ ╚═╝  ╚═╝╚═╝     ╚═╝╚══════╝     ╚═════╝   ╚═══╝  ╚══════╝╚═╝  ╚═╝ \n"))
 	b.WriteString("\n")
 
-	b.WriteString(fmt.Sprintf("  Wave reached: %d/%d\n", m.Game.Wave, m.Game.TotalWaves))
+	if m.Game.Endless {
+		b.WriteString(renderEndlessResult(m))
+	} else {
+		b.WriteString(fmt.Sprintf("  Wave reached: %d/%d\n", m.Game.Wave, m.Game.TotalWaves))
+	}
 	b.WriteString(fmt.Sprintf("  Towers built: %d\n", len(m.Game.Towers)))
+	b.WriteString(renderReport(m))
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("  Press [r] to restart, [m] for menu, or [q] to quit\n"))
+	b.WriteString(renderReplaySaved(m))
+	b.WriteString(renderReportSaved(m))
+	b.WriteString(HelpStyle.Render(endGameHelp(m, "  Press [r] to restart, [e] to export the report, [m] for menu, or [q] to quit\n")))
 
 	return b.String()
 }
@@ -633,8 +871,27 @@ func RenderVictory(m *Model) string {
 	b.WriteString(fmt.Sprintf("  Final gold: %d\n", m.Game.Gold))
 	b.WriteString(fmt.Sprintf("  Final health: %d/%d\n", m.Game.Health, m.Game.MaxHealth))
 	b.WriteString(fmt.Sprintf("  Towers built: %d\n", len(m.Game.Towers)))
+	b.WriteString(renderReport(m))
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("  Press [r] to play again, [m] for menu, or [q] to quit\n"))
+	b.WriteString(renderReplaySaved(m))
+	b.WriteString(renderReportSaved(m))
+	b.WriteString(HelpStyle.Render(endGameHelp(m, "  Press [r] to play again, [e] to export the report, [m] for menu, or [q] to quit\n")))
 
 	return b.String()
 }
+
+// renderReplaySaved tells the player where the replay of this game went.
+func renderReplaySaved(m *Model) string {
+	if m.LastReplayPath == "" {
+		return ""
+	}
+	return fmt.Sprintf("  Replay saved: %s\n", m.LastReplayPath)
+}
+
+// endGameHelp returns the end screen key help, which is different in a replay.
+func endGameHelp(m *Model, help string) string {
+	if m.Player != nil {
+		return fmt.Sprintf("  End of replay (tick %d). Press [q] to quit\n", m.Game.Tick)
+	}
+	return help
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+// waveCount is how many enemies of one type a wave spawns.
+type waveCount struct {
+	Type  entities.EnemyType
+	Count int
+}
+
+// countWave tallies a wave's spawns by enemy type, in order of first appearance.
+func countWave(wave engine.Wave) []waveCount {
+	var counts []waveCount
+	index := make(map[entities.EnemyType]int)
+	for _, spawn := range wave.Spawns {
+		i, ok := index[spawn.Type]
+		if !ok {
+			i = len(counts)
+			index[spawn.Type] = i
+			counts = append(counts, waveCount{Type: spawn.Type})
+		}
+		counts[i].Count++
+	}
+	return counts
+}
+
+// renderWavePreview lists the enemies of the next wave, how the director
+// has adjusted it and, between waves, what calling it early pays or how to
+// end the build phase.
+func renderWavePreview(m *Model) string {
+	g := m.Game
+	wave, ok := g.NextWave()
+	if !ok {
+		return WaveStyle.Render("Final wave") + HelpStyle.Render("  Survive it to win!")
+	}
+
+	var parts []string
+	for _, c := range countWave(wave) {
+		info := entities.EnemyTypes[c.Type]
+		parts = append(parts, fmt.Sprintf("%s %s ×%d", info.Symbol, info.Name, c.Count))
+	}
+	preview := WaveStyle.Render(fmt.Sprintf("Next wave %d:", g.UpcomingWave())) + " " + strings.Join(parts, "  ")
+	if d := g.Director; d != nil && d.Pressure != 0 {
+		preview += HelpStyle.Render(fmt.Sprintf("  🎚 Director: health %+.0f%%, spawn delay %+.0f%%",
+			(d.HealthMult()-1)*100, (d.DelayMult()-1)*100))
+	}
+	switch {
+	case g.Building:
+		preview += GoldStyle.Render("  [n] Start wave")
+	case g.CanCallWave():
+		bonus := g.Economy.CalculateEarlyCallBonus(g.WaveCountdown)
+		preview += GoldStyle.Render(fmt.Sprintf("  [n] Call now +%dg", bonus))
+	}
+	return preview
+}
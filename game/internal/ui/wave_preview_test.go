@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/keyforge/keyforge/internal/engine"
+	"github.com/keyforge/keyforge/internal/entities"
+)
+
+func TestCountWave(t *testing.T) {
+	wave := engine.Wave{Spawns: []engine.Spawn{
+		{Type: entities.EnemyBug}, {Type: entities.EnemyGremlin}, {Type: entities.EnemyBug},
+	}}
+	counts := countWave(wave)
+	if len(counts) != 2 || counts[0] != (waveCount{entities.EnemyBug, 2}) || counts[1] != (waveCount{entities.EnemyGremlin, 1}) {
+		t.Errorf("countWave() = %+v, want 2 bugs then 1 gremlin", counts)
+	}
+}
+
+func TestWavePreviewAndEarlyCall(t *testing.T) {
+	m := newTestModel()
+	m.Game.Gold = 0
+	preview := renderWavePreview(&m)
+	if !strings.Contains(preview, "Next wave 2") || strings.Contains(preview, "[n]") {
+		t.Fatalf("Preview during a wave = %q, want wave 2 without the call hint", preview)
+	}
+
+	m.Game.WaveComplete = true
+	m.Game.WaveCountdown = 3.0
+	if preview := renderWavePreview(&m); !strings.Contains(preview, "[n] Call now +15g") {
+		t.Errorf("Preview between waves = %q, want the early call bonus", preview)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = updated.(Model)
+	if m.Game.Wave != 2 || m.Game.Gold != 15 {
+		t.Errorf("After [n]: wave %d, gold %d; want wave 2 and 15 gold", m.Game.Wave, m.Game.Gold)
+	}
+}
@@ -154,9 +154,8 @@ func (b *Buffer) DeleteRange(start, end Position) string {
 	// Delete from start to end of first line
 	firstLine := b.GetLine(start.Line)
 	firstRunes := []rune(firstLine)
-	if start.Col < len(firstRunes) {
-		deleted.WriteString(string(firstRunes[start.Col:]))
-	}
+	start.Col = min(start.Col, len(firstRunes))
+	deleted.WriteString(string(firstRunes[start.Col:]))
 	deleted.WriteString("\n")
 
 	// Delete middle lines
@@ -169,9 +168,8 @@ func (b *Buffer) DeleteRange(start, end Position) string {
 	// Handle last line
 	lastLine := b.GetLine(start.Line + 1)
 	lastRunes := []rune(lastLine)
-	if end.Col <= len(lastRunes) {
-		deleted.WriteString(string(lastRunes[:end.Col]))
-	}
+	end.Col = min(end.Col, len(lastRunes))
+	deleted.WriteString(string(lastRunes[:end.Col]))
 
 	// Join the remaining parts
 	newFirst := string(firstRunes[:start.Col]) + string(lastRunes[end.Col:])
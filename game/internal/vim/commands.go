@@ -8,9 +8,25 @@ import (
 
 // HandleKey processes a single keypress and returns if more input is needed.
 func (e *Editor) HandleKey(key string) bool {
+	// A broken restriction locks the editor until the challenge is submitted
+	if e.Violation != "" {
+		return false
+	}
 	e.KeystrokeCount++
 	e.StatusMessage = "" // Clear status on new key
 
+	if !e.checkRestrictions(key) {
+		return false
+	}
+	defer e.checkInsertRestriction()
+	// Outside insert mode the cursor stays on a character, even after a motion
+	// or paste that lands past the end of the line
+	defer func() {
+		if e.Mode != ModeInsert {
+			e.Cursor = e.clampPosition(e.Cursor)
+		}
+	}()
+
 	switch e.Mode {
 	case ModeInsert:
 		return e.handleInsertKey(key)
@@ -104,6 +120,16 @@ func (e *Editor) handleInsertKey(key string) bool {
 	}
 }
 
+// findRange returns the inclusive range covered by a find motion, which may
+// move backward (F, T, and , after f).
+func findRange(from, to Position) Range {
+	if from.Col > to.Col {
+		from, to = to, from
+	}
+	to.Col++ // Make end inclusive
+	return Range{Start: from, End: to}
+}
+
 func (e *Editor) handleNormalKey(key string) bool {
 	// Handle waiting states first
 	switch e.WaitingFor {
@@ -114,12 +140,7 @@ func (e *Editor) handleNormalKey(key string) bool {
 			newPos := e.ExecuteFindMotion(e.LastFind.Forward, e.LastFind.Till, r, e.getCount())
 			if e.PendingOp != OpNone {
 				// Operator pending
-				rng := Range{Start: e.Cursor, End: newPos}
-				if rng.Start.Col > rng.End.Col {
-					rng.Start, rng.End = rng.End, rng.Start
-				}
-				rng.End.Col++ // Make end inclusive
-				e.ExecuteOperator(e.PendingOp, rng)
+				e.ExecuteOperator(e.PendingOp, findRange(e.Cursor, newPos))
 			} else {
 				e.Cursor = newPos
 			}
@@ -288,8 +309,7 @@ func (e *Editor) handleNormalKey(key string) bool {
 	case ";":
 		newPos := e.RepeatFind(false, e.getCount())
 		if e.PendingOp != OpNone {
-			rng := Range{Start: e.Cursor, End: Position{Line: newPos.Line, Col: newPos.Col + 1}}
-			e.ExecuteOperator(e.PendingOp, rng)
+			e.ExecuteOperator(e.PendingOp, findRange(e.Cursor, newPos))
 		} else {
 			e.Cursor = newPos
 		}
@@ -298,8 +318,7 @@ func (e *Editor) handleNormalKey(key string) bool {
 	case ",":
 		newPos := e.RepeatFind(true, e.getCount())
 		if e.PendingOp != OpNone {
-			rng := Range{Start: e.Cursor, End: Position{Line: newPos.Line, Col: newPos.Col + 1}}
-			e.ExecuteOperator(e.PendingOp, rng)
+			e.ExecuteOperator(e.PendingOp, findRange(e.Cursor, newPos))
 		} else {
 			e.Cursor = newPos
 		}
@@ -1,5 +1,7 @@
 package vim
 
+import "time"
+
 // Mode represents vim editing mode.
 type Mode int
 
@@ -94,6 +96,11 @@ type Editor struct {
 	// Keystroke tracking for efficiency scoring
 	KeystrokeCount int
 
+	// Challenge restrictions; Violation is set once a rule is broken
+	Restrictions Restrictions
+	StartedAt    time.Time
+	Violation    string
+
 	// Status message for display
 	StatusMessage string
 }
@@ -205,6 +212,31 @@ func (e *Editor) Redo() bool {
 	return true
 }
 
+// Clone creates a deep copy of the editor, including registers and undo history.
+func (e *Editor) Clone() *Editor {
+	c := *e
+	c.Buffer = e.Buffer.Clone()
+	c.CountStack = append([]int(nil), e.CountStack...)
+	c.Registers = make(map[rune]string, len(e.Registers))
+	for r, text := range e.Registers {
+		c.Registers[r] = text
+	}
+	c.UndoStack = cloneSnapshots(e.UndoStack)
+	c.RedoStack = cloneSnapshots(e.RedoStack)
+	c.Restrictions.ForbiddenKeys = append([]string(nil), e.Restrictions.ForbiddenKeys...)
+	return &c
+}
+
+// cloneSnapshots deep-copies undo/redo snapshots; Undo and Redo adopt the
+// snapshot's buffer, so sharing them between editors is not safe.
+func cloneSnapshots(snapshots []Snapshot) []Snapshot {
+	out := make([]Snapshot, len(snapshots))
+	for i, s := range snapshots {
+		out[i] = Snapshot{Buffer: s.Buffer.Clone(), Cursor: s.Cursor}
+	}
+	return out
+}
+
 // resetCommandState resets the command parsing state.
 func (e *Editor) resetCommandState() {
 	e.PendingOp = OpNone
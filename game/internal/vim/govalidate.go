@@ -0,0 +1,169 @@
+package vim
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// errNoImporter makes the type checker treat every import as unresolved.
+// Only "declared and not used" errors are of interest, so imports are never loaded.
+var errNoImporter = errors.New("imports are not resolved during validation")
+
+type nullImporter struct{}
+
+func (nullImporter) Import(string) (*types.Package, error) {
+	return nil, errNoImporter
+}
+
+// parseGoSource parses a Go challenge buffer. Snippets without a package
+// clause are parsed as if they were in package main.
+func parseGoSource(src string) (*token.FileSet, *ast.File, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "challenge.go", src, parser.SkipObjectResolution)
+	if err == nil {
+		return fset, file, nil
+	}
+	if strings.HasPrefix(strings.TrimSpace(src), "package ") {
+		return nil, nil, err
+	}
+
+	fset = token.NewFileSet()
+	file, retryErr := parser.ParseFile(fset, "challenge.go", "package main\n"+src, parser.SkipObjectResolution)
+	if retryErr != nil {
+		return nil, nil, err
+	}
+	return fset, file, nil
+}
+
+// checkGo runs the go/ast based validation types.
+func checkGo(content string, spec *ChallengeSpec) (bool, string) {
+	fset, file, err := parseGoSource(content)
+	if err != nil {
+		return false, "Go code does not parse: " + err.Error()
+	}
+
+	switch spec.ValidationType {
+	case "go_parses":
+		return true, ""
+
+	case "go_function_signature":
+		return checkGoFunction(file, spec.FunctionName, spec.Signature)
+
+	case "go_identifier_renamed":
+		return checkGoRenamed(file, spec.OldName, spec.NewName)
+
+	case "go_no_unused_vars":
+		return checkGoUnusedVars(fset, file)
+	}
+
+	return false, "Unknown Go validation: " + spec.ValidationType
+}
+
+// checkGoFunction looks for a function or method declaration by name. If
+// signature is set (e.g. "(int, int) int"), parameter and result types must match.
+func checkGoFunction(file *ast.File, name, signature string) (bool, string) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != name {
+			continue
+		}
+		if signature == "" {
+			return true, ""
+		}
+		got := goSignature(fn.Type)
+		if normalizeSignature(got) == normalizeSignature(signature) {
+			return true, ""
+		}
+		return false, "Function " + name + " has signature " + got + ", want " + signature
+	}
+	return false, "Function " + name + " not found"
+}
+
+// goSignature renders a function type as "(T1, T2) R" without parameter names.
+func goSignature(ft *ast.FuncType) string {
+	params := fieldTypes(ft.Params)
+	sig := "(" + strings.Join(params, ", ") + ")"
+
+	results := fieldTypes(ft.Results)
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// fieldTypes lists one type per parameter, expanding grouped names (a, b int).
+func fieldTypes(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+	var out []string
+	for _, f := range fields.List {
+		typ := types.ExprString(f.Type)
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for range n {
+			out = append(out, typ)
+		}
+	}
+	return out
+}
+
+func normalizeSignature(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// checkGoRenamed succeeds when no identifier named oldName remains and newName
+// is used. Comments and string literals are not identifiers, so they don't count.
+func checkGoRenamed(file *ast.File, oldName, newName string) (bool, string) {
+	var oldCount, newCount int
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			switch id.Name {
+			case oldName:
+				oldCount++
+			case newName:
+				newCount++
+			}
+		}
+		return true
+	})
+
+	if oldCount > 0 {
+		return false, "Identifier " + oldName + " is still used"
+	}
+	if newCount == 0 {
+		return false, "Identifier " + newName + " not found"
+	}
+	return true, ""
+}
+
+// checkGoUnusedVars type-checks the file and reports unused local variables.
+func checkGoUnusedVars(fset *token.FileSet, file *ast.File) (bool, string) {
+	var unused []string
+	conf := types.Config{
+		Importer: nullImporter{},
+		Error: func(err error) {
+			var typeErr types.Error
+			if errors.As(err, &typeErr) && strings.Contains(typeErr.Msg, "declared and not used") {
+				unused = append(unused, typeErr.Msg)
+			}
+		},
+	}
+	// Errors are collected above; other type errors (unresolved imports) are ignored.
+	_, _ = conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+
+	if len(unused) > 0 {
+		return false, strings.Join(unused, "; ")
+	}
+	return true, ""
+}
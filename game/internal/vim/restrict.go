@@ -0,0 +1,119 @@
+package vim
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ForbidInsertMode in ForbiddenKeys forbids entering insert mode by any key.
+const ForbidInsertMode = "insert_mode"
+
+// Restrictions are the "golf" rules of a challenge.
+type Restrictions struct {
+	// ForbiddenKeys uses Vim key notation ("h", "<Up>", "<C-r>") plus ForbidInsertMode.
+	ForbiddenKeys []string
+	MaxKeystrokes int           // 0 = unlimited
+	TimeLimit     time.Duration // 0 = unlimited
+}
+
+// IsZero reports whether no restrictions apply.
+func (r Restrictions) IsZero() bool {
+	return len(r.ForbiddenKeys) == 0 && r.MaxKeystrokes == 0 && r.TimeLimit == 0
+}
+
+// forbids reports whether the key (in Vim notation) is forbidden.
+func (r Restrictions) forbids(key string) bool {
+	return slices.ContainsFunc(r.ForbiddenKeys, func(k string) bool {
+		return strings.EqualFold(k, key) && (len(k) > 1 || k == key)
+	})
+}
+
+// forbidsInsert reports whether entering insert mode is forbidden.
+func (r Restrictions) forbidsInsert() bool {
+	return slices.Contains(r.ForbiddenKeys, ForbidInsertMode)
+}
+
+// keyViolation checks a single key against the restrictions.
+func (r Restrictions) keyViolation(key string, keystrokes int) string {
+	if r.forbids(key) {
+		return "Forbidden key: " + key
+	}
+	return r.countViolation(keystrokes)
+}
+
+// countViolation checks a keystroke count against the keystroke limit.
+func (r Restrictions) countViolation(keystrokes int) string {
+	if r.MaxKeystrokes > 0 && keystrokes > r.MaxKeystrokes {
+		return fmt.Sprintf("Keystroke limit exceeded (%d max)", r.MaxKeystrokes)
+	}
+	return ""
+}
+
+// timeViolation checks elapsed time against the time limit.
+func (r Restrictions) timeViolation(elapsed time.Duration) string {
+	if r.TimeLimit > 0 && elapsed > r.TimeLimit {
+		return fmt.Sprintf("Time limit exceeded (%s max)", r.TimeLimit)
+	}
+	return ""
+}
+
+// Restrict applies restrictions to the editor and starts its clock.
+func (e *Editor) Restrict(r Restrictions) {
+	e.Restrictions = r
+	e.StartedAt = time.Now()
+	e.Violation = ""
+}
+
+// checkRestrictions enforces restrictions before a key is handled.
+// Once violated, the editor stops accepting input.
+func (e *Editor) checkRestrictions(key string) bool {
+	if e.Restrictions.IsZero() {
+		return true
+	}
+	violation := e.Restrictions.keyViolation(KeyNotation(key), e.KeystrokeCount)
+	if violation == "" && !e.StartedAt.IsZero() {
+		violation = e.Restrictions.timeViolation(time.Since(e.StartedAt))
+	}
+	if violation != "" {
+		e.Violation = violation
+		e.StatusMessage = violation
+		return false
+	}
+	return true
+}
+
+// checkInsertRestriction flags entering insert mode when it is forbidden.
+func (e *Editor) checkInsertRestriction() {
+	if e.Mode == ModeInsert && e.Restrictions.forbidsInsert() {
+		e.Violation = "Insert mode is not allowed"
+		e.StatusMessage = e.Violation
+		e.EnterNormalMode()
+	}
+}
+
+// KeyNotation converts an editor key name to Vim key notation, matching what
+// Neovim reports (e.g. "Escape" -> "<Esc>", "ctrl+r" -> "<C-r>").
+func KeyNotation(key string) string {
+	switch key {
+	case "Escape":
+		return "<Esc>"
+	case "Enter":
+		return "<CR>"
+	case "Backspace":
+		return "<BS>"
+	case "Delete":
+		return "<Del>"
+	case "Tab":
+		return "<Tab>"
+	case " ":
+		return "<Space>"
+	case "Up", "Down", "Left", "Right":
+		return "<" + key + ">"
+	}
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "<C-" + rest + ">"
+	}
+	return key
+}
@@ -0,0 +1,431 @@
+package vim
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrNoSolution is returned when no key sequence within the search bounds
+// passes validation.
+var ErrNoSolution = errors.New("no solution within search bounds")
+
+// SolverConfig bounds the optimal keystroke search.
+type SolverConfig struct {
+	// Vocabulary is the set of keys tried in normal, visual and operator-pending mode.
+	Vocabulary []string
+	// Words are typed as a unit in insert mode, costing one keystroke per rune.
+	// Nil derives them from the spec (see WordsForSpec).
+	Words []string
+	// MaxKeystrokes is the longest sequence considered.
+	MaxKeystrokes int
+	// MaxStates caps the number of editor states explored.
+	MaxStates int
+}
+
+// defaultVocabulary covers the common motions, operators, insert entries and
+// text objects. Keys that rarely shorten a solution (undo, visual mode, ; and ,)
+// are left out to keep the search tractable; pass them in Vocabulary if needed.
+var defaultVocabulary = []string{
+	// Motions
+	"h", "j", "k", "l", "w", "b", "e", "W", "B", "E", "0", "^", "$", "G", "g",
+	"f", "F", "t", "T",
+	// Counts
+	"2", "3",
+	// Operators and edits
+	"d", "c", "y", "x", "X", "s", "J", "p", "P",
+	// Insert mode entries
+	"i", "I", "a", "A", "o", "O",
+	// Text objects (after i/a)
+	"\"", "'", "(", "[", "{",
+	"Escape",
+}
+
+// DefaultSolverConfig returns bounds suitable for the challenge library.
+func DefaultSolverConfig() SolverConfig {
+	return SolverConfig{
+		Vocabulary:    slices.Clone(defaultVocabulary),
+		MaxKeystrokes: 12,
+		MaxStates:     200000,
+	}
+}
+
+// Solution is the shortest key sequence found by Solve.
+type Solution struct {
+	Keys       []string // Individual keys, in editor key names
+	Keystrokes int
+	States     int // Editor states explored
+}
+
+// String renders the solution in Vim key notation (e.g. `ci"world<Esc>`).
+func (s *Solution) String() string {
+	var b strings.Builder
+	for _, k := range s.Keys {
+		b.WriteString(KeyNotation(k))
+	}
+	return b.String()
+}
+
+// WordsForSpec lists the text a solution may need to type: words and
+// punctuation in the expected result that do not appear in the initial buffer.
+func WordsForSpec(spec *ChallengeSpec) []string {
+	var words []string
+	for _, text := range expectedTexts(spec) {
+		for _, tok := range tokenize(text) {
+			if !strings.Contains(spec.InitialBuffer, tok) && !slices.Contains(words, tok) {
+				words = append(words, tok)
+			}
+		}
+	}
+	return words
+}
+
+// expectedTexts collects the text a spec and its sub-checks expect to find.
+func expectedTexts(spec *ChallengeSpec) []string {
+	var texts []string
+	for _, text := range []string{spec.ExpectedBuffer, spec.ExpectedContent, spec.NewName} {
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+	for i := range spec.Checks {
+		texts = append(texts, expectedTexts(&spec.Checks[i])...)
+	}
+	return texts
+}
+
+// tokenize splits text into identifier-like runs and single punctuation runes.
+func tokenize(text string) []string {
+	var tokens []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, string(word))
+			word = word[:0]
+		}
+	}
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			word = append(word, r)
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens = append(tokens, string(r))
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Solve searches for the shortest key sequence that takes the editor from its
+// current state to one that passes spec validation. The search is A* over
+// editor states with a lower-bound estimate, so the first solution found is
+// optimal within the vocabulary. Forbidden keys and insert mode restrictions
+// are honored.
+func Solve(start *Editor, spec *ChallengeSpec, cfg SolverConfig) (*Solution, error) {
+	cfg = solverBounds(cfg, spec)
+
+	root := start.Clone()
+	root.KeystrokeCount = 0
+	root.StatusMessage = ""
+	root.Violation = ""
+	// Time limits don't apply to the search; forbidden keys do
+	root.Restrictions = Restrictions{ForbiddenKeys: spec.Restrictions.ForbiddenKeys}
+	root.StartedAt = time.Time{}
+
+	s := &solver{
+		cfg:  cfg,
+		spec: spec,
+		best: make(map[solverState]int),
+		undo: slices.Contains(cfg.Vocabulary, "u") || slices.Contains(cfg.Vocabulary, "ctrl+r"),
+	}
+	s.push(root, nil, 0)
+
+	for s.queue.Len() > 0 {
+		node := heap.Pop(&s.queue).(*solverNode)
+		if node.cost > s.best[stateKey(node.editor)] {
+			continue // Superseded by a cheaper path
+		}
+		s.states++
+		if s.states > cfg.MaxStates {
+			break
+		}
+		if isIdle(node.editor) && Validate(node.editor, spec).Success {
+			return &Solution{Keys: node.keys, Keystrokes: node.cost, States: s.states}, nil
+		}
+		s.expand(node)
+	}
+	return nil, fmt.Errorf("%w (explored %d states)", ErrNoSolution, s.states)
+}
+
+// solverBounds fills in defaults and tightens bounds to the spec's keystroke limit.
+func solverBounds(cfg SolverConfig, spec *ChallengeSpec) SolverConfig {
+	def := DefaultSolverConfig()
+	if cfg.Vocabulary == nil {
+		cfg.Vocabulary = def.Vocabulary
+	}
+	if cfg.Words == nil {
+		cfg.Words = WordsForSpec(spec)
+	}
+	if cfg.MaxKeystrokes <= 0 {
+		cfg.MaxKeystrokes = def.MaxKeystrokes
+	}
+	if limit := spec.Restrictions.MaxKeystrokes; limit > 0 && limit < cfg.MaxKeystrokes {
+		cfg.MaxKeystrokes = limit
+	}
+	if cfg.MaxStates <= 0 {
+		cfg.MaxStates = def.MaxStates
+	}
+	return cfg
+}
+
+type solver struct {
+	cfg    SolverConfig
+	spec   *ChallengeSpec
+	queue  solverQueue
+	best   map[solverState]int // Cheapest known cost per state
+	undo   bool                // Whether undo history must be kept
+	seq    int
+	states int
+}
+
+type solverNode struct {
+	editor   *Editor
+	keys     []string
+	cost     int
+	priority int // cost plus the estimated keys remaining
+	seq      int // Insertion order, for deterministic results
+}
+
+// push queues a state unless it is already reachable as cheaply.
+func (s *solver) push(e *Editor, keys []string, cost int) {
+	key := stateKey(e)
+	if best, ok := s.best[key]; ok && best <= cost {
+		return
+	}
+	priority := cost + s.estimate(e)
+	if priority > s.cfg.MaxKeystrokes {
+		return // Can't finish within the keystroke limit
+	}
+	s.best[key] = cost
+	s.seq++
+	heap.Push(&s.queue, &solverNode{
+		editor:   e,
+		keys:     keys,
+		cost:     cost,
+		priority: priority,
+		seq:      s.seq,
+	})
+}
+
+// expand queues every state one input away from node.
+func (s *solver) expand(node *solverNode) {
+	for _, input := range s.inputs(node.editor) {
+		cost := node.cost + len(input)
+		if cost > s.cfg.MaxKeystrokes {
+			continue
+		}
+		child := node.editor.Clone()
+		for _, key := range input {
+			child.HandleKey(key)
+		}
+		if child.Violation != "" {
+			continue
+		}
+		if !s.undo {
+			// Undo history is only needed if undo is in the vocabulary; dropping
+			// it keeps clones cheap
+			child.UndoStack, child.RedoStack = nil, nil
+		}
+		keys := append(slices.Clip(node.keys), input...)
+		s.push(child, keys, cost)
+	}
+}
+
+// estimate returns a lower bound on the keys still needed from e. Only
+// exact_match has a useful bound: a rune of the expected buffer found neither
+// in the buffer nor in a register can only come from typing a word that
+// contains it, which takes entering insert mode, the word and Escape.
+func (s *solver) estimate(e *Editor) int {
+	idle := 0
+	if !isIdle(e) {
+		idle = 1
+	}
+	if s.spec.ValidationType != "exact_match" {
+		return idle
+	}
+
+	buffer := normalizeBuffer(e.Buffer.String())
+	if buffer == normalizeBuffer(s.spec.ExpectedBuffer) {
+		return idle
+	}
+
+	typing := 0
+	for _, r := range s.spec.ExpectedBuffer {
+		// Enter is typed in insert mode, but o/O open a line without it
+		if r == '\n' || strings.ContainsRune(buffer, r) ||
+			strings.ContainsRune(e.Unnamed, r) || registersContain(e.Registers, r) {
+			continue
+		}
+		typing = max(typing, s.typingCost(r))
+	}
+
+	switch {
+	case typing > 0 && e.Mode == ModeInsert:
+		return typing + 1
+	case typing > 0:
+		return typing + 2
+	case e.Mode == ModeInsert:
+		return 2 // A change, then Escape
+	default:
+		return 1
+	}
+}
+
+// typingCost returns the length of the shortest typeable input containing r,
+// or more than the keystroke limit if r cannot be typed at all.
+func (s *solver) typingCost(r rune) int {
+	if r == ' ' {
+		return 1
+	}
+	cost := s.cfg.MaxKeystrokes + 1
+	for _, w := range s.cfg.Words {
+		if n := utf8.RuneCountInString(w); n < cost && strings.ContainsRune(w, r) {
+			cost = n
+		}
+	}
+	return cost
+}
+
+func registersContain(registers map[rune]string, r rune) bool {
+	for _, text := range registers {
+		if strings.ContainsRune(text, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// inputs lists the candidate inputs for a state. Each input is one key, or
+// a word typed in insert mode.
+func (s *solver) inputs(e *Editor) [][]string {
+	var inputs [][]string
+	switch {
+	case e.Mode == ModeInsert:
+		for _, w := range s.cfg.Words {
+			inputs = append(inputs, strings.Split(w, ""))
+		}
+		for _, k := range []string{" ", "Enter", "Backspace", "Escape"} {
+			inputs = append(inputs, []string{k})
+		}
+	case e.WaitingFor == WaitChar:
+		for _, r := range lineRunes(e) {
+			inputs = append(inputs, []string{string(r)})
+		}
+	default:
+		for _, k := range s.cfg.Vocabulary {
+			// Single-digit counts are enough; longer ones only grow the search
+			if e.Count > 0 && isDigitKey(k) {
+				continue
+			}
+			inputs = append(inputs, []string{k})
+		}
+	}
+	return inputs
+}
+
+func isDigitKey(key string) bool {
+	return len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+}
+
+// lineRunes lists the distinct characters on the cursor line, the only
+// targets worth trying after f/F/t/T.
+func lineRunes(e *Editor) []rune {
+	var chars []rune
+	for _, r := range e.Buffer.GetLine(e.Cursor.Line) {
+		if !slices.Contains(chars, r) {
+			chars = append(chars, r)
+		}
+	}
+	return chars
+}
+
+// isIdle reports whether the editor is in normal mode with no command pending,
+// which is when a player can submit.
+func isIdle(e *Editor) bool {
+	return e.Mode == ModeNormal && e.PendingOp == OpNone && e.WaitingFor == WaitNone &&
+		e.Count == 0 && len(e.CountStack) == 0
+}
+
+// solverState identifies an editor state for duplicate detection. Undo
+// history and keystroke counts are excluded.
+type solverState struct {
+	cursor     Position
+	mode       Mode
+	op         OperatorType
+	count      int
+	countStack string
+	wait       WaitState
+	find       FindState
+	visual     Position
+	unnamed    string
+	buffer     string
+}
+
+func stateKey(e *Editor) solverState {
+	st := solverState{
+		cursor:  e.Cursor,
+		mode:    e.Mode,
+		op:      e.PendingOp,
+		count:   e.Count,
+		wait:    e.WaitingFor,
+		find:    e.LastFind,
+		unnamed: e.Unnamed,
+		buffer:  e.Buffer.String(),
+	}
+	if len(e.CountStack) > 0 {
+		var b []byte
+		for _, n := range e.CountStack {
+			b = strconv.AppendInt(b, int64(n), 10)
+			b = append(b, ',')
+		}
+		st.countStack = string(b)
+	}
+	if e.Mode == ModeVisual || e.Mode == ModeVisualLine {
+		st.visual = e.VisualStart
+	}
+	return st
+}
+
+// solverQueue is a min-heap of nodes ordered by priority, then insertion order.
+type solverQueue []*solverNode
+
+func (q solverQueue) Len() int { return len(q) }
+
+func (q solverQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q solverQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *solverQueue) Push(x any) {
+	*q = append(*q, x.(*solverNode))
+}
+
+func (q *solverQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -252,7 +252,7 @@ func (e *Editor) pairObjectRange(open, closeBracket rune, inner bool) (Range, bo
 
 		startCol := len(runes) - 1
 		if lineNum == line {
-			startCol = col
+			startCol = min(col, len(runes)-1)
 			if depth == 1 {
 				startCol = col - 1 // Don't count the close bracket we're on
 			}
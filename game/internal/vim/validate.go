@@ -3,6 +3,7 @@ package vim
 import (
 	"regexp"
 	"strings"
+	"time"
 )
 
 // ValidationResult contains the result of validating a challenge.
@@ -22,69 +23,200 @@ type ChallengeSpec struct {
 	FunctionName    string
 	InitialBuffer   string
 	ParKeystrokes   int
+	// Filetype selects syntax-aware checks (e.g. go/ast for "go")
+	Filetype string
+	// Signature is the expected "(params) results" for go_function_signature
+	Signature string
+	// OldName and NewName are the identifiers for go_identifier_renamed
+	OldName string
+	NewName string
+	// Restrictions are the golf rules (forbidden keys, keystroke and time limits)
+	Restrictions Restrictions
+	// Checks are the sub-checks of an all_of/any_of composite validation
+	Checks []ChallengeSpec
 }
 
 // Validate checks if the editor state matches challenge expectations.
 func Validate(e *Editor, spec *ChallengeSpec) ValidationResult {
 	result := ValidationResult{}
+	if violation := restrictionViolation(e, spec); violation != "" {
+		result.Message = violation
+		return result
+	}
+	result.Success, result.Message = check(e, spec)
+
+	// Calculate efficiency
+	if result.Success && e.KeystrokeCount > 0 && spec.ParKeystrokes > 0 {
+		result.Efficiency = float64(spec.ParKeystrokes) / float64(e.KeystrokeCount)
+		if result.Efficiency > 1.0 {
+			result.Efficiency = 1.0 // Cap at 100%
+		}
+	} else if result.Success {
+		result.Efficiency = 1.0
+	}
+
+	return result
+}
+
+// Submission is the final state of a challenge solved in an external editor.
+type Submission struct {
+	Buffer         string
+	Cursor         []int    // [line, col], 0-indexed
+	Keystrokes     []string // Keys in Vim notation, in order
+	KeystrokeCount int
+	EnteredInsert  bool // Whether insert mode was entered at any point
+	Elapsed        time.Duration
+}
+
+// ValidateSubmission validates a submission from an external editor (e.g. Neovim)
+// against the spec, replaying the restrictions over its key log.
+func ValidateSubmission(sub Submission, spec *ChallengeSpec) ValidationResult {
+	e := NewEditor(sub.Buffer)
+	if len(sub.Cursor) == 2 {
+		e.SetCursor(Position{Line: sub.Cursor[0], Col: sub.Cursor[1]})
+	}
+	e.KeystrokeCount = sub.KeystrokeCount
+
+	r := spec.Restrictions
+	for _, key := range sub.Keystrokes {
+		if r.forbids(key) {
+			e.Violation = "Forbidden key: " + key
+			break
+		}
+	}
+	if e.Violation == "" && sub.EnteredInsert && r.forbidsInsert() {
+		e.Violation = "Insert mode is not allowed"
+	}
+	if e.Violation == "" {
+		e.Violation = r.timeViolation(sub.Elapsed)
+	}
+	return Validate(e, spec)
+}
+
+// restrictionViolation reports the first broken restriction, if any.
+func restrictionViolation(e *Editor, spec *ChallengeSpec) string {
+	if e.Violation != "" {
+		return e.Violation
+	}
+	if violation := spec.Restrictions.countViolation(e.KeystrokeCount); violation != "" {
+		return violation
+	}
+	if !e.StartedAt.IsZero() {
+		return spec.Restrictions.timeViolation(time.Since(e.StartedAt))
+	}
+	return ""
+}
 
+// check runs a single validation type and reports success and a failure message.
+func check(e *Editor, spec *ChallengeSpec) (bool, string) {
 	switch spec.ValidationType {
 	case "exact_match":
-		result.Success = normalizeBuffer(e.Buffer.String()) == normalizeBuffer(spec.ExpectedBuffer)
-		if !result.Success {
-			result.Message = "Buffer content doesn't match expected"
+		if normalizeBuffer(e.Buffer.String()) == normalizeBuffer(spec.ExpectedBuffer) {
+			return true, ""
 		}
+		return false, "Buffer content doesn't match expected"
 
 	case "contains":
-		result.Success = strings.Contains(e.Buffer.String(), spec.ExpectedContent)
-		if !result.Success {
-			result.Message = "Buffer should contain: " + spec.ExpectedContent
+		if strings.Contains(e.Buffer.String(), spec.ExpectedContent) {
+			return true, ""
 		}
+		return false, "Buffer should contain: " + spec.ExpectedContent
 
 	case "cursor_position":
-		if len(spec.ExpectedCursor) == 2 {
-			result.Success = e.Cursor.Line == spec.ExpectedCursor[0] &&
-				e.Cursor.Col == spec.ExpectedCursor[1]
-			if !result.Success {
-				result.Message = "Cursor not at expected position"
-			}
+		if len(spec.ExpectedCursor) != 2 {
+			return false, ""
 		}
+		if e.Cursor.Line == spec.ExpectedCursor[0] && e.Cursor.Col == spec.ExpectedCursor[1] {
+			return true, ""
+		}
+		return false, "Cursor not at expected position"
 
 	case "different":
-		result.Success = normalizeBuffer(e.Buffer.String()) != normalizeBuffer(spec.InitialBuffer)
-		if !result.Success {
-			result.Message = "Buffer content unchanged"
+		if normalizeBuffer(e.Buffer.String()) != normalizeBuffer(spec.InitialBuffer) {
+			return true, ""
 		}
+		return false, "Buffer content unchanged"
 
 	case "pattern":
-		matched, _ := regexp.MatchString(spec.Pattern, e.Buffer.String())
-		result.Success = matched
-		if !result.Success {
-			result.Message = "Buffer doesn't match required pattern"
+		matched, err := regexp.MatchString(spec.Pattern, e.Buffer.String())
+		if err != nil {
+			return false, "Invalid pattern: " + err.Error()
+		}
+		if matched {
+			return true, ""
 		}
+		return false, "Buffer doesn't match required pattern"
 
 	case "function_exists":
-		result.Success = checkFunctionExists(e.Buffer.String(), spec.FunctionName)
-		if !result.Success {
-			result.Message = "Function " + spec.FunctionName + " not found"
+		if spec.Filetype == "go" {
+			// Match declarations only, not mentions in comments or strings
+			goSpec := *spec
+			goSpec.ValidationType = "go_function_signature"
+			goSpec.Signature = ""
+			return checkGo(e.Buffer.String(), &goSpec)
 		}
+		if checkFunctionExists(e.Buffer.String(), spec.FunctionName) {
+			return true, ""
+		}
+		return false, "Function " + spec.FunctionName + " not found"
+
+	case "go_parses", "go_function_signature", "go_identifier_renamed", "go_no_unused_vars":
+		return checkGo(e.Buffer.String(), spec)
+
+	case "all_of":
+		return checkAllOf(e, spec)
+
+	case "any_of":
+		return checkAnyOf(e, spec)
 
 	default:
-		// Default to success for unknown validation types
-		result.Success = true
+		// Fail closed: an unknown type would otherwise pass any buffer
+		return false, "Unknown validation type: " + spec.ValidationType
 	}
+}
 
-	// Calculate efficiency
-	if result.Success && e.KeystrokeCount > 0 && spec.ParKeystrokes > 0 {
-		result.Efficiency = float64(spec.ParKeystrokes) / float64(e.KeystrokeCount)
-		if result.Efficiency > 1.0 {
-			result.Efficiency = 1.0 // Cap at 100%
+// checkAllOf succeeds when every sub-check succeeds. It reports the first failure.
+func checkAllOf(e *Editor, spec *ChallengeSpec) (bool, string) {
+	if len(spec.Checks) == 0 {
+		return false, "No checks defined"
+	}
+	for i := range spec.Checks {
+		if ok, msg := check(e, subSpec(spec, i)); !ok {
+			return false, msg
 		}
-	} else if result.Success {
-		result.Efficiency = 1.0
 	}
+	return true, ""
+}
 
-	return result
+// checkAnyOf succeeds when at least one sub-check succeeds.
+func checkAnyOf(e *Editor, spec *ChallengeSpec) (bool, string) {
+	if len(spec.Checks) == 0 {
+		return false, "No checks defined"
+	}
+	messages := make([]string, 0, len(spec.Checks))
+	for i := range spec.Checks {
+		ok, msg := check(e, subSpec(spec, i))
+		if ok {
+			return true, ""
+		}
+		if msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	return false, "None of the checks passed: " + strings.Join(messages, "; ")
+}
+
+// subSpec returns the i-th sub-check, inheriting the parent's initial buffer
+// and filetype so "different" and Go checks work inside composites.
+func subSpec(spec *ChallengeSpec, i int) *ChallengeSpec {
+	sub := spec.Checks[i]
+	if sub.InitialBuffer == "" {
+		sub.InitialBuffer = spec.InitialBuffer
+	}
+	if sub.Filetype == "" {
+		sub.Filetype = spec.Filetype
+	}
+	return &sub
 }
 
 func normalizeBuffer(s string) string {
@@ -1,7 +1,9 @@
 package vim
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestNewBuffer(t *testing.T) {
@@ -178,6 +180,64 @@ func TestFindChar(t *testing.T) {
 	}
 }
 
+func TestFindRepeatOperatorBackward(t *testing.T) {
+	tests := []struct {
+		name string
+		col  int
+		keys []string
+		want string
+	}{
+		{name: "; after F", col: 6, keys: []string{"F", ",", "d", ";"}, want: "a,bd"},
+		{name: ", after f", col: 0, keys: []string{"f", ",", "f", ",", "d", ","}, want: "ac,d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEditor("a,b,c,d")
+			e.SetCursor(Position{Line: 0, Col: tt.col})
+			for _, key := range tt.keys {
+				e.HandleKey(key)
+			}
+			if got := e.Buffer.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCursorStaysOnText(t *testing.T) {
+	// Joining with an empty line leaves nothing after the join point
+	e := NewEditor("abc\n")
+	e.HandleKey("J")
+	if e.Cursor.Col != 2 {
+		t.Errorf("expected cursor on the last character, col 2, got %d", e.Cursor.Col)
+	}
+}
+
+func TestDeleteRangePastLineEnd(t *testing.T) {
+	b := NewBuffer("ab\ncd")
+	if deleted := b.DeleteRange(Position{Line: 0, Col: 5}, Position{Line: 1, Col: 1}); b.String() != "abd" || deleted != "\nc" {
+		t.Errorf("start past the end: buffer %q, deleted %q", b.String(), deleted)
+	}
+
+	b = NewBuffer("ab\ncd")
+	if deleted := b.DeleteRange(Position{Line: 0, Col: 1}, Position{Line: 1, Col: 9}); b.String() != "a" || deleted != "b\ncd" {
+		t.Errorf("end past the end: buffer %q, deleted %q", b.String(), deleted)
+	}
+}
+
+func TestPairObjectCursorPastLineEnd(t *testing.T) {
+	for _, cursor := range []Position{{Line: 0, Col: 9}, {Line: 1, Col: 7}} {
+		e := NewEditor("f(ab\nc)")
+		e.Cursor = cursor
+		for _, key := range []string{"d", "i", "("} {
+			e.HandleKey(key)
+		}
+		if got := e.Buffer.String(); got != "f()" {
+			t.Errorf("cursor %+v: expected %q, got %q", cursor, "f()", got)
+		}
+	}
+}
+
 func TestRenderState(t *testing.T) {
 	e := NewEditor("hello\nworld")
 	state := e.GetRenderState()
@@ -225,3 +285,407 @@ func TestValidateExactMatch(t *testing.T) {
 		t.Errorf("expected success, buffer: '%s'", e.Buffer.String())
 	}
 }
+
+func TestValidateSubmission(t *testing.T) {
+	tests := []struct {
+		name       string
+		buffer     string
+		cursor     []int
+		keystrokes int
+		spec       ChallengeSpec
+		success    bool
+		efficiency float64
+	}{
+		{
+			name:       "exact match at par",
+			buffer:     "hello world\n",
+			keystrokes: 4,
+			spec:       ChallengeSpec{ValidationType: "exact_match", ExpectedBuffer: "hello world", ParKeystrokes: 4},
+			success:    true,
+			efficiency: 1.0,
+		},
+		{
+			name:       "exact match over par",
+			buffer:     "hello world",
+			keystrokes: 8,
+			spec:       ChallengeSpec{ValidationType: "exact_match", ExpectedBuffer: "hello world", ParKeystrokes: 4},
+			success:    true,
+			efficiency: 0.5,
+		},
+		{
+			name:    "cursor position",
+			buffer:  "one\ntwo",
+			cursor:  []int{1, 2},
+			spec:    ChallengeSpec{ValidationType: "cursor_position", ExpectedCursor: []int{1, 2}},
+			success: true,
+		},
+		{
+			name:    "cursor clamped to buffer",
+			buffer:  "one",
+			cursor:  []int{5, 5},
+			spec:    ChallengeSpec{ValidationType: "cursor_position", ExpectedCursor: []int{5, 5}},
+			success: false,
+		},
+		{
+			name:    "unchanged buffer",
+			buffer:  "same",
+			spec:    ChallengeSpec{ValidationType: "different", InitialBuffer: "same"},
+			success: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateSubmission(Submission{Buffer: tt.buffer, Cursor: tt.cursor, KeystrokeCount: tt.keystrokes}, &tt.spec)
+			if result.Success != tt.success {
+				t.Errorf("expected success=%v, got %v (%s)", tt.success, result.Success, result.Message)
+			}
+			if tt.success && tt.efficiency > 0 && result.Efficiency != tt.efficiency {
+				t.Errorf("expected efficiency %.2f, got %.2f", tt.efficiency, result.Efficiency)
+			}
+		})
+	}
+}
+
+func TestValidateCompositeAndPattern(t *testing.T) {
+	e := NewEditor("alpha\nbeta")
+	e.SetCursor(Position{Line: 1, Col: 0})
+
+	bufferCheck := ChallengeSpec{ValidationType: "exact_match", ExpectedBuffer: "alpha\nbeta"}
+	cursorCheck := ChallengeSpec{ValidationType: "cursor_position", ExpectedCursor: []int{1, 0}}
+	wrongCursor := ChallengeSpec{ValidationType: "cursor_position", ExpectedCursor: []int{0, 3}}
+
+	tests := []struct {
+		name    string
+		spec    ChallengeSpec
+		success bool
+	}{
+		{"pattern match", ChallengeSpec{ValidationType: "pattern", Pattern: `(?m)^be+ta$`}, true},
+		{"pattern miss", ChallengeSpec{ValidationType: "pattern", Pattern: `gamma`}, false},
+		{"function exists", ChallengeSpec{ValidationType: "function_exists", FunctionName: "alpha"}, false},
+		{"all_of passes", ChallengeSpec{ValidationType: "all_of", Checks: []ChallengeSpec{bufferCheck, cursorCheck}}, true},
+		{"all_of fails on one", ChallengeSpec{ValidationType: "all_of", Checks: []ChallengeSpec{bufferCheck, wrongCursor}}, false},
+		{"any_of passes on one", ChallengeSpec{ValidationType: "any_of", Checks: []ChallengeSpec{wrongCursor, cursorCheck}}, true},
+		{"any_of fails on all", ChallengeSpec{ValidationType: "any_of", Checks: []ChallengeSpec{wrongCursor}}, false},
+		{"empty composite fails", ChallengeSpec{ValidationType: "all_of"}, false},
+		{"invalid pattern fails", ChallengeSpec{ValidationType: "pattern", Pattern: `(unclosed`}, false},
+		{"unknown type fails", ChallengeSpec{ValidationType: "exact_matches"}, false},
+		{
+			"nested different inherits initial buffer",
+			ChallengeSpec{
+				ValidationType: "all_of",
+				InitialBuffer:  "alpha\nbeta",
+				Checks:         []ChallengeSpec{{ValidationType: "different"}},
+			},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Validate(e, &tt.spec)
+			if result.Success != tt.success {
+				t.Errorf("expected success=%v, got %v (%s)", tt.success, result.Success, result.Message)
+			}
+			if !tt.success && result.Message == "" {
+				t.Error("expected a failure message")
+			}
+		})
+	}
+}
+
+func TestValidateGoSyntaxAware(t *testing.T) {
+	const src = `package main
+
+import "fmt"
+
+// oldName is mentioned in this comment
+func add(a, b int) int {
+	total := a + b
+	return total
+}
+
+func greet(name string) (string, error) {
+	return fmt.Sprintf("hi %s, not oldName", name), nil
+}
+`
+
+	tests := []struct {
+		name    string
+		buffer  string
+		spec    ChallengeSpec
+		success bool
+	}{
+		{"parses", src, ChallengeSpec{ValidationType: "go_parses"}, true},
+		{"parses snippet without package", "func f() {}", ChallengeSpec{ValidationType: "go_parses"}, true},
+		{"syntax error", "func f( {", ChallengeSpec{ValidationType: "go_parses"}, false},
+		{"signature match", src, ChallengeSpec{ValidationType: "go_function_signature", FunctionName: "add", Signature: "(int, int) int"}, true},
+		{"multiple results", src, ChallengeSpec{ValidationType: "go_function_signature", FunctionName: "greet", Signature: "(string) (string, error)"}, true},
+		{"signature mismatch", src, ChallengeSpec{ValidationType: "go_function_signature", FunctionName: "add", Signature: "(int) int"}, false},
+		{"function missing", src, ChallengeSpec{ValidationType: "go_function_signature", FunctionName: "sub"}, false},
+		{"renamed ignores comments and strings", src, ChallengeSpec{ValidationType: "go_identifier_renamed", OldName: "oldName", NewName: "total"}, true},
+		{"rename incomplete", src, ChallengeSpec{ValidationType: "go_identifier_renamed", OldName: "total", NewName: "sum"}, false},
+		{"no unused vars", src, ChallengeSpec{ValidationType: "go_no_unused_vars"}, true},
+		{"unused var", "package main\n\nfunc f() {\n\tx := 1\n}\n", ChallengeSpec{ValidationType: "go_no_unused_vars"}, false},
+		{"function_exists in comment only", "// func calc() {}\nfunc other() {}", ChallengeSpec{ValidationType: "function_exists", FunctionName: "calc", Filetype: "go"}, false},
+		{"function_exists regex for other filetypes", "// func calc() {}", ChallengeSpec{ValidationType: "function_exists", FunctionName: "calc"}, true},
+		{"composite inherits filetype", "// func calc() {}", ChallengeSpec{
+			ValidationType: "all_of",
+			Filetype:       "go",
+			Checks:         []ChallengeSpec{{ValidationType: "function_exists", FunctionName: "calc"}},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateSubmission(Submission{Buffer: tt.buffer}, &tt.spec)
+			if result.Success != tt.success {
+				t.Errorf("expected success=%v, got %v (%s)", tt.success, result.Success, result.Message)
+			}
+		})
+	}
+}
+
+func TestRestrictionsForbiddenKeys(t *testing.T) {
+	e := NewEditor("one two three")
+	e.Restrict(Restrictions{ForbiddenKeys: []string{"l", "<Right>"}})
+
+	e.HandleKey("w")
+	if e.Violation != "" {
+		t.Fatalf("unexpected violation: %s", e.Violation)
+	}
+
+	e.HandleKey("Right")
+	if e.Violation != "Forbidden key: <Right>" {
+		t.Errorf("expected forbidden key violation, got %q", e.Violation)
+	}
+
+	// Editor is locked after a violation
+	cursor := e.Cursor
+	e.HandleKey("w")
+	if e.Cursor != cursor {
+		t.Error("expected editor to ignore keys after a violation")
+	}
+
+	result := Validate(e, &ChallengeSpec{ValidationType: "cursor_position", ExpectedCursor: []int{0, 4}})
+	if result.Success || result.Message != "Forbidden key: <Right>" {
+		t.Errorf("expected violation in result, got %+v", result)
+	}
+}
+
+func TestRestrictionsKeyCase(t *testing.T) {
+	e := NewEditor("one two")
+	e.Restrict(Restrictions{ForbiddenKeys: []string{"w", "<esc>"}})
+
+	e.HandleKey("W")
+	if e.Violation != "" {
+		t.Errorf("expected W to be allowed when w is forbidden, got %q", e.Violation)
+	}
+	e.HandleKey("Escape")
+	if e.Violation == "" {
+		t.Error("expected <Esc> to match <esc>")
+	}
+}
+
+func TestRestrictionsInsertMode(t *testing.T) {
+	e := NewEditor("hello")
+	e.Restrict(Restrictions{ForbiddenKeys: []string{ForbidInsertMode}})
+
+	e.HandleKey("x")
+	if e.Violation != "" {
+		t.Fatalf("unexpected violation: %s", e.Violation)
+	}
+	e.HandleKey("i")
+	if e.Violation != "Insert mode is not allowed" {
+		t.Errorf("expected insert mode violation, got %q", e.Violation)
+	}
+	if e.Mode != ModeNormal {
+		t.Error("expected editor back in normal mode")
+	}
+}
+
+func TestRestrictionsMaxKeystrokes(t *testing.T) {
+	e := NewEditor("a b c d")
+	e.Restrict(Restrictions{MaxKeystrokes: 2})
+
+	e.HandleKey("w")
+	e.HandleKey("w")
+	if e.Violation != "" {
+		t.Fatalf("unexpected violation at limit: %s", e.Violation)
+	}
+	e.HandleKey("w")
+	if e.Violation == "" {
+		t.Error("expected keystroke limit violation")
+	}
+	if e.Cursor.Col != 4 {
+		t.Errorf("expected third key to be ignored, cursor at %d", e.Cursor.Col)
+	}
+}
+
+func TestRestrictionsTimeLimit(t *testing.T) {
+	e := NewEditor("a b")
+	e.Restrict(Restrictions{TimeLimit: time.Second})
+	e.StartedAt = time.Now().Add(-2 * time.Second)
+
+	e.HandleKey("w")
+	if e.Violation == "" {
+		t.Error("expected time limit violation")
+	}
+}
+
+func TestValidateSubmissionRestrictions(t *testing.T) {
+	spec := ChallengeSpec{
+		ValidationType: "different",
+		InitialBuffer:  "abc",
+		Restrictions: Restrictions{
+			ForbiddenKeys: []string{"x", ForbidInsertMode},
+			MaxKeystrokes: 3,
+			TimeLimit:     10 * time.Second,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		sub     Submission
+		message string
+	}{
+		{"clean", Submission{Buffer: "bc", Keystrokes: []string{"d", "l"}, KeystrokeCount: 2}, ""},
+		{"forbidden key", Submission{Buffer: "bc", Keystrokes: []string{"x"}, KeystrokeCount: 1}, "Forbidden key: x"},
+		{"insert mode", Submission{Buffer: "bc", KeystrokeCount: 1, EnteredInsert: true}, "Insert mode is not allowed"},
+		{"too many keys", Submission{Buffer: "bc", KeystrokeCount: 4}, "Keystroke limit exceeded (3 max)"},
+		{"too slow", Submission{Buffer: "bc", KeystrokeCount: 2, Elapsed: 11 * time.Second}, "Time limit exceeded (10s max)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateSubmission(tt.sub, &spec)
+			if result.Success != (tt.message == "") {
+				t.Errorf("expected success=%v, got %v", tt.message == "", result.Success)
+			}
+			if result.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, result.Message)
+			}
+		})
+	}
+}
+
+func TestKeyNotation(t *testing.T) {
+	tests := map[string]string{
+		"Escape": "<Esc>",
+		"Enter":  "<CR>",
+		"Up":     "<Up>",
+		"ctrl+r": "<C-r>",
+		" ":      "<Space>",
+		"h":      "h",
+	}
+	for key, want := range tests {
+		if got := KeyNotation(key); got != want {
+			t.Errorf("KeyNotation(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEditorClone(t *testing.T) {
+	e := NewEditor("hello world")
+	e.HandleKey("x")
+	c := e.Clone()
+	c.HandleKey("x")
+	c.HandleKey("u")
+	c.HandleKey("u")
+
+	if e.Buffer.String() != "ello world" {
+		t.Errorf("original buffer changed: %q", e.Buffer.String())
+	}
+	if c.Buffer.String() != "hello world" {
+		t.Errorf("expected clone to undo to %q, got %q", "hello world", c.Buffer.String())
+	}
+	if len(e.UndoStack) != 1 {
+		t.Errorf("expected original undo stack of 1, got %d", len(e.UndoStack))
+	}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string
+		cursor  Position
+		spec    ChallengeSpec
+		want    int
+	}{
+		{
+			name:    "delete char",
+			initial: "hxello",
+			cursor:  Position{Line: 0, Col: 1},
+			spec:    ChallengeSpec{ValidationType: "exact_match", ExpectedBuffer: "hello"},
+			want:    1, // x
+		},
+		{
+			name:    "delete word",
+			initial: "hello cruel world",
+			spec:    ChallengeSpec{ValidationType: "exact_match", ExpectedBuffer: "cruel world"},
+			want:    2, // dw
+		},
+		{
+			name:    "change inside quotes",
+			initial: `say "hello" now`,
+			cursor:  Position{Line: 0, Col: 6},
+			spec:    ChallengeSpec{ValidationType: "exact_match", ExpectedBuffer: `say "bye" now`},
+			want:    7, // ci"bye<Esc>
+		},
+		{
+			name:    "move to line",
+			initial: "one\ntwo\nthree\nfour",
+			spec:    ChallengeSpec{ValidationType: "cursor_position", ExpectedCursor: []int{3, 0}},
+			want:    1, // G
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEditor(tt.initial)
+			e.SetCursor(tt.cursor)
+			tt.spec.InitialBuffer = tt.initial
+
+			solution, err := Solve(e, &tt.spec, DefaultSolverConfig())
+			if err != nil {
+				t.Fatalf("Solve failed: %v", err)
+			}
+			if solution.Keystrokes != tt.want || len(solution.Keys) != tt.want {
+				t.Errorf("expected %d keystrokes, got %d (%s)", tt.want, solution.Keystrokes, solution)
+			}
+
+			// Replaying the solution must pass validation
+			replay := NewEditor(tt.initial)
+			replay.SetCursor(tt.cursor)
+			for _, key := range solution.Keys {
+				replay.HandleKey(key)
+			}
+			if result := Validate(replay, &tt.spec); !result.Success {
+				t.Errorf("replayed solution failed: %s", result.Message)
+			}
+		})
+	}
+}
+
+func TestSolveRestrictions(t *testing.T) {
+	spec := ChallengeSpec{
+		ValidationType: "cursor_position",
+		ExpectedCursor: []int{0, 3},
+		InitialBuffer:  "abcdef",
+		Restrictions:   Restrictions{ForbiddenKeys: []string{"l", "f", "t", "w", "e", "$"}},
+	}
+	solution, err := Solve(NewEditor("abcdef"), &spec, DefaultSolverConfig())
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	for _, key := range solution.Keys {
+		if spec.Restrictions.forbids(key) {
+			t.Errorf("solution %q uses forbidden key %q", solution, key)
+		}
+	}
+
+	spec.Restrictions.MaxKeystrokes = 1
+	if _, err := Solve(NewEditor("abcdef"), &spec, DefaultSolverConfig()); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("expected ErrNoSolution under a 1 key limit, got %v", err)
+	}
+}
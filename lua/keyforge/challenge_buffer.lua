@@ -16,7 +16,9 @@ M._initial_content = nil -- Initial file content for validation
 M._info_win = nil -- Floating window for challenge info
 M._info_buf = nil -- Buffer for challenge info
 M._timeout_timer = nil -- Timeout timer handle
+M._time_limit_timer = nil -- Golf time limit timer handle
 M._last_result = nil -- Last validation result (for skip after failure)
+M._mapped_keys = {} -- Submit/cancel keymaps, not counted as keystrokes
 
 --- Wrap text to fit within a maximum width
 ---@param text string The text to wrap
@@ -168,6 +170,23 @@ local function create_info_window(challenge, challenge_data)
 
   table.insert(lines, "")
   table.insert(lines, string.format("Par: %d keystrokes | Reward: %dg", challenge.par_keystrokes or 10, challenge.gold_base or 50))
+
+  -- Golf restrictions
+  if challenge.forbidden_keys and #challenge.forbidden_keys > 0 then
+    for _, line in ipairs(wrap_text("Forbidden: " .. table.concat(challenge.forbidden_keys, " "), max_width - 4)) do
+      table.insert(lines, line)
+    end
+  end
+  if (challenge.max_keystrokes or 0) > 0 or (challenge.time_limit or 0) > 0 then
+    local limits = {}
+    if (challenge.max_keystrokes or 0) > 0 then
+      table.insert(limits, string.format("Max keys: %d", challenge.max_keystrokes))
+    end
+    if (challenge.time_limit or 0) > 0 then
+      table.insert(limits, string.format("Time limit: %ds", challenge.time_limit))
+    end
+    table.insert(lines, table.concat(limits, " | "))
+  end
   table.insert(lines, "")
   table.insert(lines, string.format("Submit: %s | Cancel: %s", config.keybind_submit, cancel_key))
 
@@ -221,6 +240,7 @@ end
 
 --- Set up keymaps for the challenge buffer
 ---@param buf number Buffer number
+---@return string[] mapped Left-hand sides of the submit and cancel keymaps
 local function setup_keymaps(buf)
   local keyforge = require("keyforge")
   local config = keyforge.config
@@ -229,6 +249,7 @@ local function setup_keymaps(buf)
   vim.keymap.set("n", config.keybind_submit, function()
     M.submit_challenge()
   end, { buffer = buf, desc = "Submit challenge" })
+  local mapped = { config.keybind_submit, "<leader>q" }
 
   -- Cancel keymap - use <leader>ks (skip) instead of <Esc> to avoid conflicts
   -- <Esc> is needed for normal Vim operations (canceling motions, exiting insert mode)
@@ -237,12 +258,15 @@ local function setup_keymaps(buf)
     vim.keymap.set("n", config.keybind_cancel, function()
       M.cancel_challenge()
     end, { buffer = buf, desc = "Cancel challenge" })
+    table.insert(mapped, config.keybind_cancel)
   end
 
   -- Always provide <leader>q as a cancel option
   vim.keymap.set("n", "<leader>q", function()
     M.cancel_challenge()
   end, { buffer = buf, desc = "Cancel challenge" })
+
+  return mapped
 end
 
 --- Set up autocmds for the challenge buffer
@@ -263,6 +287,20 @@ local function setup_autocmds(buf)
     once = true,
   })
 
+  -- Track insert mode for golf challenges that forbid it
+  vim.api.nvim_create_autocmd("ModeChanged", {
+    group = group,
+    buffer = buf,
+    callback = function()
+      if vim.v.event.new_mode:sub(1, 1) == "i" then
+        challenges.record_insert()
+        if challenges.get_violation() then
+          vim.cmd("stopinsert")
+        end
+      end
+    end,
+  })
+
   -- Track when user leaves the buffer (optional warning)
   vim.api.nvim_create_autocmd("BufLeave", {
     group = group,
@@ -301,12 +339,20 @@ function M.start_challenge(request_id, challenge_data)
       expected_cursor = challenge_data.expected_cursor,
       expected_content = challenge_data.expected_content,
       function_name = challenge_data.function_name,
+      pattern = challenge_data.pattern,
+      signature = challenge_data.signature,
+      old_name = challenge_data.old_name,
+      new_name = challenge_data.new_name,
+      checks = challenge_data.checks,
       cursor_start = challenge_data.cursor_start,
       par_keystrokes = challenge_data.par_keystrokes,
       gold_base = challenge_data.gold_base,
       filetype = challenge_data.filetype,
       hint_action = challenge_data.hint_action,
       hint_fallback = challenge_data.hint_fallback,
+      forbidden_keys = challenge_data.forbidden_keys,
+      max_keystrokes = challenge_data.max_keystrokes,
+      time_limit = challenge_data.time_limit,
     }
   else
     -- Legacy fallback: use local sample challenges
@@ -358,11 +404,13 @@ function M.start_challenge(request_id, challenge_data)
   end
 
   -- Set up keymaps and autocmds
-  setup_keymaps(M._challenge_buf)
+  M._mapped_keys = setup_keymaps(M._challenge_buf)
   setup_autocmds(M._challenge_buf)
 
-  -- Start keystroke tracking
-  challenges.start_tracking()
+  -- Start keystroke tracking with the challenge's golf restrictions; submitting and cancelling are free
+  challenges.set_restrictions(challenge)
+  challenges.start_tracking(M._mapped_keys)
+  M._start_time_limit(challenge)
 
   -- Create info window with mode/streak/feedback
   create_info_window(challenge, challenge_data)
@@ -407,13 +455,76 @@ function M.submit_challenge()
     end
   end
 
-  -- Validate
+  -- Validate locally for immediate failure details
   local result = challenges.validate(M._current, M._initial_content, final_content)
 
+  -- The game is the source of truth for success and gold when connected
+  local rpc = require("keyforge.rpc")
+  if M._request_id and rpc.is_connected() then
+    M._request_verdict(result, final_content)
+    return
+  end
+
   if result.success then
     -- Calculate gold (feedback shown in next challenge's info window)
     local gold = challenges.calculate_reward(M._current, result.efficiency)
     result.gold_earned = gold
+  end
+  M._handle_result(result)
+end
+
+--- Ask the game to validate the submission and apply its verdict
+---@param result table Local validation result
+---@param final_content string[] Final buffer lines
+function M._request_verdict(result, final_content)
+  local rpc = require("keyforge.rpc")
+  local cursor = { 0, 0 }
+  if M._challenge_win and vim.api.nvim_win_is_valid(M._challenge_win) then
+    local pos = vim.api.nvim_win_get_cursor(M._challenge_win)
+    cursor = { pos[1] - 1, pos[2] }
+  end
+
+  local request_id = M._request_id
+  rpc.request("validate_challenge", {
+    request_id = request_id,
+    buffer = table.concat(final_content, "\n"),
+    cursor = cursor,
+    keystrokes = result.keystrokes or {},
+    keystroke_count = result.keystroke_count or 0,
+    time_ms = result.time_ms or 0,
+    insert_entered = result.insert_entered or false,
+  }, function(err, verdict)
+    -- Ignore verdicts for a challenge that has since ended
+    if M._request_id ~= request_id then
+      return
+    end
+
+    if err or not verdict then
+      -- Without the game's verdict the answer can't be trusted, so it earns nothing
+      result.success = false
+      result.efficiency = 0
+      result.gold_earned = 0
+      result.failure_details = { message = "The game could not verify this answer" }
+    else
+      if not verdict.success and result.success then
+        result.failure_details = { message = verdict.message or "Challenge requirements not met" }
+      end
+      result.success = verdict.success
+      result.efficiency = verdict.efficiency or 0
+      result.speed_bonus = verdict.speed_bonus
+      result.gold_earned = verdict.gold_earned or 0
+      if verdict.success and verdict.optimal then
+        vim.notify("Optimal answer: " .. verdict.optimal, vim.log.levels.INFO)
+      end
+    end
+    M._handle_result(result)
+  end)
+end
+
+--- Complete on success, or show failure feedback with retry/skip
+---@param result table Validation result
+function M._handle_result(result)
+  if result.success then
     M._complete_challenge(true, false, result)
   else
     result.gold_earned = 0
@@ -463,11 +574,31 @@ function M._retry_challenge()
   M._initial_content = vim.split(M._current.initial_buffer or "", "\n")
 
   -- Restart keystroke tracking
-  challenges.start_tracking()
+  challenges.start_tracking(M._mapped_keys)
+  M._start_time_limit(M._current)
 
   vim.notify("Challenge reset. Try again!", vim.log.levels.INFO)
 end
 
+--- Start the golf time limit timer, if the challenge has one
+---@param challenge table
+function M._start_time_limit(challenge)
+  if M._time_limit_timer then
+    vim.fn.timer_stop(M._time_limit_timer)
+    M._time_limit_timer = nil
+  end
+  local limit = challenge.time_limit or 0
+  if limit <= 0 then
+    return
+  end
+  M._time_limit_timer = vim.fn.timer_start(limit * 1000, function()
+    M._time_limit_timer = nil
+    if M._current then
+      challenges.record_violation(string.format("Time limit exceeded (%ds max)", limit))
+    end
+  end)
+end
+
 --- Cancel the current challenge
 function M.cancel_challenge()
   if not M._current then
@@ -494,11 +625,15 @@ end
 function M._complete_challenge(success, skipped, result)
   result = result or {}
 
-  -- Cancel timeout timer
+  -- Cancel timeout and time limit timers
   if M._timeout_timer then
     vim.fn.timer_stop(M._timeout_timer)
     M._timeout_timer = nil
   end
+  if M._time_limit_timer then
+    vim.fn.timer_stop(M._time_limit_timer)
+    M._time_limit_timer = nil
+  end
 
   -- Send result back to game
   if M._request_id then
@@ -389,19 +389,151 @@ end
 -- Keystroke tracking state
 M._tracking = false
 M._keystroke_count = 0
+M._keystroke_log = {}
 M._start_time = nil
 M._on_key_ns = nil
 
+-- Submit/cancel mappings, which are not counted as keystrokes
+M._untracked_keys = {}
+M._pending_keys = {}
+
+-- Golf restrictions for the current challenge
+M._restrictions = nil
+M._violation = nil
+M._insert_entered = false
+
+--- Set golf restrictions (forbidden_keys, max_keystrokes, time_limit)
+---@param challenge table|nil Challenge data
+function M.set_restrictions(challenge)
+  M._restrictions = nil
+  if challenge and (challenge.forbidden_keys or challenge.max_keystrokes or challenge.time_limit) then
+    M._restrictions = {
+      forbidden_keys = challenge.forbidden_keys or {},
+      max_keystrokes = challenge.max_keystrokes or 0,
+      time_limit = challenge.time_limit or 0,
+    }
+  end
+end
+
+--- Check whether a key (in keytrans notation) breaks a restriction
+---@param key string
+---@param count number Keystroke count including this key
+---@return string|nil violation
+---@return boolean forbidden Whether the key itself is forbidden and should be discarded
+function M._check_key(key, count)
+  local r = M._restrictions
+  if not r then
+    return nil, false
+  end
+  for _, forbidden in ipairs(r.forbidden_keys) do
+    -- Special keys compare case-insensitively ("<esc>" == "<Esc>"), letters exactly
+    if forbidden == key or (#forbidden > 1 and forbidden:lower() == key:lower()) then
+      return "Forbidden key: " .. key, true
+    end
+  end
+  if r.max_keystrokes > 0 and count > r.max_keystrokes then
+    return string.format("Keystroke limit exceeded (%d max)", r.max_keystrokes), false
+  end
+  return nil, false
+end
+
+--- Count and log one key, recording any restriction it breaks
+---@param key string Raw key
+---@return boolean discard Whether the key is forbidden
+local function count_key(key)
+  M._keystroke_count = M._keystroke_count + 1
+  -- Log keys in readable form (e.g. "<Esc>") for validation by the game
+  local name = vim.fn.keytrans(key)
+  table.insert(M._keystroke_log, name)
+
+  local violation, forbidden = M._check_key(name, M._keystroke_count)
+  if violation then
+    M.record_violation(violation)
+  end
+  return forbidden
+end
+
+--- Track a typed key, holding back keys that may start a submit/cancel mapping
+---@param key string Raw key
+---@return boolean discard Whether the key should be thrown away
+function M._track_key(key)
+  if vim.api.nvim_get_mode().mode ~= "n" or #M._untracked_keys == 0 then
+    return count_key(key)
+  end
+
+  local held = M._pending_keys
+  table.insert(held, key)
+  local typed = table.concat(held)
+  for _, seq in ipairs(M._untracked_keys) do
+    if typed == seq then
+      M._pending_keys = {}
+      return false
+    end
+  end
+  for _, seq in ipairs(M._untracked_keys) do
+    if vim.startswith(seq, typed) then
+      return false
+    end
+  end
+
+  -- Not a mapping after all: count the held keys too, but only this one can still be discarded
+  M._pending_keys = {}
+  for i = 1, #held - 1 do
+    count_key(held[i])
+  end
+  return count_key(key)
+end
+
+--- Record a restriction violation (first one wins)
+---@param violation string
+function M.record_violation(violation)
+  if M._violation then
+    return
+  end
+  M._violation = violation
+  vim.schedule(function()
+    vim.notify(violation .. " - submit to finish", vim.log.levels.WARN)
+  end)
+end
+
+--- Record that insert mode was entered
+function M.record_insert()
+  M._insert_entered = true
+  local r = M._restrictions
+  if r and vim.tbl_contains(r.forbidden_keys, "insert_mode") then
+    M.record_violation("Insert mode is not allowed")
+  end
+end
+
+--- Get the current restriction violation, if any
+---@return string|nil
+function M.get_violation()
+  return M._violation
+end
+
 --- Start tracking keystrokes
-function M.start_tracking()
+---@param untracked string[]|nil Normal mode mappings not counted as keystrokes, e.g. "<CR>" or "<leader>q"
+function M.start_tracking(untracked)
+  M._untracked_keys = {}
+  for _, lhs in ipairs(untracked or {}) do
+    table.insert(M._untracked_keys, vim.api.nvim_replace_termcodes(lhs, true, true, true))
+  end
   M._keystroke_count = 0
+  M._keystroke_log = {}
   M._start_time = vim.loop.hrtime()
   M._tracking = true
+  M._violation = nil
+  M._insert_entered = false
+  M._pending_keys = {}
 
   -- Set up keystroke tracking via vim.on_key
-  M._on_key_ns = vim.on_key(function(key)
+  M._on_key_ns = vim.on_key(function(key, typed)
     if M._tracking and key ~= "" then
-      M._keystroke_count = M._keystroke_count + 1
+      -- Match mappings on what was typed; key is already the mapping's result
+      if M._track_key(typed ~= nil and typed ~= "" and typed or key) then
+        -- Discard forbidden keys (supported since Neovim 0.11); limit overruns still go through
+        return ""
+      end
     end
   end)
 end
@@ -409,10 +541,12 @@ end
 --- Stop tracking keystrokes
 ---@return number keystrokes Total keystroke count
 ---@return number time_ms Time elapsed in milliseconds
+---@return string[] keystroke_log Keys pressed, in order
 function M.stop_tracking()
   M._tracking = false
 
   local keystrokes = M._keystroke_count
+  local keystroke_log = M._keystroke_log
   local time_ms = 0
 
   if M._start_time then
@@ -427,9 +561,11 @@ function M.stop_tracking()
   end
 
   M._keystroke_count = 0
+  M._keystroke_log = {}
+  M._pending_keys = {}
   M._start_time = nil
 
-  return keystrokes, time_ms
+  return keystrokes, time_ms, keystroke_log
 end
 
 --- Validate a challenge completion
@@ -438,46 +574,33 @@ end
 ---@param final string[] Final buffer content
 ---@return table result Validation result with failure_details on failure
 function M.validate(challenge, initial, final)
-  local keystrokes, time_ms = M.stop_tracking()
+  local keystrokes, time_ms, keystroke_log = M.stop_tracking()
 
   local result = {
     success = false,
     keystroke_count = keystrokes,
+    keystrokes = keystroke_log,
     time_ms = time_ms,
     efficiency = 0,
     error = nil,
     failure_details = nil,
   }
 
-  -- Determine validation type
-  local validation_type = challenge.validation_type or "exact_match"
-  local validation_result
+  result.insert_entered = M._insert_entered
 
-  if validation_type == "exact_match" then
-    validation_result = M._validate_exact_match(challenge, final)
-  elseif validation_type == "contains" then
-    validation_result = M._validate_contains(challenge, final)
-  elseif validation_type == "function_exists" then
-    validation_result = M._validate_function_exists(challenge, final)
-  elseif validation_type == "pattern" then
-    validation_result = M._validate_pattern(challenge, final)
-  elseif validation_type == "different" then
-    local changed = not M._content_equal(initial, final)
-    if changed then
-      validation_result = { success = true }
-    else
-      validation_result = {
-        success = false,
-        validation_type = "different",
-        message = "Content must change from initial state",
-      }
-    end
-  elseif validation_type == "cursor_position" then
-    validation_result = M._validate_cursor_position(challenge)
-  elseif validation_type == "cursor_on_char" then
-    validation_result = M._validate_cursor_on_char(challenge, final)
-  else
-    result.error = "Unknown validation type: " .. validation_type
+  -- A broken golf restriction fails the challenge regardless of the buffer
+  if M._violation then
+    result.failure_details = {
+      success = false,
+      validation_type = "restriction",
+      message = M._violation,
+    }
+    return result
+  end
+
+  local validation_result, err = M._validate_check(challenge, initial, final)
+  if not validation_result then
+    result.error = err
     return result
   end
 
@@ -500,6 +623,84 @@ function M.validate(challenge, initial, final)
   return result
 end
 
+--- Run a single check (or a composite of checks) against the final buffer
+---@param check table Challenge or sub-check with validation_type
+---@param initial string[]
+---@param final string[]
+---@return table|nil validation_result With success and failure details
+---@return string|nil error Error for unknown validation types
+function M._validate_check(check, initial, final)
+  local validation_type = check.validation_type or "exact_match"
+
+  if validation_type == "exact_match" then
+    return M._validate_exact_match(check, final)
+  elseif validation_type == "contains" then
+    return M._validate_contains(check, final)
+  elseif validation_type == "function_exists" then
+    return M._validate_function_exists(check, final)
+  elseif validation_type == "pattern" then
+    return M._validate_pattern(check, final)
+  elseif validation_type == "different" then
+    if not M._content_equal(initial, final) then
+      return { success = true }
+    end
+    return {
+      success = false,
+      validation_type = "different",
+      message = "Content must change from initial state",
+    }
+  elseif validation_type == "cursor_position" then
+    return M._validate_cursor_position(check)
+  elseif validation_type == "cursor_on_char" then
+    return M._validate_cursor_on_char(check, final)
+  elseif validation_type == "all_of" or validation_type == "any_of" then
+    return M._validate_composite(check, initial, final)
+  elseif M._go_validation_types[validation_type] then
+    return M._validate_go(check, final)
+  end
+
+  return nil, "Unknown validation type: " .. validation_type
+end
+
+--- Validate a composite check (all_of / any_of)
+---@param check table
+---@param initial string[]
+---@param final string[]
+---@return table|nil validation_result With success and failure details
+---@return string|nil error
+function M._validate_composite(check, initial, final)
+  local checks = check.checks or {}
+  if #checks == 0 then
+    return {
+      success = false,
+      validation_type = check.validation_type,
+      message = "No checks defined in challenge",
+    }
+  end
+
+  local first_failure
+  for _, sub in ipairs(checks) do
+    local sub_result, err = M._validate_check(sub, initial, final)
+    if not sub_result then
+      return nil, err
+    end
+    if sub_result.success and check.validation_type == "any_of" then
+      return { success = true }
+    end
+    if not sub_result.success then
+      first_failure = first_failure or sub_result
+      if check.validation_type == "all_of" then
+        return sub_result
+      end
+    end
+  end
+
+  if check.validation_type == "all_of" then
+    return { success = true }
+  end
+  return first_failure
+end
+
 --- Validate exact match
 ---@param challenge table
 ---@param final string[]
@@ -710,6 +911,70 @@ function M._validate_cursor_on_char(challenge, final)
   }
 end
 
+--- Validation types the game checks with Go's parser and type checker
+M._go_validation_types = {
+  go_parses = true,
+  go_function_signature = true,
+  go_identifier_renamed = true,
+  go_no_unused_vars = true,
+}
+
+--- Blank out Go comments and string literals, which hold no identifiers
+---@param content string
+---@return string
+function M._strip_go_literals(content)
+  content = content:gsub("/%*.-%*/", " ")
+  content = content:gsub("//[^\n]*", " ")
+  content = content:gsub('"[^"\n]*"', '""')
+  content = content:gsub("`[^`]*`", "``")
+  return content
+end
+
+--- Check whether Go code uses an identifier
+---@param code string Code with literals stripped
+---@param name string
+---@return boolean
+local function has_go_identifier(code, name)
+  return code:find("%f[%w_]" .. name .. "%f[^%w_]") ~= nil
+end
+
+--- Validate a Go validation type. The game parses and type-checks the code
+--- and its verdict decides; locally only what matching the text can tell is
+--- checked, for immediate failure details.
+---@param check table
+---@param final string[]
+---@return table validation_result With success and failure details
+function M._validate_go(check, final)
+  local validation_type = check.validation_type
+  local code = M._strip_go_literals(table.concat(final, "\n"))
+  local function fail(message)
+    return { success = false, validation_type = validation_type, message = message }
+  end
+
+  if validation_type == "go_identifier_renamed" then
+    if not check.old_name or not check.new_name then
+      return fail("No identifiers defined in challenge")
+    end
+    if has_go_identifier(code, check.old_name) then
+      return fail(string.format("Identifier '%s' is still used", check.old_name))
+    end
+    if not has_go_identifier(code, check.new_name) then
+      return fail(string.format("Identifier '%s' not found", check.new_name))
+    end
+  elseif validation_type == "go_function_signature" then
+    local name = check.function_name
+    if not name then
+      return fail("No function name defined in challenge")
+    end
+    if not code:find("func%s+" .. name .. "%s*%(") and not code:find("func%s*%b()%s*" .. name .. "%s*%(") then
+      return fail(string.format("Function '%s' not found", name))
+    end
+  end
+
+  -- Parse errors, signatures and unused variables are left to the game
+  return { success = true }
+end
+
 --- Check if two content arrays are equal
 ---@param a string[]
 ---@param b string[]
@@ -5,7 +5,7 @@
 ---@field keybind_skip string Keybind to skip challenge (default: "<leader>ks")
 ---@field keybind_submit string Keybind to submit challenge in buffer (default: "<CR>")
 ---@field keybind_cancel string Keybind to cancel challenge in buffer (default: "<Esc>")
----@field difficulty string Difficulty level: "easy", "normal", "hard" (default: "normal")
+---@field difficulty string Difficulty level: "easy", "normal", "hard" or a custom profile (default: "normal")
 ---@field game_speed number Game speed multiplier: 0.5, 1.0, 1.5, 2.0 (default: 1.0)
 ---@field use_nerd_fonts boolean Use Nerd Font icons (default: true)
 ---@field starting_gold number Initial gold amount (default: 200)
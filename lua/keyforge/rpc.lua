@@ -300,6 +300,8 @@ function M.handle_gold_update(params)
     msg = string.format("+%dg from defeating enemy", earned)
   elseif source == "wave_bonus" then
     msg = string.format("+%dg wave completion bonus!", earned)
+  elseif source == "interest" then
+    msg = string.format("+%dg interest on banked gold", earned)
   else
     msg = string.format("+%dg", earned)
   end
@@ -387,6 +389,26 @@ function M.handle_victory(params)
   end)
 end
 
+--- Messages for boss_phase notifications, by phase kind
+local boss_phase_messages = {
+  minions = "Boss summoned minions!",
+  speed_burst = "Boss is charging ahead!",
+  disable_towers = "Boss disabled your nearest towers!",
+  shield = "Boss raised a shield! Complete a challenge to break it",
+  shield_broken = "Boss shield broken!",
+}
+
+--- Handler for boss_phase notifications from the game
+---@param params table BossPhase params
+function M.handle_boss_phase(params)
+  local msg = boss_phase_messages[params.kind] or "Boss changed phase"
+  local level = params.shielded and vim.log.levels.WARN or vim.log.levels.INFO
+
+  vim.schedule(function()
+    vim.notify(string.format("%s (%d/%d hp)", msg, params.health or 0, params.max_health or 0), level)
+  end)
+end
+
 --- Handler for game_ready notification from the game
 ---@param _params table GameReady params (reserved for future use)
 function M.handle_game_ready(_params)
@@ -407,6 +429,7 @@ function M.register_handlers()
   M.on("game_over", M.handle_game_over)
   M.on("victory", M.handle_victory)
   M.on("game_ready", M.handle_game_ready)
+  M.on("boss_phase", M.handle_boss_phase)
 end
 
 return M
@@ -160,6 +160,42 @@ describe("challenges", function()
     end)
   end)
 
+  describe("validate_go", function()
+    it("should pass a rename that leaves the old name only in comments and strings", function()
+      local check = { validation_type = "go_identifier_renamed", old_name = "n", new_name = "count" }
+      local final = { "// n counts words", "count := 0", 'fmt.Println("n", count)' }
+      local result = challenges._validate_go(check, final)
+      assert.is_true(result.success)
+    end)
+
+    it("should fail a rename while the old identifier is used", function()
+      local check = { validation_type = "go_identifier_renamed", old_name = "n", new_name = "count" }
+      local final = { "count := 0", "n++" }
+      local result = challenges._validate_go(check, final)
+      assert.is_false(result.success)
+      assert.equals("go_identifier_renamed", result.validation_type)
+    end)
+
+    it("should find a Go function or method by name", function()
+      local check = { validation_type = "go_function_signature", function_name = "max" }
+      assert.is_true(challenges._validate_go(check, { "func max(a, b int) int {", "}" }).success)
+      assert.is_true(challenges._validate_go(check, { "func (s *Set) max() int {", "}" }).success)
+      assert.is_false(challenges._validate_go(check, { "func min(a, b int) int {", "}" }).success)
+    end)
+
+    it("should be reached through validate instead of failing as unknown", function()
+      local challenge = {
+        validation_type = "all_of",
+        checks = {
+          { validation_type = "go_parses" },
+          { validation_type = "go_no_unused_vars" },
+        },
+      }
+      local result = challenges.validate(challenge, { "x := 1" }, { "y := 2" })
+      assert.is_true(result.success)
+    end)
+  end)
+
   describe("validate_pattern", function()
     it("should match regex pattern", function()
       local challenge = { pattern = "const%s+%w+%s*=" }
@@ -267,6 +303,114 @@ describe("challenges", function()
       assert.is_not_nil(result.failure_details)
       assert.equals("different", result.failure_details.validation_type)
     end)
+
+    it("should pass all_of when every check passes", function()
+      local challenge = {
+        validation_type = "all_of",
+        checks = {
+          { validation_type = "contains", expected_content = "hello" },
+          { validation_type = "different" },
+        },
+      }
+
+      local result = challenges.validate(challenge, { "start" }, { "hello world" })
+      assert.is_true(result.success)
+    end)
+
+    it("should fail all_of with the first failing check's details", function()
+      local challenge = {
+        validation_type = "all_of",
+        checks = {
+          { validation_type = "contains", expected_content = "hello" },
+          { validation_type = "exact_match", expected_buffer = "goodbye" },
+        },
+      }
+
+      local result = challenges.validate(challenge, { "start" }, { "hello world" })
+      assert.is_false(result.success)
+      assert.equals("exact_match", result.failure_details.validation_type)
+    end)
+
+    it("should pass any_of when one check passes", function()
+      local challenge = {
+        validation_type = "any_of",
+        checks = {
+          { validation_type = "exact_match", expected_buffer = "goodbye" },
+          { validation_type = "contains", expected_content = "hello" },
+        },
+      }
+
+      local result = challenges.validate(challenge, { "start" }, { "hello world" })
+      assert.is_true(result.success)
+    end)
+  end)
+
+  describe("restrictions", function()
+    after_each(function()
+      challenges.set_restrictions(nil)
+    end)
+
+    it("should allow any key without restrictions", function()
+      challenges.set_restrictions({})
+      assert.is_nil(challenges._check_key("h", 1))
+    end)
+
+    it("should flag forbidden keys", function()
+      challenges.set_restrictions({ forbidden_keys = { "h", "<Left>" } })
+      assert.equals("Forbidden key: h", challenges._check_key("h", 1))
+      assert.equals("Forbidden key: <left>", challenges._check_key("<left>", 1))
+      assert.is_nil(challenges._check_key("H", 1))
+    end)
+
+    it("should flag exceeding max keystrokes", function()
+      challenges.set_restrictions({ max_keystrokes = 2 })
+      assert.is_nil(challenges._check_key("w", 2))
+      assert.is_not_nil(challenges._check_key("w", 3))
+    end)
+
+    it("should discard forbidden keys but let keys past the limit through", function()
+      challenges.set_restrictions({ forbidden_keys = { "h" }, max_keystrokes = 1 })
+      challenges.start_tracking()
+      assert.is_true(challenges._track_key("h"))
+      assert.is_false(challenges._track_key("w"))
+      assert.is_not_nil(challenges.get_violation())
+      local keystrokes = challenges.stop_tracking()
+      assert.equals(2, keystrokes)
+    end)
+
+    it("should not count submit and cancel mappings", function()
+      challenges.set_restrictions({ max_keystrokes = 1 })
+      challenges.start_tracking({ "<CR>", "<leader>q" })
+      local leader = vim.api.nvim_replace_termcodes("<leader>", true, true, true)
+      assert.is_false(challenges._track_key("x"))
+      assert.is_false(challenges._track_key(leader))
+      assert.is_false(challenges._track_key("q"))
+      assert.is_false(challenges._track_key("\r"))
+      assert.is_nil(challenges.get_violation())
+      local keystrokes, _, log = challenges.stop_tracking()
+      assert.equals(1, keystrokes)
+      assert.same({ "x" }, log)
+    end)
+
+    it("should count held keys that turn out not to be a mapping", function()
+      challenges.start_tracking({ "<leader>q" })
+      local leader = vim.api.nvim_replace_termcodes("<leader>", true, true, true)
+      challenges._track_key(leader)
+      challenges._track_key("w")
+      local keystrokes = challenges.stop_tracking()
+      assert.equals(2, keystrokes)
+    end)
+
+    it("should fail validation after a violation", function()
+      challenges.set_restrictions({ forbidden_keys = { "insert_mode" } })
+      challenges.start_tracking()
+      challenges.record_insert()
+
+      local result = challenges.validate({ validation_type = "different" }, { "a" }, { "b" })
+      assert.is_false(result.success)
+      assert.equals("restriction", result.failure_details.validation_type)
+      assert.is_true(result.insert_entered)
+    end)
   end)
 
   describe("calculate_reward", function()
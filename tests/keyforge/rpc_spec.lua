@@ -70,6 +70,13 @@ describe("rpc", function()
     end)
   end)
 
+  describe("register_handlers", function()
+    it("should register the boss_phase handler", function()
+      rpc.register_handlers()
+      assert.equals(rpc.handle_boss_phase, rpc._handlers["boss_phase"])
+    end)
+  end)
+
   describe("_handle_message", function()
     it("should call handler for notification", function()
       local received_params = nil